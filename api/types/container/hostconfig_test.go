@@ -87,6 +87,34 @@ func TestValidateRestartPolicy(t *testing.T) {
 			input:       RestartPolicy{Name: "unknown"},
 			expectedErr: "invalid restart policy: unknown policy 'unknown'; use one of 'no', 'always', 'on-failure', or 'unless-stopped'",
 		},
+		{
+			name:  "on-failure with ExitCodes",
+			input: RestartPolicy{Name: RestartPolicyOnFailure, ExitCodes: []int{1, 2}},
+		},
+		{
+			name:  "on-failure with ExcludeExitCodes",
+			input: RestartPolicy{Name: RestartPolicyOnFailure, ExcludeExitCodes: []int{0, 143}},
+		},
+		{
+			name:        "on-failure with out-of-range ExitCodes",
+			input:       RestartPolicy{Name: RestartPolicyOnFailure, ExitCodes: []int{256}},
+			expectedErr: "invalid restart policy: exit code 256 in ExitCodes is out of range (0-255)",
+		},
+		{
+			name:        "on-failure with negative ExcludeExitCodes",
+			input:       RestartPolicy{Name: RestartPolicyOnFailure, ExcludeExitCodes: []int{-1}},
+			expectedErr: "invalid restart policy: exit code -1 in ExcludeExitCodes is out of range (0-255)",
+		},
+		{
+			name:        "on-failure with overlapping ExitCodes and ExcludeExitCodes",
+			input:       RestartPolicy{Name: RestartPolicyOnFailure, ExitCodes: []int{1, 2}, ExcludeExitCodes: []int{2, 3}},
+			expectedErr: "invalid restart policy: exit code 2 cannot be in both ExitCodes and ExcludeExitCodes",
+		},
+		{
+			name:        "always with ExitCodes",
+			input:       RestartPolicy{Name: RestartPolicyAlways, ExitCodes: []int{1}},
+			expectedErr: "invalid restart policy: exit codes can only be used with 'on-failure'",
+		},
 	}
 
 	for _, tc := range tests {
@@ -102,6 +130,52 @@ func TestValidateRestartPolicy(t *testing.T) {
 	}
 }
 
+func TestRestartPolicyShouldRestartExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   RestartPolicy
+		exitCode int
+		want     bool
+	}{
+		{
+			name:     "no restrictions restarts for any code",
+			policy:   RestartPolicy{Name: RestartPolicyOnFailure},
+			exitCode: 42,
+			want:     true,
+		},
+		{
+			name:     "ExitCodes restarts for a listed code",
+			policy:   RestartPolicy{Name: RestartPolicyOnFailure, ExitCodes: []int{1, 2}},
+			exitCode: 1,
+			want:     true,
+		},
+		{
+			name:     "ExitCodes does not restart for an unlisted code",
+			policy:   RestartPolicy{Name: RestartPolicyOnFailure, ExitCodes: []int{1, 2}},
+			exitCode: 3,
+			want:     false,
+		},
+		{
+			name:     "ExcludeExitCodes does not restart for a listed code",
+			policy:   RestartPolicy{Name: RestartPolicyOnFailure, ExcludeExitCodes: []int{3}},
+			exitCode: 3,
+			want:     false,
+		},
+		{
+			name:     "ExcludeExitCodes restarts for an unlisted code",
+			policy:   RestartPolicy{Name: RestartPolicyOnFailure, ExcludeExitCodes: []int{3}},
+			exitCode: 1,
+			want:     true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Check(t, is.Equal(tc.want, tc.policy.ShouldRestartExitCode(tc.exitCode)))
+		})
+	}
+}
+
 // isInvalidParameter is a minimal implementation of [github.com/containerd/errdefs.IsInvalidArgument],
 // because this was the only import of that package in api/types, which is the
 // package imported by external users.
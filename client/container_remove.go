@@ -10,6 +10,10 @@ type ContainerRemoveOptions struct {
 	RemoveVolumes bool
 	RemoveLinks   bool
 	Force         bool
+	// ForceDependents stops and removes containers that depend on this one
+	// through --network=container:<id>, instead of the removal being
+	// refused while they're running.
+	ForceDependents bool
 }
 
 // ContainerRemoveResult holds the result of [Client.ContainerRemove],
@@ -36,6 +40,10 @@ func (cli *Client) ContainerRemove(ctx context.Context, containerID string, opti
 		query.Set("force", "1")
 	}
 
+	if options.ForceDependents {
+		query.Set("force-dependents", "1")
+	}
+
 	resp, err := cli.delete(ctx, "/containers/"+containerID, query, nil)
 	defer ensureReaderClosed(resp)
 	if err != nil {
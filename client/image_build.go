@@ -175,5 +175,8 @@ func (cli *Client) imageBuildOptionsToQuery(_ context.Context, options ImageBuil
 		}
 		query.Set("outputs", string(outputsJSON))
 	}
+	if options.StrictBuildArgs {
+		query.Set("strictbuildargs", "1")
+	}
 	return query, nil
 }
@@ -0,0 +1,76 @@
+//go:build linux
+
+package daemon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+
+	aaprofile "github.com/moby/profiles/apparmor"
+)
+
+// derivedAppArmorProfileName deterministically names the profile generated
+// for base plus tweaks, so that containers created with the same base
+// profile and tweak set reuse the same loaded profile instead of generating
+// and loading a new one on every create.
+func derivedAppArmorProfileName(base string, tweaks []string) string {
+	sorted := append([]string(nil), tweaks...)
+	sort.Strings(sorted)
+
+	h := sha256.New()
+	fmt.Fprint(h, base)
+	for _, t := range sorted {
+		fmt.Fprintf(h, "|%s", t)
+	}
+	return fmt.Sprintf("%s-tweaked-%s", base, hex.EncodeToString(h.Sum(nil))[:12])
+}
+
+// generateAppArmorTweaks renders the additive rules for each "kind:path"
+// tweak in tweaks (as validated by parseAppArmorTweaks) into AppArmor profile
+// syntax, in sorted order.
+func generateAppArmorTweaks(tweaks []string) string {
+	sorted := append([]string(nil), tweaks...)
+	sort.Strings(sorted)
+
+	var b strings.Builder
+	for _, t := range sorted {
+		kind, path, _ := strings.Cut(t, ":")
+		mode := "r"
+		if kind == "allow-write" {
+			mode = "rw"
+		}
+		fmt.Fprintf(&b, "  %s %s,\n", path, mode)
+	}
+	return b.String()
+}
+
+// loadTweakedAppArmorProfile generates a profile named after base and tweaks
+// that includes base and additionally grants the access described by
+// tweaks, then loads it into the kernel, returning its name. base must
+// already be loaded, since the generated profile includes it by name.
+func loadTweakedAppArmorProfile(base string, tweaks []string) (string, error) {
+	name := derivedAppArmorProfileName(base, tweaks)
+
+	loaded, err := aaprofile.IsLoaded(name)
+	if err != nil {
+		return "", fmt.Errorf("could not check if %s AppArmor profile was loaded: %s", name, err)
+	}
+	if loaded {
+		return name, nil
+	}
+
+	profile := fmt.Sprintf("profile %s flags=(attach_disconnected,mediate_deleted) {\n  #include <%s>\n%s}\n",
+		name, base, generateAppArmorTweaks(tweaks))
+
+	c := exec.CommandContext(context.Background(), "apparmor_parser", "-Kr")
+	c.Stdin = strings.NewReader(profile)
+	if out, err := c.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("running %v failed with output: %s\nerror: %w", c.Args, out, err)
+	}
+	return name, nil
+}
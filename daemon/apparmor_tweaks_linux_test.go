@@ -0,0 +1,26 @@
+package daemon
+
+import (
+	"testing"
+
+	aaprofile "github.com/moby/profiles/apparmor"
+	"gotest.tools/v3/assert"
+	"gotest.tools/v3/skip"
+)
+
+// TestLoadTweakedAppArmorProfile asserts that loadTweakedAppArmorProfile
+// generates and loads a profile combining the base profile with the
+// requested tweaks, under a deterministic derived name.
+func TestLoadTweakedAppArmorProfile(t *testing.T) {
+	skip.If(t, !appArmorSupported(), "AppArmor is not supported/enabled on this host")
+
+	assert.NilError(t, installDefaultAppArmorProfile())
+
+	name, err := loadTweakedAppArmorProfile(defaultAppArmorProfile, []string{"allow-read:/tmp/apparmor-tweaks-test/**"})
+	assert.NilError(t, err)
+	assert.Equal(t, name, derivedAppArmorProfileName(defaultAppArmorProfile, []string{"allow-read:/tmp/apparmor-tweaks-test/**"}))
+
+	loaded, err := aaprofile.IsLoaded(name)
+	assert.NilError(t, err)
+	assert.Check(t, loaded, "expected derived profile %q to be loaded", name)
+}
@@ -0,0 +1,24 @@
+//go:build unix
+
+package daemon
+
+import (
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+)
+
+// statfs is a variable so that it can be stubbed out in tests.
+var statfs = unix.Statfs
+
+// checkMinFreeInodes returns an error if the filesystem containing path has
+// fewer than minFreeInodes free inodes.
+func checkMinFreeInodes(path string, minFreeInodes uint64) error {
+	var buf unix.Statfs_t
+	if err := statfs(path, &buf); err != nil {
+		return errors.Wrapf(err, "failed to stat build root filesystem %q", path)
+	}
+	if buf.Ffree < minFreeInodes {
+		return errors.Errorf("insufficient free inodes on build root filesystem %q: have %d, need at least %d", path, buf.Ffree, minFreeInodes)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+//go:build unix
+
+package daemon
+
+import (
+	"testing"
+
+	"golang.org/x/sys/unix"
+	"gotest.tools/v3/assert"
+)
+
+func TestCheckMinFreeInodes(t *testing.T) {
+	defer func(orig func(path string, buf *unix.Statfs_t) error) { statfs = orig }(statfs)
+
+	statfs = func(path string, buf *unix.Statfs_t) error {
+		buf.Ffree = 100
+		return nil
+	}
+
+	assert.NilError(t, checkMinFreeInodes("/build/root", 50))
+
+	err := checkMinFreeInodes("/build/root", 200)
+	assert.ErrorContains(t, err, "insufficient free inodes")
+}
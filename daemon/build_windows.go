@@ -0,0 +1,6 @@
+package daemon
+
+// checkMinFreeInodes is a no-op on Windows, which has no inode concept.
+func checkMinFreeInodes(path string, minFreeInodes uint64) error {
+	return nil
+}
@@ -4,7 +4,9 @@
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
+	"github.com/containerd/platforms"
 	"github.com/distribution/reference"
 	"github.com/moby/moby/api/types/build"
 	"github.com/moby/moby/api/types/events"
@@ -14,6 +16,8 @@
 	"github.com/moby/moby/v2/daemon/internal/image"
 	"github.com/moby/moby/v2/daemon/internal/stringid"
 	"github.com/moby/moby/v2/daemon/server/buildbackend"
+	"github.com/moby/moby/v2/errdefs"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc"
 )
@@ -50,40 +54,51 @@ func (b *Backend) RegisterGRPC(s *grpc.Server) {
 }
 
 // Build builds an image from a Source
-func (b *Backend) Build(ctx context.Context, config buildbackend.BuildConfig) (string, error) {
+func (b *Backend) Build(ctx context.Context, config buildbackend.BuildConfig) (string, *build.CacheSummary, error) {
 	options := config.Options
 	useBuildKit := options.Version == build.BuilderBuildKit
 
+	if err := validateAttestations(options, useBuildKit); err != nil {
+		return "", nil, err
+	}
+
+	if err := b.validatePlatform(options); err != nil {
+		return "", nil, err
+	}
+
 	tags, err := sanitizeRepoAndTags(options.Tags)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	var buildResult *builder.Result
 	if useBuildKit {
+		if b.buildkit == nil {
+			return "", nil, errdefs.NotImplemented(errors.New("BuildKit is disabled in the daemon configuration"))
+		}
 		buildResult, err = b.buildkit.Build(ctx, config)
 		if err != nil {
-			return "", err
+			return "", nil, err
 		}
 	} else {
 		buildResult, err = b.builder.Build(ctx, config)
 		if err != nil {
-			return "", err
+			return "", nil, err
 		}
 	}
 
 	if buildResult == nil {
-		return "", nil
+		return "", nil, nil
 	}
 
 	imageID := buildResult.ImageID
 	if options.Squash {
 		if imageID, err = squashBuild(buildResult, b.imageComponent); err != nil {
-			return "", err
+			return "", nil, err
 		}
 		if config.ProgressWriter.AuxFormatter != nil {
 			if err = config.ProgressWriter.AuxFormatter.Emit("moby.image.id", build.Result{ID: imageID}); err != nil {
-				return "", err
+				return "", nil, err
 			}
 		}
 	}
@@ -93,7 +108,7 @@ func (b *Backend) Build(ctx context.Context, config buildbackend.BuildConfig) (s
 		_, _ = fmt.Fprintf(stdout, "Successfully built %s\n", stringid.TruncateID(imageID))
 		err = tagImages(ctx, b.imageComponent, config.ProgressWriter.StdoutFormatter, image.ID(imageID), tags)
 	}
-	return imageID, err
+	return imageID, buildResult.CacheSummary, err
 }
 
 // PruneCache removes all cached build sources
@@ -115,6 +130,59 @@ func (b *Backend) Cancel(ctx context.Context, id string) error {
 	return b.buildkit.Cancel(ctx, id)
 }
 
+// CancelSession cancels every in-flight build associated with sessionID and
+// returns how many builds were cancelled.
+func (b *Backend) CancelSession(ctx context.Context, sessionID string) (int, error) {
+	return b.buildkit.CancelSession(ctx, sessionID)
+}
+
+// validateAttestations returns an error if options requests SBOM/provenance
+// attestations that the selected builder can't produce: attestation
+// generation is only implemented in BuildKit, so the classic builder must
+// reject the build rather than silently skip them.
+func validateAttestations(options *buildbackend.BuildOptions, useBuildKit bool) error {
+	if len(options.Attestations) > 0 && !useBuildKit {
+		return errdefs.InvalidParameter(errors.New("SBOM/provenance attestations are not supported by the classic builder; use BuildKit instead"))
+	}
+	return nil
+}
+
+// validatePlatform checks that options.Platform, when set, is buildable by
+// this daemon: either its native platform, or a platform it can emulate
+// through a registered binfmt handler (e.g. via QEMU). Doing this up front
+// turns an unbuildable platform into an immediate, actionable error instead
+// of a failure deep inside the build.
+func (b *Backend) validatePlatform(options *buildbackend.BuildOptions) error {
+	if options.Platform == "" || b.buildkit == nil {
+		return nil
+	}
+	return validateBuildPlatform(options.Platform, b.buildkit.SupportedPlatforms(false))
+}
+
+// validateBuildPlatform returns an error if platform doesn't parse, or
+// doesn't match any of the buildable platforms.
+func validateBuildPlatform(platform string, buildable []ocispec.Platform) error {
+	requested, err := platforms.Parse(platform)
+	if err != nil {
+		return errdefs.InvalidParameter(errors.Wrap(err, "invalid platform"))
+	}
+
+	for _, p := range buildable {
+		if platforms.NewMatcher(p).Match(requested) {
+			return nil
+		}
+	}
+
+	formatted := make([]string, 0, len(buildable))
+	for _, p := range buildable {
+		formatted = append(formatted, platforms.Format(p))
+	}
+	return errdefs.InvalidParameter(errors.Errorf(
+		"the requested platform %q is not buildable by this daemon; no matching native or emulated (binfmt) platform found; buildable platforms: %s",
+		platforms.Format(requested), strings.Join(formatted, ", "),
+	))
+}
+
 func squashBuild(build *builder.Result, imageComponent ImageComponent) (string, error) {
 	var fromID string
 	if build.FromImage != nil {
@@ -0,0 +1,54 @@
+package build
+
+import (
+	"context"
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/api/types/build"
+	"github.com/moby/moby/v2/daemon/server/buildbackend"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"gotest.tools/v3/assert"
+)
+
+func TestValidateAttestationsRejectedByClassicBuilder(t *testing.T) {
+	err := validateAttestations(&buildbackend.BuildOptions{Attestations: []string{"sbom"}}, false)
+	assert.ErrorContains(t, err, "not supported by the classic builder")
+}
+
+func TestValidateAttestationsAllowedByBuildKit(t *testing.T) {
+	err := validateAttestations(&buildbackend.BuildOptions{Attestations: []string{"sbom", "provenance"}}, true)
+	assert.NilError(t, err)
+}
+
+func TestValidateAttestationsNoneRequested(t *testing.T) {
+	assert.NilError(t, validateAttestations(&buildbackend.BuildOptions{}, false))
+	assert.NilError(t, validateAttestations(&buildbackend.BuildOptions{}, true))
+}
+
+func TestValidateBuildPlatformNative(t *testing.T) {
+	buildable := []ocispec.Platform{{OS: "linux", Architecture: "amd64"}}
+	assert.NilError(t, validateBuildPlatform("linux/amd64", buildable))
+}
+
+func TestValidateBuildPlatformUnsupportedEmulated(t *testing.T) {
+	buildable := []ocispec.Platform{{OS: "linux", Architecture: "amd64"}}
+	err := validateBuildPlatform("linux/s390x", buildable)
+	assert.ErrorContains(t, err, "not buildable by this daemon")
+	assert.ErrorContains(t, err, "linux/amd64")
+	assert.Check(t, cerrdefs.IsInvalidArgument(err))
+}
+
+func TestValidateBuildPlatformInvalid(t *testing.T) {
+	err := validateBuildPlatform("not a platform", nil)
+	assert.Check(t, cerrdefs.IsInvalidArgument(err))
+}
+
+func TestBuildWithBuildKitDisabledReturnsNotImplemented(t *testing.T) {
+	b := &Backend{}
+	_, _, err := b.Build(context.Background(), buildbackend.BuildConfig{
+		Options: &buildbackend.BuildOptions{Version: build.BuilderBuildKit},
+	})
+	assert.ErrorContains(t, err, "BuildKit is disabled")
+	assert.Check(t, cerrdefs.IsNotImplemented(err))
+}
@@ -8,6 +8,7 @@
 	"context"
 	"io"
 
+	"github.com/moby/moby/api/types/build"
 	"github.com/moby/moby/api/types/container"
 	containerpkg "github.com/moby/moby/v2/daemon/container"
 	"github.com/moby/moby/v2/daemon/internal/image"
@@ -48,6 +49,14 @@ type Backend interface {
 	ContainerCreateWorkdir(containerID string) error
 	CreateImage(ctx context.Context, config []byte, parent string, contentStoreDigest digest.Digest) (Image, error)
 
+	// BuildCommandAllowlist returns the configured list of binary names that
+	// RUN instructions are permitted to invoke, or nil if unrestricted.
+	BuildCommandAllowlist() []string
+
+	// BuildMaxLayers returns the configured maximum number of layers a build
+	// may produce, or 0 if unlimited.
+	BuildMaxLayers() int
+
 	ImageCacheBuilder
 }
 
@@ -74,6 +83,10 @@ type ExecBackend interface {
 type Result struct {
 	ImageID   string
 	FromImage Image
+	// CacheSummary reports cache-hit statistics for the build. It is nil for
+	// builders that do not track cache usage this way (e.g. BuildKit, which
+	// reports cache information through its own solve status).
+	CacheSummary *build.CacheSummary
 }
 
 // ImageCacheBuilder represents a generator for stateful image cache.
@@ -64,9 +64,11 @@ func (b *BuildArgs) MergeReferencedArgs(other *BuildArgs) {
 	}
 }
 
-// WarnOnUnusedBuildArgs checks if there are any leftover build-args that were
-// passed but not consumed during build. Print a warning, if there are any.
-func (b *BuildArgs) WarnOnUnusedBuildArgs(out io.Writer) {
+// UnusedArgs returns the build-args that were passed (including those
+// mentioned but left unset, e.g. "--build-arg FOO" with no matching "FOO="
+// in the environment) but never referenced by an ARG instruction anywhere
+// in the Dockerfile.
+func (b *BuildArgs) UnusedArgs() []string {
 	var leftoverArgs []string
 	for arg := range b.argsFromOptions {
 		_, isReferenced := b.referencedArgs[arg]
@@ -75,8 +77,14 @@ func (b *BuildArgs) WarnOnUnusedBuildArgs(out io.Writer) {
 			leftoverArgs = append(leftoverArgs, arg)
 		}
 	}
-	if len(leftoverArgs) > 0 {
-		sort.Strings(leftoverArgs)
+	sort.Strings(leftoverArgs)
+	return leftoverArgs
+}
+
+// WarnOnUnusedBuildArgs checks if there are any leftover build-args that were
+// passed but not consumed during build. Print a warning, if there are any.
+func (b *BuildArgs) WarnOnUnusedBuildArgs(out io.Writer) {
+	if leftoverArgs := b.UnusedArgs(); len(leftoverArgs) > 0 {
 		fmt.Fprintf(out, "[Warning] One or more build-args %v were not consumed\n", leftoverArgs)
 	}
 }
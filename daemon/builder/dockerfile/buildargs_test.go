@@ -87,6 +87,20 @@ func TestWarnOnUnusedBuildArgs(t *testing.T) {
 	assert.Check(t, is.Contains(out, "ThisArgIsNotUsed"))
 }
 
+func TestUnusedArgs(t *testing.T) {
+	buildArgs := NewBuildArgs(map[string]*string{
+		"ThisArgIsUsed":     strPtr("fromopt1"),
+		"ThisArgIsNotUsed":  strPtr("fromopt2"),
+		"MentionedButUnset": nil,
+		"HTTPS_PROXY":       strPtr("referenced builtin"),
+		"HTTP_PROXY":        strPtr("unreferenced builtin"),
+	})
+	buildArgs.AddArg("ThisArgIsUsed", nil)
+	buildArgs.AddArg("HTTPS_PROXY", nil)
+
+	assert.Check(t, is.DeepEqual(buildArgs.UnusedArgs(), []string{"MentionedButUnset", "ThisArgIsNotUsed"}))
+}
+
 func TestIsUnreferencedBuiltin(t *testing.T) {
 	buildArgs := NewBuildArgs(map[string]*string{
 		"ThisArgIsUsed":    strPtr("fromopt1"),
@@ -214,7 +214,14 @@ func (b *Builder) build(ctx context.Context, source builder.Source, dockerfile *
 		buildsFailed.WithValues(metricsDockerfileEmptyError).Inc()
 		return nil, errors.New("No image was generated. Is your Dockerfile empty?")
 	}
-	return &builder.Result{ImageID: state.imageID, FromImage: state.baseImage}, nil
+
+	total, hits := b.imageProber.Stats()
+	cacheSummary := &build.CacheSummary{
+		Total:     total,
+		CacheHits: hits,
+		Executed:  total - hits,
+	}
+	return &builder.Result{ImageID: state.imageID, FromImage: state.baseImage, CacheSummary: cacheSummary}, nil
 }
 
 func emitImageID(aux buildbackend.AuxEmitter, state *dispatchState) error {
@@ -306,7 +313,13 @@ func (b *Builder) dispatchDockerfileWithCancellation(ctx context.Context, parseR
 			return nil, err
 		}
 	}
-	buildArgs.WarnOnUnusedBuildArgs(b.Stdout)
+	if b.options.StrictBuildArgs {
+		if leftoverArgs := buildArgs.UnusedArgs(); len(leftoverArgs) > 0 {
+			return nil, errdefs.InvalidParameter(errors.Errorf("one or more build-args %v were not consumed, and --build-arg strict mode is enabled", leftoverArgs))
+		}
+	} else {
+		buildArgs.WarnOnUnusedBuildArgs(b.Stdout)
+	}
 	return request.state, nil
 }
 
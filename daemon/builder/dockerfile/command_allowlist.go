@@ -0,0 +1,53 @@
+package dockerfile
+
+import (
+	"path"
+	"regexp"
+	"strings"
+)
+
+// shellCommandSeparators splits a shell-form RUN command line into its
+// individual invocations so each one can be checked against the allowlist.
+// This is intentionally coarse: it does not parse the shell grammar, it just
+// looks for the common separators between commands.
+var shellCommandSeparators = regexp.MustCompile(`&&|\|\||[;|]`)
+
+// checkBuildCommandAllowed verifies that every command invoked by a RUN
+// instruction's command line appears in allowlist. It returns the first
+// disallowed binary name found, and whether the check passed. An empty
+// allowlist always passes.
+//
+// This is a coarse prefix/binary-name check, not a sandbox: it can be
+// defeated by indirection (e.g. `sh -c $(echo Y3VybA== | base64 -d)`). It is
+// meant as a guardrail against Dockerfiles plainly invoking disallowed
+// commands, not as a security boundary.
+func checkBuildCommandAllowed(cmdLine []string, argsEscaped bool, allowlist []string) (string, bool) {
+	if len(allowlist) == 0 {
+		return "", true
+	}
+	allowed := make(map[string]bool, len(allowlist))
+	for _, c := range allowlist {
+		allowed[c] = true
+	}
+
+	var commands []string
+	if !argsEscaped && len(cmdLine) == 3 && cmdLine[1] == "-c" {
+		// Shell form, e.g. ["/bin/sh", "-c", "curl foo | sh"]: split the
+		// shell command on common separators to find each invoked binary.
+		for _, part := range shellCommandSeparators.Split(cmdLine[2], -1) {
+			if fields := strings.Fields(part); len(fields) > 0 {
+				commands = append(commands, fields[0])
+			}
+		}
+	} else if len(cmdLine) > 0 {
+		// Exec form: the first element is the binary being run.
+		commands = append(commands, cmdLine[0])
+	}
+
+	for _, cmd := range commands {
+		if base := path.Base(cmd); !allowed[base] {
+			return base, false
+		}
+	}
+	return "", true
+}
@@ -0,0 +1,67 @@
+package dockerfile
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestCheckBuildCommandAllowed(t *testing.T) {
+	testcases := []struct {
+		name        string
+		cmdLine     []string
+		argsEscaped bool
+		allowlist   []string
+		expectedCmd string
+		expectedOK  bool
+	}{
+		{
+			name:       "empty allowlist allows anything",
+			cmdLine:    []string{"/bin/sh", "-c", "curl example.com"},
+			expectedOK: true,
+		},
+		{
+			name:       "shell form allowed command",
+			cmdLine:    []string{"/bin/sh", "-c", "echo hi"},
+			allowlist:  []string{"echo", "sh"},
+			expectedOK: true,
+		},
+		{
+			name:        "shell form disallowed command",
+			cmdLine:     []string{"/bin/sh", "-c", "curl example.com"},
+			allowlist:   []string{"echo", "sh"},
+			expectedCmd: "curl",
+			expectedOK:  false,
+		},
+		{
+			name:        "shell form disallowed command after separator",
+			cmdLine:     []string{"/bin/sh", "-c", "echo hi && curl example.com"},
+			allowlist:   []string{"echo", "sh"},
+			expectedCmd: "curl",
+			expectedOK:  false,
+		},
+		{
+			name:        "exec form disallowed command",
+			cmdLine:     []string{"curl", "example.com"},
+			argsEscaped: true,
+			allowlist:   []string{"echo", "sh"},
+			expectedCmd: "curl",
+			expectedOK:  false,
+		},
+		{
+			name:        "exec form allowed command",
+			cmdLine:     []string{"echo", "hi"},
+			argsEscaped: true,
+			allowlist:   []string{"echo", "sh"},
+			expectedOK:  true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd, ok := checkBuildCommandAllowed(tc.cmdLine, tc.argsEscaped, tc.allowlist)
+			assert.Equal(t, ok, tc.expectedOK)
+			assert.Equal(t, cmd, tc.expectedCmd)
+		})
+	}
+}
@@ -347,6 +347,13 @@ func dispatchRun(ctx context.Context, d dispatchRequest, c *instructions.RunComm
 
 	stateRunConfig := d.state.runConfig
 	cmdFromArgs, argsEscaped := resolveCmdLine(c.ShellDependantCmdLine, stateRunConfig, d.state.operatingSystem, c.Name(), c.String())
+
+	if allowlist := d.builder.docker.BuildCommandAllowlist(); len(allowlist) > 0 {
+		if cmd, ok := checkBuildCommandAllowed(cmdFromArgs, argsEscaped, allowlist); !ok {
+			return errdefs.InvalidParameter(errors.Errorf("RUN command %q is not in the configured build-command-allowlist", cmd))
+		}
+	}
+
 	buildArgs := d.state.buildArgs.FilterAllowed(stateRunConfig.Env)
 
 	saveCmd := cmdFromArgs
@@ -630,6 +630,33 @@ func TestDispatchUnsupportedOptions(t *testing.T) {
 	})
 }
 
+func TestDispatchRunCommandAllowlist(t *testing.T) {
+	b := newBuilderWithMockBackend(t)
+	b.docker.(*MockBackend).buildCommandAllowlist = []string{"echo", "sh"}
+	sb := newDispatchRequest(b, '`', nil, NewBuildArgs(make(map[string]*string)), newStagesBuildResults())
+	sb.state.baseImage = &mockImage{}
+	sb.state.operatingSystem = runtime.GOOS
+
+	t.Run("allowed command", func(t *testing.T) {
+		runint, err := instructions.ParseInstruction(&parser.Node{Original: `RUN echo foo`, Value: "run"})
+		assert.NilError(t, err)
+		cmd := runint.(*instructions.RunCommand)
+		cmd.PrependShell = true
+
+		assert.NilError(t, dispatch(t.Context(), sb, cmd))
+	})
+
+	t.Run("disallowed command", func(t *testing.T) {
+		runint, err := instructions.ParseInstruction(&parser.Node{Original: `RUN curl https://example.com`, Value: "run"})
+		assert.NilError(t, err)
+		cmd := runint.(*instructions.RunCommand)
+		cmd.PrependShell = true
+
+		err = dispatch(t.Context(), sb, cmd)
+		assert.ErrorContains(t, err, `RUN command "curl" is not in the configured build-command-allowlist`)
+	})
+}
+
 // Copied and modified from https://github.com/docker/go-connections/blob/c296721c0d56d3acad2973376ded214103a4fd2e/nat/nat_test.go#L390-L499
 func TestParsePortSpecs(t *testing.T) {
 	var (
@@ -114,6 +114,7 @@ type dispatchState struct {
 	stageName       string
 	buildArgs       *BuildArgs
 	operatingSystem string
+	layerCount      int
 }
 
 func newDispatchState(baseArgs *BuildArgs) *dispatchState {
@@ -14,6 +14,9 @@
 type ImageProber interface {
 	Reset(ctx context.Context) error
 	Probe(parentID string, runConfig *container.Config, platform ocispec.Platform) (string, error)
+	// Stats returns the number of cacheable steps probed so far, and how
+	// many of those were satisfied from the cache.
+	Stats() (total, hits int)
 }
 
 type resetFunc func(context.Context) (builder.ImageCache, error)
@@ -22,6 +25,8 @@ type imageProber struct {
 	cache       builder.ImageCache
 	reset       resetFunc
 	cacheBusted bool
+	total       int
+	hits        int
 }
 
 func newImageProber(ctx context.Context, cacheBuilder builder.ImageCacheBuilder, cacheFrom []string, noCache bool) (ImageProber, error) {
@@ -53,6 +58,7 @@ func (c *imageProber) Reset(ctx context.Context) error {
 // Probe checks if cache match can be found for current build instruction.
 // It returns the cachedID if there is a hit, and the empty string on miss
 func (c *imageProber) Probe(parentID string, runConfig *container.Config, platform ocispec.Platform) (string, error) {
+	c.total++
 	if c.cacheBusted {
 		return "", nil
 	}
@@ -66,15 +72,29 @@ func (c *imageProber) Probe(parentID string, runConfig *container.Config, platfo
 		return "", nil
 	}
 	log.G(context.TODO()).Debugf("[BUILDER] Use cached version: %s", runConfig.Cmd)
+	c.hits++
 	return cacheID, nil
 }
 
-type nopProber struct{}
+// Stats returns the number of steps probed and how many hit the cache.
+func (c *imageProber) Stats() (total, hits int) {
+	return c.total, c.hits
+}
+
+type nopProber struct {
+	total int
+}
 
 func (c *nopProber) Reset(ctx context.Context) error {
 	return nil
 }
 
 func (c *nopProber) Probe(_ string, _ *container.Config, _ ocispec.Platform) (string, error) {
+	c.total++
 	return "", nil
 }
+
+// Stats returns the number of steps probed. A nopProber never hits the cache.
+func (c *nopProber) Stats() (total, hits int) {
+	return c.total, 0
+}
@@ -24,6 +24,7 @@
 	networkSettings "github.com/moby/moby/v2/daemon/network"
 	"github.com/moby/moby/v2/daemon/server/backend"
 	"github.com/moby/moby/v2/daemon/server/buildbackend"
+	"github.com/moby/moby/v2/errdefs"
 	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 )
@@ -63,8 +64,11 @@ func (b *Builder) commitContainer(ctx context.Context, dispatchState *dispatchSt
 	}
 
 	imageID, err := b.docker.CommitBuildStep(ctx, commitCfg)
+	if err != nil {
+		return err
+	}
 	dispatchState.imageID = string(imageID)
-	return err
+	return b.recordLayer(dispatchState)
 }
 
 func (b *Builder) exportImage(ctx context.Context, state *dispatchState, layer builder.RWLayer, parent builder.Image, runConfig *container.Config) error {
@@ -313,7 +317,18 @@ func (b *Builder) probeCache(dispatchState *dispatchState, runConfig *container.
 	_, _ = fmt.Fprintln(b.Stdout, " ---> Using cache")
 
 	dispatchState.imageID = cachedID
-	return true, nil
+	return true, b.recordLayer(dispatchState)
+}
+
+// recordLayer counts a layer produced by dispatchState (whether newly built
+// or reused from cache) against the configured BuildMaxLayers, failing the
+// build as soon as the limit is exceeded.
+func (b *Builder) recordLayer(dispatchState *dispatchState) error {
+	dispatchState.layerCount++
+	if max := b.docker.BuildMaxLayers(); max > 0 && dispatchState.layerCount > max {
+		return errdefs.InvalidParameter(errors.Errorf("build exceeds the configured maximum of %d layers", max))
+	}
+	return nil
 }
 
 var defaultLogConfig = container.LogConfig{Type: "none"}
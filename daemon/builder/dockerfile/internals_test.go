@@ -194,6 +194,25 @@ func getMockBuildBackend() builder.Backend {
 	return &MockBackend{}
 }
 
+func TestRecordLayerMaxLayers(t *testing.T) {
+	backend := getMockBuildBackend().(*MockBackend)
+	backend.buildMaxLayers = 2
+	b := &Builder{docker: backend}
+	state := newDispatchState(NewBuildArgs(map[string]*string{}))
+
+	assert.NilError(t, b.recordLayer(state))
+	assert.NilError(t, b.recordLayer(state))
+	err := b.recordLayer(state)
+	assert.ErrorContains(t, err, "build exceeds the configured maximum of 2 layers")
+
+	// unlimited (the default) never fails, regardless of the layer count
+	unlimited := &Builder{docker: getMockBuildBackend()}
+	unlimitedState := newDispatchState(NewBuildArgs(map[string]*string{}))
+	for range 10 {
+		assert.NilError(t, unlimited.recordLayer(unlimitedState))
+	}
+}
+
 func TestExportImage(t *testing.T) {
 	ds := newDispatchState(NewBuildArgs(map[string]*string{}))
 	parentImage := &image.Image{
@@ -19,10 +19,20 @@
 
 // MockBackend implements the builder.Backend interface for unit testing
 type MockBackend struct {
-	containerCreateFunc func(config backend.ContainerCreateConfig) (container.CreateResponse, error)
-	commitFunc          func(backend.CommitConfig) (image.ID, error)
-	getImageFunc        func(string) (builder.Image, builder.ROLayer, error)
-	makeImageCacheFunc  func(cacheFrom []string) builder.ImageCache
+	containerCreateFunc   func(config backend.ContainerCreateConfig) (container.CreateResponse, error)
+	commitFunc            func(backend.CommitConfig) (image.ID, error)
+	getImageFunc          func(string) (builder.Image, builder.ROLayer, error)
+	makeImageCacheFunc    func(cacheFrom []string) builder.ImageCache
+	buildCommandAllowlist []string
+	buildMaxLayers        int
+}
+
+func (m *MockBackend) BuildCommandAllowlist() []string {
+	return m.buildCommandAllowlist
+}
+
+func (m *MockBackend) BuildMaxLayers() int {
+	return m.buildMaxLayers
 }
 
 func (m *MockBackend) ContainerAttachRaw(cID string, stdin io.ReadCloser, stdout, stderr io.Writer, stream bool, attached chan struct{}) error {
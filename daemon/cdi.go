@@ -22,7 +22,10 @@ type cdiHandler struct {
 // RegisterCDIDriver registers the CDI device driver.
 // The driver injects CDI devices into an incoming OCI spec and is called for DeviceRequests associated with CDI devices.
 // If the list of CDI spec directories is empty, the driver is not registered.
-func RegisterCDIDriver(cdiSpecDirs ...string) *cdi.Cache {
+// When strict is true, a CDI spec file that fails to parse causes registration
+// to fail outright; otherwise the offending file is skipped, its error is
+// logged, and specs that did parse successfully remain usable.
+func RegisterCDIDriver(strict bool, cdiSpecDirs ...string) (*cdi.Cache, error) {
 	for i, dir := range cdiSpecDirs {
 		if _, err := os.Stat(dir); !errors.Is(err, os.ErrNotExist) {
 			cdiSpecDirs[i], err = filepath.EvalSymlinks(dir)
@@ -31,16 +34,23 @@ func RegisterCDIDriver(cdiSpecDirs ...string) *cdi.Cache {
 			}
 		}
 	}
-	driver, cache := newCDIDeviceDriver(cdiSpecDirs...)
+	driver, cache, err := newCDIDeviceDriver(strict, cdiSpecDirs...)
+	if err != nil {
+		return nil, err
+	}
 	registerDeviceDriver("cdi", driver)
-	return cache
+	return cache, nil
 }
 
 // newCDIDeviceDriver creates a new CDI device driver.
-// If the creation of the CDI cache fails, a driver is returned that will return an error on an injection request.
-func newCDIDeviceDriver(cdiSpecDirs ...string) (*deviceDriver, *cdi.Cache) {
-	cache, err := createCDICache(cdiSpecDirs...)
+// If the creation of the CDI cache fails, a driver is returned that will return an error on an injection request,
+// unless strict is true, in which case the error is returned immediately.
+func newCDIDeviceDriver(strict bool, cdiSpecDirs ...string) (*deviceDriver, *cdi.Cache, error) {
+	cache, err := createCDICache(strict, cdiSpecDirs...)
 	if err != nil {
+		if strict {
+			return nil, nil, fmt.Errorf("CDI registry initialization failed: %w", err)
+		}
 		log.G(context.TODO()).WithError(err).Error("Failed to create CDI cache")
 		// We create a spec updater that always returns an error.
 		// This error will be returned only when a CDI device is requested.
@@ -56,7 +66,7 @@ func newCDIDeviceDriver(cdiSpecDirs ...string) (*deviceDriver, *cdi.Cache) {
 					Warnings: []string{fmt.Sprintf("CDI cache initialization failed: %v", err)},
 				}, nil
 			},
-		}, nil
+		}, nil, nil
 	}
 
 	// We construct a spec updates that injects CDI devices into the OCI spec using the initialized registry.
@@ -67,12 +77,14 @@ func newCDIDeviceDriver(cdiSpecDirs ...string) (*deviceDriver, *cdi.Cache) {
 	return &deviceDriver{
 		updateSpec:  c.injectCDIDevices,
 		ListDevices: c.listDevices,
-	}, cache
+	}, cache, nil
 }
 
 // createCDICache creates a CDI cache for the specified CDI specification directories.
 // If the list of CDI specification directories is empty or the creation of the CDI cache fails, an error is returned.
-func createCDICache(cdiSpecDirs ...string) (*cdi.Cache, error) {
+// Parse errors for individual spec files are logged and skipped, unless
+// strict is true, in which case the first such error is returned.
+func createCDICache(strict bool, cdiSpecDirs ...string) (*cdi.Cache, error) {
 	if len(cdiSpecDirs) == 0 {
 		return nil, errors.New("no CDI specification directories specified")
 	}
@@ -82,13 +94,16 @@ func createCDICache(cdiSpecDirs ...string) (*cdi.Cache, error) {
 		return nil, fmt.Errorf("CDI registry initialization failure: %w", err)
 	}
 
-	for dir, errs := range cache.GetErrors() {
+	for path, errs := range cache.GetErrors() {
 		for _, err := range errs {
 			if errors.Is(err, os.ErrNotExist) {
-				log.L.WithField("dir", dir).Info("CDI directory does not exist, skipping")
+				log.L.WithField("path", path).Info("CDI directory does not exist, skipping")
 				continue
 			}
-			log.L.WithFields(log.Fields{"error": err, "dir": dir}).Warn("CDI setup error")
+			if strict {
+				return nil, fmt.Errorf("failed to parse CDI spec %s: %w", path, err)
+			}
+			log.L.WithFields(log.Fields{"error": err, "path": path}).Warn("Failed to parse CDI spec, skipping")
 		}
 	}
 
@@ -0,0 +1,41 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestCreateCDICacheWithMalformedSpec(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "valid.yaml"), []byte(`
+cdiVersion: "0.7.0"
+kind: "example.com/device"
+devices:
+- name: foo
+  containerEdits:
+    additionalGids:
+    - 1234
+`), 0o644))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "malformed.yaml"), []byte(`not: [valid`), 0o644))
+
+	t.Run("lenient (default) skips the malformed spec and loads the rest", func(t *testing.T) {
+		cache, err := createCDICache(false, dir)
+		assert.NilError(t, err)
+
+		assert.DeepEqual(t, []string{"example.com/device=foo"}, cache.ListDevices())
+
+		var sawError bool
+		for _, errs := range cache.GetErrors() {
+			sawError = sawError || len(errs) > 0
+		}
+		assert.Assert(t, sawError, "expected the malformed spec to be recorded as a cache error")
+	})
+
+	t.Run("strict fails outright on the malformed spec", func(t *testing.T) {
+		_, err := createCDICache(true, dir)
+		assert.ErrorContains(t, err, "malformed.yaml")
+	})
+}
@@ -22,11 +22,16 @@ func installCommonConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 	flags.Var(insecureRegistries, "insecure-registry", "Enable insecure registry communication")
 
 	flags.Var(opts.NewNamedListOptsRef("storage-opts", &conf.GraphOptions, nil), "storage-opt", "Storage driver options")
+	flags.StringVar(&conf.DefaultRootfsSize, "default-rootfs-size", "", `Default size quota (e.g. "10G") for a container's writable layer, on storage drivers that support per-container quotas`)
 	flags.Var(opts.NewNamedListOptsRef("authorization-plugins", &conf.AuthorizationPlugins, nil), "authorization-plugin", "Authorization plugins to load")
+	flags.StringVar(&conf.AuthzFailMode, "authz-fail-mode", conf.AuthzFailMode, `Action to take when an authorization plugin is unreachable ("closed"|"open")`)
 	flags.Var(opts.NewNamedListOptsRef("exec-opts", &conf.ExecOptions, nil), "exec-opt", "Runtime execution options")
 	flags.StringVarP(&conf.Pidfile, "pidfile", "p", conf.Pidfile, "Path to use for daemon PID file")
+	flags.BoolVar(&conf.PidfileOptional, "pidfile-optional", false, "Continue startup without a PID file if it can't be created or written")
 	flags.StringVar(&conf.Root, "data-root", conf.Root, "Root directory of persistent Docker state")
 	flags.StringVar(&conf.ExecRoot, "exec-root", conf.ExecRoot, "Root directory for execution state files")
+	flags.StringVar(&conf.BuildKitRoot, "buildkit-root", conf.BuildKitRoot, "Root directory for buildkit state, defaults to a subdirectory of data-root")
+	flags.IntVar(&conf.TCPListenBacklog, "tcp-listen-backlog", conf.TCPListenBacklog, "Set the accept queue size for TCP listeners (0 uses the OS default)")
 	flags.StringVar(&conf.ContainerdAddr, "containerd", "", "containerd grpc address")
 	flags.BoolVar(&conf.CriContainerd, "cri-containerd", false, "start containerd with cri")
 	flags.Var(dopts.NewNamedSetOpts("features", conf.Features), "feature", "Enable feature in the daemon")
@@ -42,6 +47,7 @@ func installCommonConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 		flags.MarkHidden("mtu")
 	}
 
+	flags.BoolVar(&conf.AutoCreateMissingNetwork, "auto-create-missing-network", conf.AutoCreateMissingNetwork, "Automatically re-create a container's user-defined network at start if it's missing")
 	flags.IntVar(&conf.NetworkControlPlaneMTU, "network-control-plane-mtu", conf.NetworkControlPlaneMTU, "Network Control plane MTU")
 	flags.IntVar(&conf.NetworkDiagnosticPort, "network-diagnostic-port", 0, "TCP port number of the network diagnostic server")
 	_ = flags.MarkHidden("network-diagnostic-port")
@@ -56,16 +62,23 @@ func installCommonConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 	flags.Var(opts.NewListOptsRef(&conf.DNSSearch, opts.ValidateDNSSearch), "dns-search", "DNS search domains to use")
 	flags.Var(dopts.NewNamedIPListOptsRef("host-gateway-ips", &conf.HostGatewayIPs), "host-gateway-ip", "IP addresses that the special 'host-gateway' string in --add-host resolves to. Defaults to the IP addresses of the default bridge")
 	flags.Var(opts.NewNamedListOptsRef("labels", &conf.Labels, opts.ValidateLabel), "label", "Set key=value labels to the daemon")
+	flags.Var(opts.NewListOptsRef(&conf.AllowedSysctls, nil), "allow-sysctl", "Allow additional sysctls, beyond the default namespaced set, in non-privileged containers")
+	flags.BoolVar(&conf.AutoPullMissingImageOnStart, "auto-pull-missing-image-on-start", conf.AutoPullMissingImageOnStart, "Re-pull a container's image if it is missing when the container is started")
+	flags.BoolVar(&conf.RejectUlimitCgroupConflicts, "reject-ulimit-cgroup-conflicts", conf.RejectUlimitCgroupConflicts, "Reject containers that set both a conflicting --ulimit nproc and --pids-limit, instead of just warning")
+	flags.BoolVar(&conf.StrictExecRootCheck, "strict-exec-root-check", conf.StrictExecRootCheck, "Refuse to start if exec-root and data-root are on the same filesystem, instead of just warning")
 	flags.StringVar(&conf.LogConfig.Type, "log-driver", conf.LogConfig.Type, "Default driver for container logs")
 	flags.Var(opts.NewNamedMapOpts("log-opts", conf.LogConfig.Config, nil), "log-opt", "Default log driver options for containers")
 
 	flags.IntVar(&conf.MaxConcurrentDownloads, "max-concurrent-downloads", conf.MaxConcurrentDownloads, "Set the max concurrent downloads")
 	flags.IntVar(&conf.MaxConcurrentUploads, "max-concurrent-uploads", conf.MaxConcurrentUploads, "Set the max concurrent uploads")
 	flags.IntVar(&conf.MaxDownloadAttempts, "max-download-attempts", conf.MaxDownloadAttempts, "Set the max download attempts for each pull")
+	flags.IntVar(&conf.MaxConcurrentStops, "max-concurrent-stops", conf.MaxConcurrentStops, "Set the max number of container stops processed concurrently (0 for unbounded)")
+	flags.IntVar(&conf.ContainerCreateTimeout, "container-create-timeout", conf.ContainerCreateTimeout, "Set the timeout, in seconds, for the whole container create operation (0 for unbounded)")
 	flags.IntVar(&conf.ShutdownTimeout, "shutdown-timeout", conf.ShutdownTimeout, "Set the default shutdown timeout")
 
 	flags.StringVar(&conf.SwarmDefaultAdvertiseAddr, "swarm-default-advertise-addr", "", "Set default address or interface for swarm advertised address")
 	flags.BoolVar(&conf.Experimental, "experimental", false, "Enable experimental features")
+	flags.BoolVar(&conf.EnableBuildSquash, "enable-build-squash", conf.EnableBuildSquash, "Allow build --squash without enabling experimental features")
 	flags.StringVar(&conf.MetricsAddress, "metrics-addr", "", "Set default address and port to serve the metrics api on")
 	flags.Var(opts.NewNamedListOptsRef("node-generic-resources", &conf.NodeGenericResources, opts.ValidateSingleGenericResource), "node-generic-resource", "Advertise user-defined resource")
 
@@ -76,8 +89,18 @@ func installCommonConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 	flags.StringVar(&conf.HTTPProxy, "http-proxy", "", "HTTP proxy URL to use for outgoing traffic")
 	flags.StringVar(&conf.HTTPSProxy, "https-proxy", "", "HTTPS proxy URL to use for outgoing traffic")
 	flags.StringVar(&conf.NoProxy, "no-proxy", "", "Comma-separated list of hosts or IP addresses for which the proxy is skipped")
+	flags.StringVar(&conf.ProxyEnvPrecedence, "proxy-env-precedence", conf.ProxyEnvPrecedence, `Which value wins when a proxy is set both in the configuration and the daemon's environment ("config"|"environment")`)
 
 	flags.Var(opts.NewNamedListOptsRef("cdi-spec-dirs", &conf.CDISpecDirs, nil), "cdi-spec-dir", "CDI specification directories to use")
+	flags.BoolVar(&conf.CDIStrict, "cdi-strict", false, "Refuse to start if a CDI spec file fails to parse, instead of skipping it")
+
+	flags.Var(opts.NewNamedListOptsRef("allowed-extra-hosts-file-dirs", &conf.AllowedExtraHostsFileDirs, nil), "allowed-extra-hosts-file-dir", "Directories from which a container's extra-hosts file may be read")
+
+	flags.Var(opts.NewNamedListOptsRef("allowed-resolv-conf-template-dirs", &conf.AllowedResolvConfTemplateDirs, nil), "allowed-resolv-conf-template-dir", "Directories from which a container's resolv.conf template may be read")
+
+	flags.BoolVar(&conf.OTLPUseLibraryDefaults, "otlp-use-library-defaults", false, "Let OTLP exporter libraries pick their own default protocol instead of forcing http/protobuf")
+
+	flags.StringVar(&conf.PrivilegedContainersPolicy, "privileged-containers-policy", conf.PrivilegedContainersPolicy, `Policy for creating privileged containers ("warn"|"forbid")`)
 
 	flags.Var(opts.NewNamedNRIOptsRef(&conf.NRIOpts), "nri-opts", "Node Resource Interface configuration")
 
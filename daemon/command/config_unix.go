@@ -38,6 +38,7 @@ func installConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 	flags.IPVar(&conf.BridgeConfig.DefaultIP, "ip", net.IPv4zero, "Host IP for port publishing from the default bridge network")
 	flags.BoolVar(&conf.BridgeConfig.EnableUserlandProxy, "userland-proxy", conf.BridgeConfig.EnableUserlandProxy, "Use userland proxy for loopback traffic")
 	flags.StringVar(&conf.BridgeConfig.UserlandProxyPath, "userland-proxy-path", conf.BridgeConfig.UserlandProxyPath, "Path to the userland proxy binary")
+	flags.StringVar(&conf.BridgeConfig.UserlandProxyBackend, "userland-proxy-backend", conf.BridgeConfig.UserlandProxyBackend, "Name of the userland-proxy backend to use, falls back to the binary backend if not registered")
 	flags.BoolVar(&conf.BridgeConfig.AllowDirectRouting, "allow-direct-routing", false, "Allow remote access to published ports on container IP addresses")
 	flags.StringVar(&conf.BridgeConfig.BridgeAcceptFwMark, "bridge-accept-fwmark", "", "In bridge networks, accept packets with this firewall mark/mask")
 	flags.StringVar(&conf.CgroupParent, "cgroup-parent", "", "Set parent cgroup for all containers")
@@ -53,8 +54,13 @@ func installConfigFlags(conf *config.Config, flags *pflag.FlagSet) {
 	flags.StringVar(&conf.IpcMode, "default-ipc-mode", conf.IpcMode, `Default mode for containers ipc ("shareable" | "private")`)
 	flags.Var(&conf.NetworkConfig.DefaultAddressPools, "default-address-pool", "Default address pools for node specific local networks")
 	flags.StringVar(&conf.NetworkConfig.FirewallBackend, "firewall-backend", "", "Firewall backend to use, iptables or nftables")
+	flags.StringVar(&conf.NetworkConfig.EmbeddedDNSAddress, "embedded-dns-address", "", "IP address the embedded DNS resolver listens on inside containers (default 127.0.0.11)")
+	flags.IntVar(&conf.VolumeCreateRetries, "volume-create-retries", 0, "Number of times to retry creating an anonymous volume after a transient volume driver error")
+	flags.IntVar(&conf.VolumeCreateRetryBackoff, "volume-create-retry-backoff", 0, "Base delay in milliseconds between anonymous volume create retries (doubles each attempt)")
+	flags.StringVar(&conf.DataRootPermissionPolicy, "data-root-permission-policy", conf.DataRootPermissionPolicy, `Action to take when the data root already exists with unexpected ownership ("fixup"|"warn"|"fail")`)
 	// rootless needs to be explicitly specified for running "rootful" dockerd in rootless dockerd (#38702)
 	// Note that conf.BridgeConfig.UserlandProxyPath and honorXDG are configured according to the value of rootless.RunningWithRootlessKit, not the value of --rootless.
 	flags.BoolVar(&conf.Rootless, "rootless", conf.Rootless, "Enable rootless mode; typically used with RootlessKit")
 	flags.StringVar(&conf.CgroupNamespaceMode, "default-cgroupns-mode", conf.CgroupNamespaceMode, `Default mode for containers cgroup namespace ("host" | "private")`)
+	flags.Var(opts.NewNamedListOptsRef("default-security-opts", &conf.DefaultSecurityOpts, nil), "default-security-opt", "Default security options for containers that don't set their own")
 }
@@ -57,6 +57,7 @@
 	"github.com/moby/moby/v2/pkg/homedir"
 	"github.com/moby/moby/v2/pkg/pidfile"
 	"github.com/moby/moby/v2/pkg/plugingetter"
+	"github.com/moby/moby/v2/pkg/process"
 	"github.com/moby/sys/userns"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
@@ -76,6 +77,9 @@ type daemonCLI struct {
 	d               *daemon.Daemon
 	authzMiddleware *authorization.Middleware // authzMiddleware enables to dynamically reload the authorization plugins
 
+	experimentalMiddleware *middleware.ExperimentalMiddleware // experimentalMiddleware enables to dynamically reload the experimental header on config reload
+	routers                []router.Router                    // routers enables toggling experimental routes on config reload
+
 	stopOnce     sync.Once
 	apiShutdown  chan struct{}
 	apiTLSConfig *tls.Config
@@ -146,7 +150,7 @@ func (cli *daemonCLI) start(ctx context.Context) (retErr error) {
 		return errors.New("dockerd needs to be started with root privileges. To run dockerd in rootless mode as an unprivileged user, see https://docs.docker.com/go/rootless/")
 	}
 
-	if err := setDefaultUmask(); err != nil {
+	if err := setDefaultUmask(cli.Config); err != nil {
 		return err
 	}
 
@@ -160,21 +164,25 @@ func (cli *daemonCLI) start(ctx context.Context) (retErr error) {
 		return err
 	}
 
+	if err := cli.checkExecRootFilesystem(ctx); err != nil {
+		return err
+	}
+
 	potentiallyUnderRuntimeDir := []string{cli.Config.ExecRoot}
 
 	if cli.Pidfile != "" {
-		if err := os.MkdirAll(filepath.Dir(cli.Pidfile), 0o755); err != nil {
-			return errors.Wrap(err, "failed to create pidfile directory")
+		written, err := writePidfile(ctx, cli.Pidfile, cli.Config.PidfileOptional)
+		if err != nil {
+			return err
 		}
-		if err := pidfile.Write(cli.Pidfile, os.Getpid()); err != nil {
-			return errors.Wrapf(err, "failed to start daemon, ensure docker is not running or delete %s", cli.Pidfile)
+		if written {
+			potentiallyUnderRuntimeDir = append(potentiallyUnderRuntimeDir, cli.Pidfile)
+			defer func() {
+				if err := os.Remove(cli.Pidfile); err != nil {
+					log.G(ctx).Error(err)
+				}
+			}()
 		}
-		potentiallyUnderRuntimeDir = append(potentiallyUnderRuntimeDir, cli.Pidfile)
-		defer func() {
-			if err := os.Remove(cli.Pidfile); err != nil {
-				log.G(ctx).Error(err)
-			}
-		}()
 	}
 
 	if cli.Config.IsRootless() {
@@ -256,7 +264,7 @@ func (cli *daemonCLI) start(ctx context.Context) (retErr error) {
 		_ = os.Setenv(otelServiceNameEnv, filepath.Base(os.Args[0]))
 	}
 
-	setOTLPProtoDefault()
+	setOTLPProtoDefault(ctx, cli.Config.OTLPUseLibraryDefaults)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
 
 	// Initialize the trace recorder for buildkit.
@@ -282,17 +290,22 @@ func (cli *daemonCLI) start(ctx context.Context) (retErr error) {
 	// - The DeviceRequests API must be extended to non-linux platforms.
 	var cdiCache *cdi.Cache
 	if cdiEnabled(cli.Config) {
-		cdiCache = daemon.RegisterCDIDriver(cli.Config.CDISpecDirs...)
+		var err error
+		cdiCache, err = daemon.RegisterCDIDriver(cli.Config.CDIStrict, cli.Config.CDISpecDirs...)
+		if err != nil {
+			return err
+		}
 	}
 
 	daemon.RegisterGPUDeviceDrivers(cdiCache)
 
 	var apiServer apiserver.Server
-	authz, err := initMiddlewares(ctx, &apiServer, cli.Config, pluginStore)
+	authz, exp, err := initMiddlewares(ctx, &apiServer, cli.Config, pluginStore)
 	if err != nil {
 		return errors.Wrap(err, "failed to start API server")
 	}
 	cli.authzMiddleware = authz
+	cli.experimentalMiddleware = exp
 
 	d, err := daemon.NewDaemon(ctx, cli.Config, pluginStore, cli.authzMiddleware)
 	if err != nil {
@@ -346,6 +359,7 @@ func (cli *daemonCLI) start(ctx context.Context) (retErr error) {
 		cluster:  c,
 		builder:  b,
 	})
+	cli.routers = routers
 	gs := newGRPCServer(ctx)
 	b.backend.RegisterGRPC(gs)
 	httpServer.Protocols = &p
@@ -421,11 +435,74 @@ func (cli *daemonCLI) start(ctx context.Context) (retErr error) {
 	return nil
 }
 
+// dockerdCommName is the command name we expect to find (via process.Comm)
+// for the process owning a live pidfile.
+const dockerdCommName = "dockerd"
+
+// reapStalePidfile removes path if it names a still-running process that
+// isn't actually a dockerd, so a PID recycled by container-in-container or
+// namespaced setups doesn't falsely block startup. If the owning process's
+// identity can't be determined, it conservatively leaves path in place, so
+// pidfile.Write's normal "still running" check still applies.
+func reapStalePidfile(ctx context.Context, path string) error {
+	pid, err := pidfile.Read(path)
+	if err != nil || pid == 0 {
+		// No pidfile, or no live PID recorded: nothing to reap. Any real
+		// problem reading the file is surfaced by the pidfile.Write that
+		// follows.
+		return nil
+	}
+	comm, err := process.Comm(pid)
+	if err != nil {
+		// Can't determine the process's identity: be conservative and leave
+		// the pidfile as-is, so it's treated as a genuine conflict.
+		return nil
+	}
+	if comm == dockerdCommName {
+		return nil
+	}
+	log.G(ctx).WithFields(log.Fields{
+		"pid":  pid,
+		"comm": comm,
+	}).Warn("Removing stale pidfile: recorded PID belongs to a recycled, non-dockerd process")
+	return os.Remove(path)
+}
+
+// writePidfile creates the directory for path and writes the daemon's PID to
+// it. If optional is true, a failure to create the directory or write the
+// file is logged as a warning and writePidfile returns written=false instead
+// of an error, allowing the daemon to start without a pidfile. If optional is
+// false (the default), any failure is returned as an error.
+func writePidfile(ctx context.Context, path string, optional bool) (written bool, _ error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		if optional {
+			log.G(ctx).WithError(err).Warn("Failed to create pidfile directory, continuing without a pidfile")
+			return false, nil
+		}
+		return false, errors.Wrap(err, "failed to create pidfile directory")
+	}
+	if err := reapStalePidfile(ctx, path); err != nil {
+		log.G(ctx).WithError(err).Warn("Failed to check for a stale pidfile, continuing")
+	}
+	if err := pidfile.Write(path, os.Getpid()); err != nil {
+		if optional {
+			log.G(ctx).WithError(err).Warn("Failed to write pidfile, continuing without a pidfile")
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to start daemon, ensure docker is not running or delete %s", path)
+	}
+	return true, nil
+}
+
 // The buildkit "detect" package uses grpc as the default proto, which is in conformance with the old spec.
 // For a little while now http/protobuf is the default spec, so this function sets the protocol to http/protobuf when the env var is unset
 // so that the detect package will use http/protobuf as a default.
 // TODO: This can be removed after buildkit is updated to use http/protobuf as the default.
-func setOTLPProtoDefault() {
+//
+// useLibraryDefault skips this workaround entirely, letting the OTLP
+// exporter libraries fall back to their own default (grpc), for operators
+// who want that instead.
+func setOTLPProtoDefault(ctx context.Context, useLibraryDefault bool) {
 	const (
 		tracesEnv  = "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"
 		metricsEnv = "OTEL_EXPORTER_OTLP_METRICS_PROTOCOL"
@@ -434,7 +511,7 @@ func setOTLPProtoDefault() {
 		defaultProto = "http/protobuf"
 	)
 
-	if os.Getenv(protoEnv) == "" {
+	if !useLibraryDefault && os.Getenv(protoEnv) == "" {
 		if os.Getenv(tracesEnv) == "" {
 			_ = os.Setenv(tracesEnv, defaultProto)
 		}
@@ -442,27 +519,84 @@ func setOTLPProtoDefault() {
 			_ = os.Setenv(metricsEnv, defaultProto)
 		}
 	}
+
+	fallback := os.Getenv(protoEnv)
+	if fallback == "" {
+		fallback = "grpc (library default)"
+	}
+	tracesProto, metricsProto := os.Getenv(tracesEnv), os.Getenv(metricsEnv)
+	if tracesProto == "" {
+		tracesProto = fallback
+	}
+	if metricsProto == "" {
+		metricsProto = fallback
+	}
+	log.G(ctx).WithFields(log.Fields{
+		"otlp-traces-protocol":  tracesProto,
+		"otlp-metrics-protocol": metricsProto,
+	}).Debug("OTLP exporter protocol")
+}
+
+// buildkitRoot returns the directory buildkit should use for its state and
+// cache. It defaults to a "buildkit" subdirectory of the data root, or, when
+// cfg.BuildKitRoot is set, uses that directory instead after confirming it
+// exists and is writable.
+func buildkitRoot(cfg *config.Config) (string, error) {
+	if cfg.BuildKitRoot == "" {
+		return filepath.Join(cfg.Root, "buildkit"), nil
+	}
+
+	fi, err := os.Stat(cfg.BuildKitRoot)
+	if err != nil {
+		return "", errors.Wrap(err, "buildkit-root")
+	}
+	if !fi.IsDir() {
+		return "", errors.Errorf("buildkit-root %q is not a directory", cfg.BuildKitRoot)
+	}
+
+	f, err := os.CreateTemp(cfg.BuildKitRoot, ".buildkit-root-check-*")
+	if err != nil {
+		return "", errors.Wrapf(err, "buildkit-root %q is not writable", cfg.BuildKitRoot)
+	}
+	f.Close()
+	os.Remove(f.Name())
+
+	return cfg.BuildKitRoot, nil
 }
 
 func initBuildkit(ctx context.Context, d *daemon.Daemon, cdiCache *cdi.Cache) (_ builderOptions, closeFn func(), _ error) {
 	log.G(ctx).Info("Initializing buildkit")
 	closeFn = func() {}
 
+	manager, err := dockerfile.NewBuildManager(d.BuilderBackend(), d.IdentityMapping())
+	if err != nil {
+		return builderOptions{}, closeFn, err
+	}
+
+	cfg := d.Config()
+
+	if !cfg.Builder.BuildKit.IsEnabled() {
+		log.G(ctx).Info("BuildKit is disabled in the daemon configuration, only the classic builder is available")
+		bb, err := buildbackend.NewBackend(d.ImageService(), manager, nil, d.EventsService)
+		if err != nil {
+			return builderOptions{}, closeFn, errors.Wrap(err, "failed to create builder backend")
+		}
+		return builderOptions{backend: bb}, closeFn, nil
+	}
+
 	sm, err := session.NewManager()
 	if err != nil {
 		return builderOptions{}, closeFn, errors.Wrap(err, "failed to create sessionmanager")
 	}
 
-	manager, err := dockerfile.NewBuildManager(d.BuilderBackend(), d.IdentityMapping())
+	buildkitRootDir, err := buildkitRoot(&cfg)
 	if err != nil {
 		return builderOptions{}, closeFn, err
 	}
 
-	cfg := d.Config()
-
 	bk, err := buildkit.New(ctx, buildkit.Opt{
 		SessionManager:      sm,
-		Root:                filepath.Join(cfg.Root, "buildkit"),
+		Root:                buildkitRootDir,
 		EngineID:            d.ID(),
 		Dist:                d.DistributionServices(),
 		ImageTagger:         d.ImageService(),
@@ -540,6 +674,14 @@ func (cli *daemonCLI) reloadConfig() {
 		// don't want to partially apply the config if the daemon is unhappy with it.
 
 		cli.authzMiddleware.SetPlugins(cfg.AuthorizationPlugins)
+		if cfg.IsValueSet("authz-fail-mode") {
+			cli.authzMiddleware.SetFailMode(authorization.FailMode(cfg.AuthzFailMode))
+		}
+
+		if cfg.IsValueSet("experimental") {
+			cli.experimentalMiddleware.SetExperimental(cfg.Experimental)
+			setExperimentalRoutesEnabled(cli.routers, cfg.Experimental)
+		}
 
 		if cfg.IsValueSet("debug") {
 			debugEnabled := debug.IsEnabled()
@@ -710,7 +852,7 @@ func loadDaemonCliConfig(opts *daemonOptions) (*config.Config, error) {
 	}
 
 	// Check if duplicate label-keys with different values are found
-	newLabels, err := config.GetConflictFreeLabels(conf.Labels)
+	newLabels, err := config.GetConflictFreeLabels(conf.Labels, conf.LabelConflictPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -833,7 +975,6 @@ func buildRouters(opts routerOptions) []router.Router {
 		systemrouter.NewRouter(opts.daemon, opts.cluster, opts.builder.buildkit, opts.daemon.Features),
 		volume.NewRouter(opts.daemon.VolumesService(), opts.cluster),
 		build.NewRouter(opts.builder.backend, opts.daemon),
-		sessionrouter.NewRouter(opts.builder.sessionManager), //nolint:staticcheck // Deprecated endpoint kept for backward compatibility
 		swarmrouter.NewRouter(opts.cluster),
 		pluginrouter.NewRouter(opts.daemon.PluginManager()),
 		distributionrouter.NewRouter(opts.daemon.ImageBackend()),
@@ -841,36 +982,53 @@ func buildRouters(opts routerOptions) []router.Router {
 		debugrouter.NewRouter(),
 	}
 
-	if opts.builder.backend != nil {
-		routers = append(routers, grpcrouter.NewRouter(opts.builder.backend)) //nolint:staticcheck // Deprecated endpoint kept for backward compatibility
+	// The session and grpc (BuildKit controller) routers are only meaningful
+	// when BuildKit is enabled; the classic builder doesn't use either. Both
+	// are skipped when BuildKit has been disabled via the daemon
+	// configuration, in which case opts.builder.buildkit is nil.
+	if opts.builder.buildkit != nil {
+		routers = append(routers, sessionrouter.NewRouter(opts.builder.sessionManager)) //nolint:staticcheck // Deprecated endpoint kept for backward compatibility
+		routers = append(routers, grpcrouter.NewRouter(opts.builder.backend))           //nolint:staticcheck // Deprecated endpoint kept for backward compatibility
 	}
 
-	if opts.daemon.HasExperimental() {
-		for _, r := range routers {
-			for _, route := range r.Routes() {
-				if experimental, ok := route.(router.ExperimentalRoute); ok {
-					experimental.Enable()
-				}
+	setExperimentalRoutesEnabled(routers, opts.daemon.HasExperimental())
+
+	return routers
+}
+
+// setExperimentalRoutesEnabled enables or disables every experimental route
+// found among routers, so that experimental support can be toggled after
+// buildRouters has already run (e.g. on config reload).
+func setExperimentalRoutesEnabled(routers []router.Router, enabled bool) {
+	for _, r := range routers {
+		for _, route := range r.Routes() {
+			experimental, ok := route.(router.ExperimentalRoute)
+			if !ok {
+				continue
+			}
+			if enabled {
+				experimental.Enable()
+			} else {
+				experimental.Disable()
 			}
 		}
 	}
-
-	return routers
 }
 
-func initMiddlewares(_ context.Context, s *apiserver.Server, cfg *config.Config, pluginStore plugingetter.PluginGetter) (*authorization.Middleware, error) {
+func initMiddlewares(_ context.Context, s *apiserver.Server, cfg *config.Config, pluginStore plugingetter.PluginGetter) (*authorization.Middleware, *middleware.ExperimentalMiddleware, error) {
 	exp := middleware.NewExperimentalMiddleware(cfg.Experimental)
 	s.UseMiddleware(exp)
 
 	vm, err := middleware.NewVersionMiddleware(dockerversion.Version, config.MaxAPIVersion, cfg.MinAPIVersion)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	s.UseMiddleware(*vm)
 
 	authzMiddleware := authorization.NewMiddleware(cfg.AuthorizationPlugins, pluginStore)
+	authzMiddleware.SetFailMode(authorization.FailMode(cfg.AuthzFailMode))
 	s.UseMiddleware(authzMiddleware)
-	return authzMiddleware, nil
+	return authzMiddleware, exp, nil
 }
 
 func getContainerdDaemonOpts(cfg *config.Config) ([]supervisor.DaemonOpt, error) {
@@ -1024,7 +1182,7 @@ func loadListeners(cfg *config.Config, tlsConfig *tls.Config) ([]net.Listener, [
 				return nil, nil, err
 			}
 		}
-		ls, err := listeners.Init(proto, addr, cfg.SocketGroup, tlsConfig)
+		ls, err := listeners.Init(proto, addr, cfg.SocketGroup, tlsConfig, cfg.TCPListenBacklog)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -1117,28 +1275,49 @@ func configureDaemonLogs(ctx context.Context, conf config.DaemonLogConfig) error
 }
 
 func configureProxyEnv(ctx context.Context, cfg config.Proxies) {
+	precedence := cfg.ProxyEnvPrecedence
+	if precedence == "" {
+		precedence = config.ProxyEnvPrecedenceConfig
+	}
 	if p := cfg.HTTPProxy; p != "" {
-		overrideProxyEnv(ctx, "HTTP_PROXY", p)
-		overrideProxyEnv(ctx, "http_proxy", p)
+		overrideProxyEnv(ctx, "HTTP_PROXY", p, precedence)
+		overrideProxyEnv(ctx, "http_proxy", p, precedence)
 	}
 	if p := cfg.HTTPSProxy; p != "" {
-		overrideProxyEnv(ctx, "HTTPS_PROXY", p)
-		overrideProxyEnv(ctx, "https_proxy", p)
+		overrideProxyEnv(ctx, "HTTPS_PROXY", p, precedence)
+		overrideProxyEnv(ctx, "https_proxy", p, precedence)
 	}
 	if p := cfg.NoProxy; p != "" {
-		overrideProxyEnv(ctx, "NO_PROXY", p)
-		overrideProxyEnv(ctx, "no_proxy", p)
+		overrideProxyEnv(ctx, "NO_PROXY", p, precedence)
+		overrideProxyEnv(ctx, "no_proxy", p, precedence)
 	}
 }
 
-func overrideProxyEnv(ctx context.Context, name, val string) {
-	if oldVal := os.Getenv(name); oldVal != "" && oldVal != val {
+// overrideProxyEnv sets the process environment variable name to val, unless
+// the variable is already set to a different, non-empty value and
+// precedence is config.ProxyEnvPrecedenceEnvironment, in which case the
+// existing environment value is left untouched. Either way, a value that's
+// about to be replaced is logged prominently, so the winning source is
+// never a surprise.
+func overrideProxyEnv(ctx context.Context, name, val, precedence string) {
+	oldVal := os.Getenv(name)
+	if oldVal == "" || oldVal == val {
+		_ = os.Setenv(name, val)
+		return
+	}
+	if precedence == config.ProxyEnvPrecedenceEnvironment {
 		log.G(ctx).WithFields(log.Fields{
-			"name":      name,
-			"old-value": config.MaskCredentials(oldVal),
-			"new-value": config.MaskCredentials(val),
-		}).Warn("overriding existing proxy variable with value from configuration")
+			"name":         name,
+			"env-value":    config.MaskCredentials(oldVal),
+			"config-value": config.MaskCredentials(val),
+		}).Warn("keeping proxy variable from environment over value from configuration")
+		return
 	}
+	log.G(ctx).WithFields(log.Fields{
+		"name":      name,
+		"old-value": config.MaskCredentials(oldVal),
+		"new-value": config.MaskCredentials(val),
+	}).Warn("overriding existing proxy variable with value from configuration")
 	_ = os.Setenv(name, val)
 }
 
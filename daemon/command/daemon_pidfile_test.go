@@ -0,0 +1,55 @@
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/moby/moby/v2/pkg/pidfile"
+	"gotest.tools/v3/assert"
+)
+
+// TestReapStalePidfileRecycledPID simulates the scenario that motivates
+// reapStalePidfile: a pidfile recording a PID that is alive, but was
+// recycled by an unrelated process (e.g. after PID reuse in a namespaced
+// setup), rather than by dockerd. The stale pidfile should be removed so it
+// doesn't block startup.
+func TestReapStalePidfileRecycledPID(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("process.Comm is only implemented on Linux")
+	}
+
+	path := filepath.Join(t.TempDir(), "docker.pid")
+	assert.NilError(t, os.WriteFile(path, []byte(strconv.Itoa(os.Getpid())), 0o644))
+
+	// The current test binary is alive but is not "dockerd", so this
+	// reproduces a recycled-PID pidfile.
+	assert.NilError(t, reapStalePidfile(context.Background(), path))
+
+	_, err := os.Stat(path)
+	assert.Check(t, os.IsNotExist(err), "expected stale pidfile to be removed")
+}
+
+func TestReapStalePidfileMissingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docker.pid")
+	assert.NilError(t, reapStalePidfile(context.Background(), path))
+}
+
+// TestReapStalePidfileUnknownProcessIsConservative asserts that a pidfile
+// recording a PID that isn't running is left for pidfile.Write to handle
+// normally (it's simply not a live conflict), rather than reapStalePidfile
+// making assumptions about it.
+func TestReapStalePidfileUnknownProcessIsConservative(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "docker.pid")
+	// A PID that's exceedingly unlikely to be alive.
+	assert.NilError(t, os.WriteFile(path, []byte(strconv.Itoa(1<<30)), 0o644))
+
+	assert.NilError(t, reapStalePidfile(context.Background(), path))
+
+	pid, err := pidfile.Read(path)
+	assert.NilError(t, err)
+	assert.Equal(t, pid, 0)
+}
@@ -1,12 +1,19 @@
 package command
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
 
 	"github.com/containerd/log"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/moby/moby/v2/daemon/config"
+	"github.com/moby/moby/v2/daemon/server/middleware"
+	"github.com/moby/moby/v2/daemon/server/router"
 	"github.com/spf13/pflag"
 	"go.opentelemetry.io/otel"
 	"gotest.tools/v3/assert"
@@ -213,6 +220,106 @@ func TestConfigureDaemonLogs(t *testing.T) {
 	assert.Check(t, is.Equal(log.WarnLevel, log.GetLevel()))
 }
 
+func TestOverrideProxyEnv(t *testing.T) {
+	const name = "HTTP_PROXY"
+
+	testCases := []struct {
+		doc        string
+		precedence string
+		want       string
+	}{
+		{
+			doc:        "config wins by default",
+			precedence: config.ProxyEnvPrecedenceConfig,
+			want:       "http://config.example.com",
+		},
+		{
+			doc:        "environment wins when configured to",
+			precedence: config.ProxyEnvPrecedenceEnvironment,
+			want:       "http://env.example.com",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.doc, func(t *testing.T) {
+			t.Setenv(name, "http://env.example.com")
+			overrideProxyEnv(t.Context(), name, "http://config.example.com", tc.precedence)
+			assert.Check(t, is.Equal(tc.want, os.Getenv(name)))
+		})
+	}
+}
+
+func TestSetOTLPProtoDefault(t *testing.T) {
+	const (
+		tracesEnv  = "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"
+		metricsEnv = "OTEL_EXPORTER_OTLP_METRICS_PROTOCOL"
+		protoEnv   = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	)
+
+	t.Run("sets http/protobuf by default", func(t *testing.T) {
+		os.Unsetenv(protoEnv)
+		os.Unsetenv(tracesEnv)
+		os.Unsetenv(metricsEnv)
+		defer os.Unsetenv(tracesEnv)
+		defer os.Unsetenv(metricsEnv)
+
+		setOTLPProtoDefault(t.Context(), false)
+		assert.Check(t, is.Equal("http/protobuf", os.Getenv(tracesEnv)))
+		assert.Check(t, is.Equal("http/protobuf", os.Getenv(metricsEnv)))
+	})
+
+	t.Run("leaves env vars untouched when skip config is set", func(t *testing.T) {
+		os.Unsetenv(protoEnv)
+		os.Unsetenv(tracesEnv)
+		os.Unsetenv(metricsEnv)
+		defer os.Unsetenv(tracesEnv)
+		defer os.Unsetenv(metricsEnv)
+
+		setOTLPProtoDefault(t.Context(), true)
+		assert.Check(t, is.Equal("", os.Getenv(tracesEnv)))
+		assert.Check(t, is.Equal("", os.Getenv(metricsEnv)))
+	})
+}
+
+func TestBuildkitRoot(t *testing.T) {
+	t.Run("defaults to a subdirectory of data-root", func(t *testing.T) {
+		cfg := &config.Config{CommonConfig: config.CommonConfig{Root: "/var/lib/docker"}}
+		root, err := buildkitRoot(cfg)
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(filepath.Join("/var/lib/docker", "buildkit"), root))
+	})
+
+	t.Run("uses buildkit-root when set", func(t *testing.T) {
+		dir := t.TempDir()
+		cfg := &config.Config{CommonConfig: config.CommonConfig{Root: "/var/lib/docker", BuildKitRoot: dir}}
+		root, err := buildkitRoot(cfg)
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(dir, root))
+	})
+
+	t.Run("errors when buildkit-root does not exist", func(t *testing.T) {
+		cfg := &config.Config{CommonConfig: config.CommonConfig{BuildKitRoot: filepath.Join(t.TempDir(), "missing")}}
+		_, err := buildkitRoot(cfg)
+		assert.ErrorContains(t, err, "buildkit-root")
+	})
+
+	t.Run("errors when buildkit-root is not writable", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("permission bits are not enforced the same way on Windows")
+		}
+		if os.Getuid() == 0 {
+			t.Skip("skipping test that requires non-root")
+		}
+
+		dir := t.TempDir()
+		assert.NilError(t, os.Chmod(dir, 0o500))
+		defer os.Chmod(dir, 0o700)
+		cfg := &config.Config{CommonConfig: config.CommonConfig{BuildKitRoot: dir}}
+		_, err := buildkitRoot(cfg)
+		assert.ErrorContains(t, err, "not writable")
+	})
+}
+
 func TestCDISpecDirs(t *testing.T) {
 	testCases := []struct {
 		description         string
@@ -282,6 +389,33 @@ func TestCDISpecDirs(t *testing.T) {
 	}
 }
 
+func TestWritePidfile(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("permission bits are not enforced the same way on Windows")
+	}
+	if os.Getuid() == 0 {
+		t.Skip("skipping test that requires non-root")
+	}
+
+	dir := fs.NewDir(t, "pidfile-test")
+	defer dir.Remove()
+
+	// Make the parent directory read-only so that creating a pidfile
+	// directory underneath it fails.
+	assert.NilError(t, os.Chmod(dir.Path(), 0o500))
+	pidPath := filepath.Join(dir.Path(), "sub", "docker.pid")
+
+	ctx := t.Context()
+
+	written, err := writePidfile(ctx, pidPath, false)
+	assert.Check(t, is.ErrorContains(err, "failed to create pidfile directory"))
+	assert.Check(t, !written)
+
+	written, err = writePidfile(ctx, pidPath, true)
+	assert.NilError(t, err)
+	assert.Check(t, !written)
+}
+
 // TestOtelMeterLeak is a regression test for a memory leak in the OTEL meter
 // implementation that was fixed in OTEL v1.30.0.
 //
@@ -312,3 +446,41 @@ func TestOtelMeterLeak(t *testing.T) {
 		t.Fatalf("Possible OTel leak; got more than 10 allocations (allocs: %d).", allocs)
 	}
 }
+
+type fakeRouter struct{ routes []router.Route }
+
+func (r fakeRouter) Routes() []router.Route { return r.routes }
+
+// TestReloadToggleExperimental verifies that toggling the "experimental"
+// config value on reload updates both the experimental header middleware and
+// the availability of experimental routes, without requiring a restart.
+func TestReloadToggleExperimental(t *testing.T) {
+	route := router.Experimental(router.NewGetRoute("/test", func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		return nil
+	}))
+	routers := []router.Router{fakeRouter{routes: []router.Route{route}}}
+	exp := middleware.NewExperimentalMiddleware(false)
+
+	callHandler := func() (string, error) {
+		wrapped := exp.WrapHandler(route.Handler())
+		w := httptest.NewRecorder()
+		err := wrapped(context.Background(), w, nil, nil)
+		return w.Header().Get("Docker-Experimental"), err
+	}
+
+	header, err := callHandler()
+	assert.Equal(t, header, "false")
+	assert.ErrorContains(t, err, "disabled by default")
+
+	exp.SetExperimental(true)
+	setExperimentalRoutesEnabled(routers, true)
+	header, err = callHandler()
+	assert.Equal(t, header, "true")
+	assert.NilError(t, err)
+
+	exp.SetExperimental(false)
+	setExperimentalRoutesEnabled(routers, false)
+	header, err = callHandler()
+	assert.Equal(t, header, "false")
+	assert.ErrorContains(t, err, "disabled by default")
+}
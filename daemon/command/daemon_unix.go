@@ -4,6 +4,7 @@
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"os"
 	"os/signal"
@@ -11,6 +12,7 @@
 	"strconv"
 	"time"
 
+	"github.com/containerd/log"
 	"github.com/moby/moby/v2/daemon"
 	"github.com/moby/moby/v2/daemon/config"
 	"github.com/moby/moby/v2/daemon/libnetwork/portallocator"
@@ -42,15 +44,23 @@ func getDefaultDaemonConfigFile() string {
 	return filepath.Join(dir, "daemon.json")
 }
 
-// setDefaultUmask sets the umask to 0 to avoid problems
-// caused by custom umask
-func setDefaultUmask() error {
+// setDefaultUmask sets the umask to 0, or to cfg.Umask when configured, to
+// avoid problems caused by an inherited or custom umask affecting files the
+// daemon creates directly (e.g. logs, volumes).
+func setDefaultUmask(cfg *config.Config) error {
 	desiredUmask := 0
+	if cfg.Umask != "" {
+		parsed, err := strconv.ParseUint(cfg.Umask, 8, 32)
+		if err != nil {
+			return errors.Wrapf(err, "invalid umask %q", cfg.Umask)
+		}
+		desiredUmask = int(parsed)
+	}
 	unix.Umask(desiredUmask)
 	if umask := unix.Umask(desiredUmask); umask != desiredUmask {
 		return errors.Errorf("failed to set umask: expected %#o, got %#o", desiredUmask, umask)
 	}
-	fsutilcopy.UmaskIsZero = true
+	fsutilcopy.UmaskIsZero = desiredUmask == 0
 
 	return nil
 }
@@ -116,6 +126,40 @@ func newCgroupParent(cfg *config.Config) string {
 	return cgroupParent
 }
 
+// checkExecRootFilesystem warns (or, when the daemon is configured for
+// strict checks, fails startup) if exec-root and data-root resolve to the
+// same filesystem. exec-root is expected to live on a tmpfs or other
+// runtime filesystem, separate from the persistent data-root; colocating
+// them can cause subtle problems (e.g. exec-root state filling up the data
+// disk, or outliving a reboot when it shouldn't).
+func (cli *daemonCLI) checkExecRootFilesystem(ctx context.Context) error {
+	same, err := sameFilesystem(cli.Config.Root, cli.Config.ExecRoot)
+	if err != nil || !same {
+		return nil
+	}
+
+	msg := fmt.Sprintf("exec-root (%s) and data-root (%s) are on the same filesystem; exec-root is expected to be on a tmpfs or other runtime filesystem, separate from data-root", cli.Config.ExecRoot, cli.Config.Root)
+	if cli.Config.StrictExecRootCheck {
+		return errors.New(msg)
+	}
+	log.G(ctx).Warn(msg)
+	return nil
+}
+
+// sameFilesystem reports whether the two paths reside on the same
+// filesystem/device. Errors stat'ing either path (e.g. because it hasn't
+// been created yet) are treated as "unknown", not "same".
+func sameFilesystem(a, b string) (bool, error) {
+	var aStat, bStat unix.Stat_t
+	if err := unix.Stat(a, &aStat); err != nil {
+		return false, err
+	}
+	if err := unix.Stat(b, &bStat); err != nil {
+		return false, err
+	}
+	return aStat.Dev == bStat.Dev, nil
+}
+
 func (cli *daemonCLI) initContainerd(ctx context.Context) (func(time.Duration) error, error) {
 	if cli.Config.ContainerdAddr != "" {
 		// use system containerd at the given address.
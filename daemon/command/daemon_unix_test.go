@@ -3,9 +3,13 @@
 package command
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/moby/moby/v2/daemon/config"
+	"golang.org/x/sys/unix"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
 	"gotest.tools/v3/fs"
@@ -86,3 +90,80 @@ func TestLoadDaemonConfigWithTrueDefaultValuesLeaveDefaults(t *testing.T) {
 
 	assert.Check(t, loadedConfig.EnableUserlandProxy)
 }
+
+func TestSameFilesystem(t *testing.T) {
+	dir := t.TempDir()
+
+	same, err := sameFilesystem(dir, dir)
+	assert.NilError(t, err)
+	assert.Check(t, same)
+
+	_, err = sameFilesystem(dir, filepath.Join(dir, "does-not-exist"))
+	assert.Check(t, err != nil)
+}
+
+// TestSetDefaultUmask covers both the default behavior (umask cleared to
+// 0000) and a configured cfg.Umask, asserting that a file the daemon creates
+// afterwards ends up with the permissions the umask implies.
+func TestSetDefaultUmask(t *testing.T) {
+	oldUmask := unix.Umask(0)
+	defer unix.Umask(oldUmask)
+
+	t.Run("default clears the umask", func(t *testing.T) {
+		assert.NilError(t, setDefaultUmask(&config.Config{}))
+
+		path := filepath.Join(t.TempDir(), "file")
+		assert.NilError(t, os.WriteFile(path, nil, 0o666))
+
+		info, err := os.Stat(path)
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(info.Mode().Perm(), os.FileMode(0o666)))
+	})
+
+	t.Run("configured umask is applied", func(t *testing.T) {
+		assert.NilError(t, setDefaultUmask(&config.Config{CommonConfig: config.CommonConfig{Umask: "0022"}}))
+
+		path := filepath.Join(t.TempDir(), "file")
+		assert.NilError(t, os.WriteFile(path, nil, 0o666))
+
+		info, err := os.Stat(path)
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(info.Mode().Perm(), os.FileMode(0o644)))
+	})
+
+	t.Run("invalid umask is rejected", func(t *testing.T) {
+		err := setDefaultUmask(&config.Config{CommonConfig: config.CommonConfig{Umask: "not-octal"}})
+		assert.Check(t, is.ErrorContains(err, "invalid umask"))
+	})
+}
+
+func TestCheckExecRootFilesystem(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("colocated roots warn by default", func(t *testing.T) {
+		cli := &daemonCLI{Config: &config.Config{}}
+		cli.Config.Root = dir
+		cli.Config.ExecRoot = dir
+
+		assert.NilError(t, cli.checkExecRootFilesystem(context.Background()))
+	})
+
+	t.Run("colocated roots fail in strict mode", func(t *testing.T) {
+		cli := &daemonCLI{Config: &config.Config{}}
+		cli.Config.Root = dir
+		cli.Config.ExecRoot = dir
+		cli.Config.StrictExecRootCheck = true
+
+		err := cli.checkExecRootFilesystem(context.Background())
+		assert.ErrorContains(t, err, "same filesystem")
+	})
+
+	t.Run("separate roots never fail", func(t *testing.T) {
+		cli := &daemonCLI{Config: &config.Config{}}
+		cli.Config.Root = filepath.Join(dir, "root")
+		cli.Config.ExecRoot = filepath.Join(dir, "does-not-exist")
+		cli.Config.StrictExecRootCheck = true
+
+		assert.NilError(t, cli.checkExecRootFilesystem(context.Background()))
+	})
+}
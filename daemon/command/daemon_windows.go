@@ -35,7 +35,7 @@ func setPlatformOptions(cfg *config.Config) error {
 }
 
 // setDefaultUmask doesn't do anything on windows
-func setDefaultUmask() error {
+func setDefaultUmask(cfg *config.Config) error {
 	return nil
 }
 
@@ -109,6 +109,13 @@ func newCgroupParent(*config.Config) string {
 	return ""
 }
 
+// checkExecRootFilesystem is a no-op on Windows: exec-root and data-root
+// aren't expected to live on separate filesystem types the way they are on
+// Unix (where exec-root is typically a tmpfs), so there's nothing to check.
+func (cli *daemonCLI) checkExecRootFilesystem(context.Context) error {
+	return nil
+}
+
 func (cli *daemonCLI) initContainerd(ctx context.Context) (func(time.Duration) error, error) {
 	if cli.Config.ContainerdAddr != "" {
 		return nil, nil
@@ -22,8 +22,9 @@
 // and the user container configuration, either passed by the API or generated
 // by the cli.
 // It will mutate the specified user configuration (userConf) with the image
-// configuration where the user configuration is incomplete.
-func merge(userConf, imageConf *containertypes.Config) error {
+// configuration where the user configuration is incomplete. If inheritLabels
+// is false, the image's config labels are not merged onto userConf.Labels.
+func merge(userConf, imageConf *containertypes.Config, inheritLabels bool) error {
 	if userConf.User == "" {
 		userConf.User = imageConf.User
 	}
@@ -64,9 +65,11 @@ func merge(userConf, imageConf *containertypes.Config) error {
 	if userConf.Labels == nil {
 		userConf.Labels = map[string]string{}
 	}
-	for l, v := range imageConf.Labels {
-		if _, ok := userConf.Labels[l]; !ok {
-			userConf.Labels[l] = v
+	if inheritLabels {
+		for l, v := range imageConf.Labels {
+			if _, ok := userConf.Labels[l]; !ok {
+				userConf.Labels[l] = v
+			}
 		}
 	}
 
@@ -157,7 +160,7 @@ func (daemon *Daemon) CreateImageFromContainer(ctx context.Context, name string,
 	if err != nil {
 		return "", err
 	}
-	if err := merge(newConfig, container.Config); err != nil {
+	if err := merge(newConfig, container.Config, true); err != nil {
 		return "", err
 	}
 
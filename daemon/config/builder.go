@@ -143,4 +143,20 @@ type BuilderConfig struct {
 	GC           BuilderGCConfig
 	Entitlements BuilderEntitlements
 	History      *BuilderHistoryConfig `json:",omitempty"`
+	BuildKit     BuildKitConfig
+}
+
+// BuildKitConfig contains config for enabling or disabling the BuildKit
+// builder.
+type BuildKitConfig struct {
+	Enabled *bool `json:",omitempty"`
+}
+
+// IsEnabled returns whether the BuildKit builder is enabled. BuildKit is
+// enabled by default; it's only disabled if Enabled is explicitly set to
+// false. Disabling it skips creating the BuildKit worker (and its
+// Root/buildkit state directory) entirely, leaving the classic builder
+// (BuilderV1) as the only build path.
+func (x *BuildKitConfig) IsEnabled() bool {
+	return x.Enabled == nil || *x.Enabled
 }
@@ -114,3 +114,27 @@ func TestBuilderGC_Enabled(t *testing.T) {
 		})
 	}
 }
+
+func TestBuilderBuildKit_Enabled(t *testing.T) {
+	tests := []struct {
+		doc, config string
+		expected    bool
+	}{
+		{doc: "empty config", config: ``, expected: true},
+		{doc: "empty json", config: `{}`, expected: true},
+		{doc: "empty builder", config: `{"builder": {}}`, expected: true},
+		{doc: "empty buildkit", config: `{"builder": {"buildkit": {}}}`, expected: true},
+		{doc: "buildkit enabled", config: `{"builder": {"buildkit": {"enabled": true}}}`, expected: true},
+		{doc: "buildkit disabled", config: `{"builder": {"buildkit": {"enabled": false}}}`, expected: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.doc, func(t *testing.T) {
+			tempFile := fs.NewFile(t, "config", fs.WithContent(tc.config))
+			configFile := tempFile.Path()
+
+			cfg, err := MergeDaemonConfigurations(&Config{}, nil, configFile)
+			assert.NilError(t, err)
+			assert.Equal(t, cfg.Builder.BuildKit.IsEnabled(), tc.expected)
+		})
+	}
+}
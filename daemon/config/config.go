@@ -2,6 +2,7 @@
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	stderrors "errors"
 	"fmt"
@@ -10,6 +11,7 @@
 	"net/netip"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 
 	"dario.cat/mergo"
@@ -18,6 +20,7 @@
 	"github.com/moby/moby/v2/daemon/internal/versions"
 	"github.com/moby/moby/v2/daemon/pkg/opts"
 	"github.com/moby/moby/v2/daemon/pkg/registry"
+	"github.com/moby/patternmatcher"
 	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 	"golang.org/x/text/encoding"
@@ -77,6 +80,62 @@
 	// LibnetDataPath is the path to libnetwork's data directory, relative to cfg.Root.
 	// Windows tolerates the "/".
 	LibnetDataPath = "network/files"
+
+	// DataRootPermissionPolicyFixup makes the daemon chown/chmod an existing
+	// data root to the expected ownership, as it always has done.
+	DataRootPermissionPolicyFixup = "fixup"
+	// DataRootPermissionPolicyWarn makes the daemon warn about a data root
+	// with unexpected ownership, without changing it. This is the default.
+	DataRootPermissionPolicyWarn = "warn"
+	// DataRootPermissionPolicyFail makes the daemon refuse to start when the
+	// data root has unexpected ownership.
+	DataRootPermissionPolicyFail = "fail"
+
+	// BuildSymlinkPolicyReject makes the classic builder fail a build
+	// outright when the context contains a symlink whose target resolves
+	// outside the context.
+	BuildSymlinkPolicyReject = "reject"
+	// BuildSymlinkPolicyFollowWithin drops symlinks whose target resolves
+	// outside the context from the build context, while leaving symlinks
+	// that resolve within the context untouched.
+	BuildSymlinkPolicyFollowWithin = "follow-within"
+	// BuildSymlinkPolicyPreserve keeps all symlinks in the build context
+	// as-is, regardless of where they resolve. This is the default.
+	BuildSymlinkPolicyPreserve = "preserve"
+
+	// AuthzFailModeClosed denies a request when an authorization plugin
+	// cannot be reached. This is the default.
+	AuthzFailModeClosed = "closed"
+	// AuthzFailModeOpen allows a request through when an authorization
+	// plugin cannot be reached, trading availability for the security
+	// guarantees the plugin would otherwise provide.
+	AuthzFailModeOpen = "open"
+
+	// ProxyEnvPrecedenceConfig makes a proxy value from the daemon
+	// configuration win over one already present in the daemon's
+	// environment. This is the default.
+	ProxyEnvPrecedenceConfig = "config"
+	// ProxyEnvPrecedenceEnvironment makes a proxy value already present in
+	// the daemon's environment win over one from the daemon configuration.
+	ProxyEnvPrecedenceEnvironment = "environment"
+
+	// PrivilegedContainersPolicyWarn allows privileged containers to be
+	// created, logging a warning and an audit event for each one. This is
+	// the default.
+	PrivilegedContainersPolicyWarn = "warn"
+	// PrivilegedContainersPolicyForbid rejects the creation of privileged
+	// containers outright.
+	PrivilegedContainersPolicyForbid = "forbid"
+
+	// LabelConflictPolicyError makes the daemon refuse to start (or reload)
+	// when duplicate label keys have conflicting values. This is the default.
+	LabelConflictPolicyError = "error"
+	// LabelConflictPolicyLastWins resolves a label-key conflict by keeping
+	// the last conflicting value encountered, logging a warning.
+	LabelConflictPolicyLastWins = "last-wins"
+	// LabelConflictPolicyFirstWins resolves a label-key conflict by keeping
+	// the first conflicting value encountered, logging a warning.
+	LabelConflictPolicyFirstWins = "first-wins"
 )
 
 // flatOptions contains configuration keys
@@ -164,6 +223,11 @@ type NetworkConfig struct {
 	// implementation. Currently only used on Linux, it is an error to
 	// supply a value for other platforms.
 	FirewallBackend string `json:"firewall-backend,omitempty"`
+	// EmbeddedDNSAddress overrides the IP address the embedded DNS resolver
+	// listens on inside container network namespaces (default 127.0.0.11).
+	// This is useful for containers that hard-code expectations about the
+	// resolver address.
+	EmbeddedDNSAddress string `json:"embedded-dns-address,omitempty"`
 }
 
 // TLSOptions defines TLS configuration for the daemon server.
@@ -190,18 +254,51 @@ type DNSConfig struct {
 // using the same names that the flags in the command line use.
 type CommonConfig struct {
 	AuthorizationPlugins []string `json:"authorization-plugins,omitempty"` // AuthorizationPlugins holds list of authorization plugins
-	AutoRestart          bool     `json:"-"`
-	DisableBridge        bool     `json:"-"`
-	ExecOptions          []string `json:"exec-opts,omitempty"`
+	// AuthzFailMode controls what happens to a request when an authorization
+	// plugin cannot be reached: AuthzFailModeClosed (the default) denies the
+	// request, AuthzFailModeOpen allows it through.
+	AuthzFailMode string `json:"authz-fail-mode,omitempty"`
+	// PrivilegedContainersPolicy controls what happens when a privileged
+	// container is created: PrivilegedContainersPolicyWarn (the default)
+	// allows it, logging a warning and an audit event, while
+	// PrivilegedContainersPolicyForbid rejects the request outright.
+	PrivilegedContainersPolicy string   `json:"privileged-containers-policy,omitempty"`
+	AutoRestart                bool     `json:"-"`
+	DisableBridge              bool     `json:"-"`
+	ExecOptions                []string `json:"exec-opts,omitempty"`
 	// TODO: Should be renamed to StorageDriver
-	GraphDriver           string   `json:"storage-driver,omitempty"`
-	GraphOptions          []string `json:"storage-opts,omitempty"`
-	Labels                []string `json:"labels,omitempty"`
-	NetworkDiagnosticPort int      `json:"network-diagnostic-port,omitempty"`
-	Pidfile               string   `json:"pidfile,omitempty"`
-	Root                  string   `json:"data-root,omitempty"`
-	ExecRoot              string   `json:"exec-root,omitempty"`
-	SocketGroup           string   `json:"group,omitempty"`
+	GraphDriver  string   `json:"storage-driver,omitempty"`
+	GraphOptions []string `json:"storage-opts,omitempty"`
+	Labels       []string `json:"labels,omitempty"`
+	// LabelConflictPolicy controls what GetConflictFreeLabels does when
+	// duplicate label keys have different values: LabelConflictPolicyError
+	// (the default) fails config loading, LabelConflictPolicyLastWins or
+	// LabelConflictPolicyFirstWins instead resolve the conflict and log a
+	// warning.
+	LabelConflictPolicy   string `json:"label-conflict-policy,omitempty"`
+	NetworkDiagnosticPort int    `json:"network-diagnostic-port,omitempty"`
+	// AutoCreateMissingNetwork, when set, causes a container start to
+	// automatically (re-)create a missing user-defined network, with
+	// default settings, instead of failing. This is opt-in because
+	// auto-creating networks on behalf of the user can be surprising.
+	AutoCreateMissingNetwork bool   `json:"auto-create-missing-network,omitempty"`
+	Pidfile                  string `json:"pidfile,omitempty"`
+	// PidfileOptional downgrades pidfile directory creation and write
+	// failures from a fatal startup error to a logged warning, allowing the
+	// daemon to start without a pidfile. Defaults to false, since many init
+	// systems depend on the pidfile being written.
+	PidfileOptional bool   `json:"pidfile-optional,omitempty"`
+	Root            string `json:"data-root,omitempty"`
+	ExecRoot        string `json:"exec-root,omitempty"`
+	SocketGroup     string `json:"group,omitempty"`
+	// BuildKitRoot overrides the directory buildkit uses for its state and
+	// cache. When empty, buildkit uses a "buildkit" subdirectory of Root.
+	BuildKitRoot string `json:"buildkit-root,omitempty"`
+
+	// TCPListenBacklog sets the accept queue size (the listen(2) backlog)
+	// for TCP listeners created for the API. A value of 0 (the default)
+	// leaves the OS default backlog in place.
+	TCPListenBacklog int `json:"tcp-listen-backlog,omitempty"`
 
 	// Proxies holds the proxies that are configured for the daemon.
 	Proxies `json:"proxies"`
@@ -222,10 +319,139 @@ type CommonConfig struct {
 	// may take place at a time for each push.
 	MaxDownloadAttempts int `json:"max-download-attempts,omitempty"`
 
+	// MaxConcurrentStops bounds how many container stops the daemon
+	// processes concurrently; the rest queue until a slot frees up. This
+	// protects the host from bursts of simultaneous signal/kill/cleanup
+	// work during a bulk `docker stop`. A value of 0 (the default) leaves
+	// concurrent stops unbounded.
+	MaxConcurrentStops int `json:"max-concurrent-stops,omitempty"`
+
+	// ContainerCreateTimeout bounds, in seconds, the whole container create
+	// operation, including host-OS-specific setup and volume creation/mount,
+	// so a stuck volume plugin or storage operation cannot hang create
+	// indefinitely. On timeout, any partially-created container and its
+	// volumes are cleaned up and a clear error is returned. A value of 0
+	// (the default) leaves create unbounded.
+	ContainerCreateTimeout int `json:"container-create-timeout,omitempty"`
+
 	// ShutdownTimeout is the timeout value (in seconds) the daemon will wait for the container
 	// to stop when daemon is being shutdown
 	ShutdownTimeout int `json:"shutdown-timeout,omitempty"`
 
+	// ShutdownStopSignalForwarding, when enabled, makes the daemon broadcast
+	// each running container's stop signal to every process in the container
+	// during daemon shutdown, regardless of the container's own
+	// HostConfig.ForwardStopSignal setting. This gives containers whose PID 1
+	// does not forward signals a better chance of stopping gracefully within
+	// the shutdown timeout instead of being killed.
+	ShutdownStopSignalForwarding bool `json:"shutdown-stop-signal-forwarding,omitempty"`
+
+	// AnonymousVolumeUsageCap is the maximum total disk usage (in bytes)
+	// allowed across all anonymous volumes. When exceeded, container
+	// creates that require a new anonymous volume are rejected. A value
+	// of 0 disables the cap.
+	AnonymousVolumeUsageCap int64 `json:"anonymous-volume-usage-cap,omitempty"`
+
+	// DefaultRootfsSize is the default size quota applied to a container's
+	// writable layer (the "size" storage-opt) when the container doesn't
+	// set one itself. Only takes effect on storage drivers that support
+	// per-container size quotas; ignored (with no error) otherwise. Empty
+	// (the default) leaves the writable layer unlimited.
+	DefaultRootfsSize string `json:"default-rootfs-size,omitempty"`
+
+	// AutoPullMissingImageOnStart controls whether starting a container whose
+	// image was removed (e.g. by a prune) triggers a re-pull of the
+	// container's original image reference before failing. When false (the
+	// default), start fails immediately with a clear error naming the
+	// missing image.
+	AutoPullMissingImageOnStart bool `json:"auto-pull-missing-image-on-start,omitempty"`
+
+	// RejectUlimitCgroupConflicts controls what happens when a container
+	// requests both an "nproc" ulimit and a cgroup PidsLimit, which enforce
+	// process counts through two different, easily-confused mechanisms. When
+	// true, create rejects the conflict outright; when false (the default),
+	// create allows it, warning that the cgroup PidsLimit takes precedence.
+	RejectUlimitCgroupConflicts bool `json:"reject-ulimit-cgroup-conflicts,omitempty"`
+
+	// StrictExecRootCheck controls what happens when exec-root and data-root
+	// resolve to the same filesystem at startup. exec-root is expected to
+	// live on a tmpfs or other runtime filesystem, separate from data-root;
+	// colocating them can cause subtle problems. When true, startup fails
+	// with an error; when false (the default), startup only logs a warning.
+	StrictExecRootCheck bool `json:"strict-exec-root-check,omitempty"`
+
+	// AllowedSysctls lists additional sysctls, beyond the default namespaced
+	// set that is always permitted, that containers are allowed to set via
+	// HostConfig.Sysctls. Privileged containers are exempt from this check.
+	// An empty list (the default) allows only the default namespaced set.
+	AllowedSysctls []string `json:"allow-sysctls,omitempty"`
+
+	// BuildCommandAllowlist restricts the classic (non-BuildKit) builder to
+	// only running RUN commands whose binary name (matched coarsely, not a
+	// sandbox) appears in this list. Builds that invoke a command outside
+	// the allowlist fail. An empty list (the default) allows any command.
+	BuildCommandAllowlist []string `json:"build-command-allowlist,omitempty"`
+
+	// BuildMaxLayers is the maximum number of layers a classic (non-BuildKit)
+	// build may produce. A build that would exceed it fails as soon as the
+	// limit is reached, instead of running to completion. A value of 0 (the
+	// default) leaves the number of layers unlimited.
+	BuildMaxLayers int `json:"build-max-layers,omitempty"`
+
+	// BuildMinFreeInodes is the minimum number of free inodes required on
+	// the build root's filesystem for a build to be accepted, complementing
+	// disk-space checks for builds that create many small files. A value of
+	// 0 (the default) disables the check.
+	BuildMinFreeInodes uint64 `json:"build-min-free-inodes,omitempty"`
+
+	// BuildContextExcludePatterns is a list of .dockerignore-style patterns
+	// that the daemon applies to every received build context in addition
+	// to (and regardless of) the client-supplied .dockerignore, so a client
+	// can't include files the daemon operator wants excluded (e.g. ".git"
+	// or credential-shaped paths) by omitting or editing its .dockerignore.
+	// Matching entries are stripped from the context before the build runs
+	// and logged at debug. Empty (the default) applies no extra exclusions.
+	BuildContextExcludePatterns []string `json:"build-context-exclude,omitempty"`
+
+	// BuildSymlinkPolicy controls how the classic builder's context
+	// extraction handles symlinks whose target resolves outside the build
+	// context: one of BuildSymlinkPolicyReject, BuildSymlinkPolicyFollowWithin,
+	// or BuildSymlinkPolicyPreserve (the default, preserving prior behavior).
+	BuildSymlinkPolicy string `json:"build-symlink-policy,omitempty"`
+
+	// SensitiveBuildArgPatterns is a list of additional case-insensitive
+	// substrings, beyond the daemon's built-in defaults ("key", "token",
+	// "secret", "password", "passwd", "credential"), used to decide whether
+	// a --build-arg's value looks sensitive and should be masked wherever
+	// build options are logged or echoed back to the client for debugging.
+	// The real value is always passed through to the build unmasked; only
+	// output is affected.
+	SensitiveBuildArgPatterns []string `json:"sensitive-build-arg-patterns,omitempty"`
+
+	// DataRootPermissionPolicy controls what CreateDaemonRoot does when the
+	// data root directory already exists with unexpected ownership: one of
+	// DataRootPermissionPolicyFixup, DataRootPermissionPolicyWarn (the
+	// default), or DataRootPermissionPolicyFail.
+	DataRootPermissionPolicy string `json:"data-root-permission-policy,omitempty"`
+
+	// Umask is the umask the daemon process runs with, applied in place of
+	// the hard-coded default of 0000, as an octal string (e.g. "0022"). It
+	// affects the permissions of files the daemon creates directly, such as
+	// logs and volumes. When empty (the default), the daemon preserves its
+	// current behavior of clearing the umask entirely.
+	Umask string `json:"umask,omitempty"`
+
+	// VolumeCreateRetries is the number of times the daemon retries creating
+	// an anonymous volume through a volume driver after a transient error,
+	// before giving up. A value of 0 (the default) disables retries,
+	// preserving prior behavior.
+	VolumeCreateRetries int `json:"volume-create-retries,omitempty"`
+
+	// VolumeCreateRetryBackoff is the base delay, in milliseconds, before the
+	// first retry of a failed anonymous volume create; the delay doubles
+	// after each subsequent attempt. Ignored if VolumeCreateRetries is 0.
+	VolumeCreateRetryBackoff int `json:"volume-create-retry-backoff,omitempty"`
+
 	Debug     bool     `json:"debug,omitempty"`
 	Hosts     []string `json:"hosts,omitempty"`
 	TLS       *bool    `json:"tls,omitempty"`
@@ -261,6 +487,11 @@ type CommonConfig struct {
 
 	Experimental bool `json:"experimental"` // Experimental indicates whether experimental features should be exposed or not
 
+	// EnableBuildSquash allows `docker build --squash` to be used without
+	// enabling Experimental. It has no effect when Experimental is already
+	// enabled, since that already permits squash.
+	EnableBuildSquash bool `json:"enable-build-squash,omitempty"`
+
 	// Exposed node Generic Resources
 	// e.g: ["orange=red", "orange=green", "orange=blue", "apple=3"]
 	NodeGenericResources []string `json:"node-generic-resources,omitempty"`
@@ -288,6 +519,29 @@ type CommonConfig struct {
 	// CDISpecDirs is a list of directories in which CDI specifications can be found.
 	CDISpecDirs []string `json:"cdi-spec-dirs,omitempty"`
 
+	// CDIStrict makes the daemon refuse to start (or reload) when a CDI spec
+	// file fails to parse, instead of logging the parse error and continuing
+	// to serve the CDI specs that did load successfully.
+	CDIStrict bool `json:"cdi-strict,omitempty"`
+
+	// AllowedExtraHostsFileDirs is a list of directories from which a
+	// container's HostConfig.ExtraHostsFile is allowed to be read. A
+	// container create referencing a file outside of these directories is
+	// rejected.
+	AllowedExtraHostsFileDirs []string `json:"allowed-extra-hosts-file-dirs,omitempty"`
+
+	// AllowedResolvConfTemplateDirs is a list of directories from which a
+	// container's HostConfig.ResolvConfTemplate is allowed to be read. A
+	// container create referencing a template outside of these directories
+	// is rejected.
+	AllowedResolvConfTemplateDirs []string `json:"allowed-resolv-conf-template-dirs,omitempty"`
+
+	// OTLPUseLibraryDefaults skips the daemon's workaround of forcing
+	// http/protobuf as the OTLP exporter protocol when the OTEL_EXPORTER_OTLP_*
+	// protocol env vars are unset, letting the underlying OTLP libraries pick
+	// their own default (grpc) instead.
+	OTLPUseLibraryDefaults bool `json:"otlp-use-library-defaults,omitempty"`
+
 	// NRIOpts defines configuration for NRI (Node Resource Interface).
 	NRIOpts opts.NRIOpts `json:"nri-opts"`
 
@@ -319,6 +573,11 @@ type Proxies struct {
 	HTTPProxy  string `json:"http-proxy,omitempty"`
 	HTTPSProxy string `json:"https-proxy,omitempty"`
 	NoProxy    string `json:"no-proxy,omitempty"`
+	// ProxyEnvPrecedence controls which of a proxy value from the daemon
+	// configuration and the corresponding value already present in the
+	// daemon's environment wins when both are set: ProxyEnvPrecedenceConfig
+	// (the default) or ProxyEnvPrecedenceEnvironment.
+	ProxyEnvPrecedence string `json:"proxy-env-precedence,omitempty"`
 }
 
 // IsValueSet returns true if a configuration value
@@ -345,9 +604,16 @@ func New() (*Config, error) {
 				LogLevel:  "info",
 				LogFormat: log.TextFormat,
 			},
-			MaxConcurrentDownloads: DefaultMaxConcurrentDownloads,
-			MaxConcurrentUploads:   DefaultMaxConcurrentUploads,
-			MaxDownloadAttempts:    DefaultDownloadAttempts,
+			MaxConcurrentDownloads:     DefaultMaxConcurrentDownloads,
+			MaxConcurrentUploads:       DefaultMaxConcurrentUploads,
+			MaxDownloadAttempts:        DefaultDownloadAttempts,
+			DataRootPermissionPolicy:   DataRootPermissionPolicyWarn,
+			AuthzFailMode:              AuthzFailModeClosed,
+			PrivilegedContainersPolicy: PrivilegedContainersPolicyWarn,
+			LabelConflictPolicy:        LabelConflictPolicyError,
+			Proxies: Proxies{
+				ProxyEnvPrecedence: ProxyEnvPrecedenceConfig,
+			},
 			BridgeConfig: BridgeConfig{
 				DefaultBridgeConfig: DefaultBridgeConfig{
 					MTU: DefaultNetworkMtu,
@@ -386,15 +652,28 @@ func (conf *Config) GetExecOpt(name string) (val string, found bool, _ error) {
 // GetConflictFreeLabels validates Labels for conflict
 // In swarm the duplicates for labels are removed
 // so we only take same values here, no conflict values
-// If the key-value is the same we will only take the last label
-func GetConflictFreeLabels(labels []string) ([]string, error) {
+// If the key-value is the same we will only take the last label.
+//
+// How a conflict (the same key with different values) is resolved depends
+// on policy: LabelConflictPolicyError (the default) returns an error,
+// LabelConflictPolicyLastWins keeps the last value encountered, and
+// LabelConflictPolicyFirstWins keeps the first. Both "*Wins" policies log a
+// warning for each conflict they resolve.
+func GetConflictFreeLabels(labels []string, policy string) ([]string, error) {
 	labelMap := map[string]string{}
 	for _, label := range labels {
 		key, val, ok := strings.Cut(label, "=")
 		if ok {
-			// If there is a conflict we will return an error
 			if v, ok := labelMap[key]; ok && v != val {
-				return nil, errors.Errorf("conflict labels for %s=%s and %s=%s", key, val, key, v)
+				switch policy {
+				case LabelConflictPolicyLastWins:
+					log.G(context.TODO()).Warnf("conflicting labels for %s: keeping %q, discarding %q", key, val, v)
+				case LabelConflictPolicyFirstWins:
+					log.G(context.TODO()).Warnf("conflicting labels for %s: keeping %q, discarding %q", key, v, val)
+					continue
+				default:
+					return nil, errors.Errorf("conflict labels for %s=%s and %s=%s", key, val, key, v)
+				}
 			}
 			labelMap[key] = val
 		}
@@ -421,7 +700,7 @@ func Reload(configFile string, flags *pflag.FlagSet, reload func(*Config)) error
 	}
 
 	// Check if duplicate label-keys with different values are found
-	newLabels, err := GetConflictFreeLabels(newConfig.Labels)
+	newLabels, err := GetConflictFreeLabels(newConfig.Labels, newConfig.LabelConflictPolicy)
 	if err != nil {
 		return err
 	}
@@ -722,6 +1001,13 @@ func Validate(config *Config) error {
 		return err
 	}
 
+	// validate EmbeddedDNSAddress
+	if config.EmbeddedDNSAddress != "" {
+		if _, err := netip.ParseAddr(config.EmbeddedDNSAddress); err != nil {
+			return errors.Errorf("invalid embedded-dns-address: %v", err)
+		}
+	}
+
 	// validate Labels
 	for _, label := range config.Labels {
 		if _, err := opts.ValidateLabel(label); err != nil {
@@ -742,9 +1028,59 @@ func Validate(config *Config) error {
 	if config.MaxDownloadAttempts < 0 {
 		return errors.Errorf("invalid max download attempts: %d", config.MaxDownloadAttempts)
 	}
+	if config.MaxConcurrentStops < 0 {
+		return errors.Errorf("invalid max concurrent stops: %d", config.MaxConcurrentStops)
+	}
+	if config.ContainerCreateTimeout < 0 {
+		return errors.Errorf("invalid container create timeout: %d", config.ContainerCreateTimeout)
+	}
+	if config.BuildMaxLayers < 0 {
+		return errors.Errorf("invalid build-max-layers: %d", config.BuildMaxLayers)
+	}
+	if _, err := patternmatcher.New(config.BuildContextExcludePatterns); err != nil {
+		return errors.Wrap(err, "invalid build-context-exclude")
+	}
+	switch config.DataRootPermissionPolicy {
+	case "", DataRootPermissionPolicyFixup, DataRootPermissionPolicyWarn, DataRootPermissionPolicyFail:
+	default:
+		return errors.Errorf("invalid data-root-permission-policy: %q", config.DataRootPermissionPolicy)
+	}
+	if config.Umask != "" {
+		if _, err := strconv.ParseUint(config.Umask, 8, 32); err != nil {
+			return errors.Errorf("invalid umask: %q is not a valid octal number", config.Umask)
+		}
+	}
+	switch config.BuildSymlinkPolicy {
+	case "", BuildSymlinkPolicyReject, BuildSymlinkPolicyFollowWithin, BuildSymlinkPolicyPreserve:
+	default:
+		return errors.Errorf("invalid build-symlink-policy: %q", config.BuildSymlinkPolicy)
+	}
+	switch config.AuthzFailMode {
+	case "", AuthzFailModeClosed, AuthzFailModeOpen:
+	default:
+		return errors.Errorf("invalid authz-fail-mode: %q", config.AuthzFailMode)
+	}
+	switch config.ProxyEnvPrecedence {
+	case "", ProxyEnvPrecedenceConfig, ProxyEnvPrecedenceEnvironment:
+	default:
+		return errors.Errorf("invalid proxy-env-precedence: %q", config.ProxyEnvPrecedence)
+	}
+	switch config.PrivilegedContainersPolicy {
+	case "", PrivilegedContainersPolicyWarn, PrivilegedContainersPolicyForbid:
+	default:
+		return errors.Errorf("invalid privileged-containers-policy: %q", config.PrivilegedContainersPolicy)
+	}
+	switch config.LabelConflictPolicy {
+	case "", LabelConflictPolicyError, LabelConflictPolicyLastWins, LabelConflictPolicyFirstWins:
+	default:
+		return errors.Errorf("invalid label-conflict-policy: %q", config.LabelConflictPolicy)
+	}
 	if config.NetworkDiagnosticPort < 0 || config.NetworkDiagnosticPort > 65535 {
 		return errors.Errorf("invalid network-diagnostic-port (%d): value must be between 0 and 65535", config.NetworkDiagnosticPort)
 	}
+	if config.TCPListenBacklog < 0 {
+		return errors.Errorf("invalid tcp-listen-backlog: %d", config.TCPListenBacklog)
+	}
 
 	if _, err := ParseGenericResources(config.NodeGenericResources); err != nil {
 		return err
@@ -49,8 +49,12 @@ type BridgeConfig struct {
 	EnableIPMasq             bool   `json:"ip-masq,omitempty"`
 	EnableUserlandProxy      bool   `json:"userland-proxy,omitempty"`
 	UserlandProxyPath        string `json:"userland-proxy-path,omitempty"`
-	AllowDirectRouting       bool   `json:"allow-direct-routing,omitempty"`
-	BridgeAcceptFwMark       string `json:"bridge-accept-fwmark,omitempty"`
+	// UserlandProxyBackend selects a userland-proxy backend registered with
+	// portmapper.RegisterProxyBackend, by name. An empty value, or a name
+	// that isn't registered, falls back to the default binary backend.
+	UserlandProxyBackend string `json:"userland-proxy-backend,omitempty"`
+	AllowDirectRouting   bool   `json:"allow-direct-routing,omitempty"`
+	BridgeAcceptFwMark   string `json:"bridge-accept-fwmark,omitempty"`
 }
 
 // DefaultBridgeConfig stores all the parameters for the default bridge network.
@@ -94,6 +98,24 @@ type Config struct {
 	// ResolvConf is the path to the configuration of the host resolver
 	ResolvConf string `json:"resolv-conf,omitempty"`
 	Rootless   bool   `json:"rootless,omitempty"`
+
+	// DefaultMemorySwappiness is the memory swappiness (0-100) applied to
+	// containers that don't set HostConfig.MemorySwappiness. A nil value
+	// (the default) leaves the kernel's own default in effect.
+	DefaultMemorySwappiness *int64 `json:"default-memory-swappiness,omitempty"`
+
+	// DefaultBlkioWeight is the block IO weight (10-1000) applied to
+	// containers that don't set HostConfig.BlkioWeight, so disk IO is
+	// fairly shared among containers by default. A nil value (the default)
+	// leaves the kernel's own default in effect. A container-specified
+	// weight always takes precedence over this default.
+	DefaultBlkioWeight *uint16 `json:"default-blkio-weight,omitempty"`
+
+	// DefaultSecurityOpts is a list of "--security-opt"-style options merged
+	// into every container's HostConfig.SecurityOpt at create time. An option
+	// that the container already sets (matched by key, e.g. "apparmor" or
+	// "no-new-privileges") is left untouched; the container's own value wins.
+	DefaultSecurityOpts []string `json:"default-security-opts,omitempty"`
 }
 
 // GetExecRoot returns the user configured Exec-root
@@ -324,10 +346,20 @@ func validateFwMarkMask(val string) error {
 }
 
 func verifyDefaultCgroupNsMode(mode string) error {
+	return verifyDefaultCgroupNsModeForCgroupVersion(mode, cgroups.Mode())
+}
+
+// verifyDefaultCgroupNsModeForCgroupVersion is the testable core of
+// verifyDefaultCgroupNsMode, taking the host's cgroup mode as a parameter
+// instead of querying it directly.
+func verifyDefaultCgroupNsModeForCgroupVersion(mode string, cgroupMode cgroups.CGMode) error {
 	cm := container.CgroupnsMode(mode)
 	if !cm.Valid() {
 		return fmt.Errorf(`invalid default cgroup namespace (%v): use "host" or "private"`, cm)
 	}
+	if cm.IsPrivate() && cgroupMode != cgroups.Unified {
+		return fmt.Errorf(`invalid default cgroup namespace (%v): "private" requires cgroup v2`, cm)
+	}
 
 	return nil
 }
@@ -4,6 +4,7 @@
 	"net/netip"
 	"testing"
 
+	"github.com/containerd/cgroups/v3"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/moby/moby/api/types/container"
 	dopts "github.com/moby/moby/v2/daemon/internal/opts"
@@ -464,3 +465,38 @@ func TestValidateAcceptFwMarkMark(t *testing.T) {
 		})
 	}
 }
+
+func TestVerifyDefaultCgroupNsModeForCgroupVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       string
+		cgroupMode cgroups.CGMode
+		expErr     string
+	}{
+		{name: "host on v1", mode: "host", cgroupMode: cgroups.Legacy},
+		{name: "host on v2", mode: "host", cgroupMode: cgroups.Unified},
+		{name: "private on v2", mode: "private", cgroupMode: cgroups.Unified},
+		{
+			name:       "private on v1",
+			mode:       "private",
+			cgroupMode: cgroups.Legacy,
+			expErr:     `"private" requires cgroup v2`,
+		},
+		{
+			name:       "invalid mode",
+			mode:       "bogus",
+			cgroupMode: cgroups.Unified,
+			expErr:     `use "host" or "private"`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifyDefaultCgroupNsModeForCgroupVersion(tc.mode, tc.cgroupMode)
+			if tc.expErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Check(t, is.ErrorContains(err, tc.expErr))
+			}
+		})
+	}
+}
@@ -287,6 +287,15 @@ func TestValidateConfigurationErrors(t *testing.T) {
 			},
 			expectedErr: "invalid max concurrent downloads: -10",
 		},
+		{
+			name: "invalid umask",
+			config: &Config{
+				CommonConfig: CommonConfig{
+					Umask: "not-octal",
+				},
+			},
+			expectedErr: "invalid umask",
+		},
 		{
 			name: "negative max-concurrent-uploads",
 			config: &Config{
@@ -336,6 +345,15 @@ func TestValidateConfigurationErrors(t *testing.T) {
 			},
 			expectedErr: "invalid network-diagnostic-port (65536): value must be between 0 and 65535",
 		},
+		{
+			name: "negative tcp-listen-backlog",
+			config: &Config{
+				CommonConfig: CommonConfig{
+					TCPListenBacklog: -1,
+				},
+			},
+			expectedErr: "invalid tcp-listen-backlog: -1",
+		},
 		{
 			name: "generic resource without =",
 			config: &Config{
@@ -579,6 +597,15 @@ func TestValidateConfiguration(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:  "with umask",
+			field: "Umask",
+			config: &Config{
+				CommonConfig: CommonConfig{
+					Umask: "0022",
+				},
+			},
+		},
 	}
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -785,6 +812,49 @@ func TestReloadWithDuplicateLabels(t *testing.T) {
 	assert.Check(t, reloaded)
 }
 
+func TestGetConflictFreeLabels(t *testing.T) {
+	conflicting := []string{"foo=bar", "foo=baz"}
+
+	tests := []struct {
+		name      string
+		policy    string
+		expLabels []string
+		expErrMsg string
+	}{
+		{
+			name:      "default policy errors on conflict",
+			policy:    "",
+			expErrMsg: "conflict labels for foo=baz and foo=bar",
+		},
+		{
+			name:      "error policy errors on conflict",
+			policy:    LabelConflictPolicyError,
+			expErrMsg: "conflict labels for foo=baz and foo=bar",
+		},
+		{
+			name:      "last-wins policy keeps the last value",
+			policy:    LabelConflictPolicyLastWins,
+			expLabels: []string{"foo=baz"},
+		},
+		{
+			name:      "first-wins policy keeps the first value",
+			policy:    LabelConflictPolicyFirstWins,
+			expLabels: []string{"foo=bar"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := GetConflictFreeLabels(conflicting, tc.policy)
+			if tc.expErrMsg != "" {
+				assert.Check(t, is.ErrorContains(err, tc.expErrMsg))
+				return
+			}
+			assert.Check(t, err)
+			assert.Check(t, is.DeepEqual(got, tc.expLabels))
+		})
+	}
+}
+
 func TestMaskURLCredentials(t *testing.T) {
 	tests := []struct {
 		rawURL    string
@@ -6,9 +6,11 @@
 	"maps"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"slices"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/containerd/log"
@@ -18,6 +20,7 @@
 	"github.com/moby/moby/v2/daemon/config"
 	"github.com/moby/moby/v2/daemon/container"
 	"github.com/moby/moby/v2/daemon/internal/image"
+	"github.com/moby/moby/v2/daemon/libnetwork/resolvconf"
 	"github.com/moby/moby/v2/daemon/network"
 	"github.com/moby/moby/v2/daemon/pkg/oci/caps"
 	"github.com/moby/moby/v2/daemon/pkg/opts"
@@ -35,6 +38,10 @@
 //   - A partial container ID prefix (e.g. short ID) of any length that is
 //     unique enough to only return a single container object
 //     If none of these searches succeed, an error is returned
+//
+// If a partial ID prefix matches more than one container, the returned error
+// wraps an ambiguous-prefix error that satisfies [cerrdefs.IsConflict],
+// instead of the not-found error returned when the prefix matches nothing.
 func (daemon *Daemon) GetContainer(prefixOrName string) (*container.Container, error) {
 	if prefixOrName == "" {
 		return nil, errors.WithStack(invalidIdentifier(prefixOrName))
@@ -69,6 +76,94 @@ func (daemon *Daemon) GetContainer(prefixOrName string) (*container.Container, e
 	return ctr, nil
 }
 
+// GetContainers resolves multiple container references (IDs, names, or
+// unique ID prefixes) in a single pass over one consistent replica snapshot,
+// instead of calling GetContainer for each reference in a loop. It uses the
+// same resolution order as GetContainer (exact ID, exact name, unique
+// prefix) for each reference.
+//
+// It returns the containers that were found, in no particular order, and a
+// map from reference to error for the references that couldn't be resolved.
+func (daemon *Daemon) GetContainers(refs []string) ([]*container.Container, map[string]error) {
+	view := daemon.containersReplica.Snapshot()
+
+	var found []*container.Container
+	errs := make(map[string]error)
+	for _, ref := range refs {
+		if ref == "" {
+			errs[ref] = errors.WithStack(invalidIdentifier(ref))
+			continue
+		}
+
+		if containerByID := daemon.containers.Get(ref); containerByID != nil {
+			// ref is an exact match to a full container ID
+			found = append(found, containerByID)
+			continue
+		}
+
+		fullName := ref
+		if fullName[0] != '/' {
+			fullName = "/" + fullName
+		}
+		id, err := view.GetID(fullName)
+		if err != nil {
+			// ref didn't match a reserved name; fall back to prefix matching
+			if id, err = view.GetByPrefix(ref); err != nil {
+				errs[ref] = err
+				continue
+			}
+		}
+
+		ctr := daemon.containers.Get(id)
+		if ctr == nil {
+			// Updates to the daemon.containersReplica ViewDB are not atomic
+			// or consistent w.r.t. the live daemon.containers Store so
+			// while reaching this code path may be indicative of a bug,
+			// it is not _necessarily_ the case.
+			log.G(context.TODO()).WithField("ref", ref).
+				WithField("id", id).
+				Debugf("daemon.GetContainers: container is known to daemon.containersReplica but not daemon.containers")
+			errs[ref] = containerNotFound(ref)
+			continue
+		}
+		found = append(found, ctr)
+	}
+	return found, errs
+}
+
+// GetContainerByLabel returns the single container whose Config.Labels has
+// the given key set to value. It returns an [errdefs.NotFound] if no
+// container matches, and an [errAmbiguousLabel] satisfying
+// [cerrdefs.IsConflict] if more than one does.
+func (daemon *Daemon) GetContainerByLabel(key, value string) (*container.Container, error) {
+	all, err := daemon.containersReplica.Snapshot().All()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, c := range all {
+		if c.Labels[key] == value {
+			ids = append(ids, c.ID)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return nil, containerNotFound(fmt.Sprintf("label %s=%s", key, value))
+	case 1:
+		ctr := daemon.containers.Get(ids[0])
+		if ctr == nil {
+			log.G(context.TODO()).WithField("key", key).WithField("value", value).WithField("id", ids[0]).
+				Debugf("daemon.GetContainerByLabel: container is known to daemon.containersReplica but not daemon.containers")
+			return nil, containerNotFound(fmt.Sprintf("label %s=%s", key, value))
+		}
+		return ctr, nil
+	default:
+		return nil, errAmbiguousLabel{key: key, value: value, ids: ids}
+	}
+}
+
 // Load reads the contents of a container from disk
 // This is typically done at startup.
 func (daemon *Daemon) load(id string) (*container.Container, error) {
@@ -181,6 +276,7 @@ func (daemon *Daemon) GetByName(name string) (*container.Container, error) {
 // Dependencies are determined by:
 //   - Network mode dependencies (--network=container:xxx)
 //   - Legacy container links (--link)
+//   - Volumes-from dependencies (--volumes-from)
 //
 // This is primarily used during daemon startup to determine container startup order,
 // ensuring that dependent containers are started after their dependencies are running.
@@ -199,9 +295,68 @@ func (daemon *Daemon) GetDependentContainers(c *container.Container) []*containe
 		dependentContainers = append(dependentContainers, dependencyContainer)
 	}
 
+	parser := volumemounts.NewParser()
+	for _, v := range c.HostConfig.VolumesFrom {
+		containerID, _, err := parser.ParseVolumesFrom(v)
+		if err != nil {
+			log.G(context.TODO()).WithError(err).Errorf("Could not parse volumes-from for %s", c.ID)
+			return dependentContainers
+		}
+		dependencyContainer, err := daemon.GetContainer(containerID)
+		if err != nil {
+			log.G(context.TODO()).WithError(err).Errorf("Could not find dependent container for %s", c.ID)
+			return dependentContainers
+		}
+		dependentContainers = append(dependentContainers, dependencyContainer)
+	}
+
 	return append(dependentContainers, slices.Collect(maps.Values(daemon.linkIndex.children(c)))...)
 }
 
+// checkDependencyCycle walks the dependency graph rooted at c (as returned
+// by GetDependentContainers) and returns an [errDependencyCycle] if the walk
+// revisits a container already on the current path. This can happen after a
+// crash or restore leaves two or more containers referencing each other,
+// e.g. via mutual "--network=container:" HostConfig settings, which would
+// otherwise make daemon startup ordering loop indefinitely.
+func (daemon *Daemon) checkDependencyCycle(c *container.Container) error {
+	return daemon.walkDependencies(c, nil)
+}
+
+func (daemon *Daemon) walkDependencies(c *container.Container, path []string) error {
+	for _, id := range path {
+		if id == c.ID {
+			return errDependencyCycle{ids: append(append([]string{}, path...), c.ID)}
+		}
+	}
+	path = append(path, c.ID)
+	for _, dep := range daemon.GetDependentContainers(c) {
+		if err := daemon.walkDependencies(dep, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetDependentsOf returns the containers that depend on c through a
+// --network=container:<c> network-mode connection. This is the reverse of
+// the network-mode half of GetDependentContainers: it answers "who shares
+// c's network namespace", used to decide whether removing c is safe.
+func (daemon *Daemon) GetDependentsOf(c *container.Container) []*container.Container {
+	var dependents []*container.Container
+	for _, other := range daemon.List() {
+		if other.ID == c.ID || !other.HostConfig.NetworkMode.IsContainer() {
+			continue
+		}
+		dependency, err := daemon.GetContainer(other.HostConfig.NetworkMode.ConnectedContainer())
+		if err != nil || dependency.ID != c.ID {
+			continue
+		}
+		dependents = append(dependents, other)
+	}
+	return dependents
+}
+
 func (daemon *Daemon) setSecurityOptions(cfg *config.Config, container *container.Container) error {
 	container.Lock()
 	defer container.Unlock()
@@ -210,18 +365,41 @@ func (daemon *Daemon) setSecurityOptions(cfg *config.Config, container *containe
 
 // verifyContainerSettings performs validation of the hostconfig and config
 // structures.
-func (daemon *Daemon) verifyContainerSettings(daemonCfg *configStore, hostConfig *containertypes.HostConfig, config *containertypes.Config, update bool) (warnings []string, _ error) {
+func (daemon *Daemon) verifyContainerSettings(daemonCfg *configStore, hostConfig *containertypes.HostConfig, containerConfig *containertypes.Config, update bool) (warnings []string, _ error) {
 	// First perform verification of settings common across all platforms.
-	if err := validateContainerConfig(config); err != nil {
-		return nil, err
+	warns, err := validateContainerConfig(containerConfig)
+	warnings = append(warnings, warns...)
+	if err != nil {
+		return warnings, err
 	}
 
-	warns, err := validateHostConfig(hostConfig)
+	warns, err = validateHostConfig(hostConfig)
 	warnings = append(warnings, warns...)
 	if err != nil {
 		return warnings, err
 	}
 
+	if hostConfig != nil && hostConfig.Privileged {
+		switch daemonCfg.PrivilegedContainersPolicy {
+		case config.PrivilegedContainersPolicyForbid:
+			return warnings, errors.Errorf("privileged containers are forbidden by daemon configuration")
+		default:
+			warnings = append(warnings, "This container is privileged, which grants it full access to the host and disables most container isolation. Privileged containers should be avoided on shared or multi-tenant hosts.")
+		}
+	}
+
+	if hostConfig != nil && hostConfig.ExtraHostsFile != "" {
+		if err := validateExtraHostsFile(daemonCfg, hostConfig.ExtraHostsFile); err != nil {
+			return warnings, err
+		}
+	}
+
+	if hostConfig != nil && hostConfig.ResolvConfTemplate != "" {
+		if err := validateResolvConfTemplate(daemonCfg, hostConfig.ResolvConfTemplate); err != nil {
+			return warnings, err
+		}
+	}
+
 	// Now do platform-specific verification
 	warns, err = verifyPlatformContainerSettings(daemon, daemonCfg, hostConfig, update)
 	warnings = append(warnings, warns...)
@@ -229,25 +407,172 @@ func (daemon *Daemon) verifyContainerSettings(daemonCfg *configStore, hostConfig
 	return warnings, err
 }
 
-func validateContainerConfig(config *containertypes.Config) error {
+// validateExtraHostsFile checks that path is located within one of the
+// daemon's configured allowed directories, and that its contents can be
+// parsed as extra-hosts entries. It doesn't return the parsed entries: the
+// file is re-read (and merged into the container's /etc/hosts) at container
+// start, since it may be updated between the container being created and
+// started.
+func validateExtraHostsFile(daemonCfg *configStore, path string) error {
+	if !filepath.IsAbs(path) {
+		return errors.Errorf("extra-hosts file %q must be an absolute path", path)
+	}
+
+	var allowed bool
+	for _, dir := range daemonCfg.AllowedExtraHostsFileDirs {
+		if rel, err := filepath.Rel(dir, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return errors.Errorf("extra-hosts file %q is not in an allowed directory; allowed directories can be configured with allowed-extra-hosts-file-dirs", path)
+	}
+
+	if _, err := opts.ParseExtraHostsFile(path); err != nil {
+		return err
+	}
+	return nil
+}
+
+// validateResolvConfTemplate checks that path is located within one of the
+// daemon's configured allowed directories, and that its contents can be
+// parsed as a resolv.conf. It doesn't return the parsed content: the file is
+// re-read (and used verbatim as the container's resolv.conf) when the
+// container's networking is set up, since it may be updated between the
+// container being created and started.
+func validateResolvConfTemplate(daemonCfg *configStore, path string) error {
+	if !filepath.IsAbs(path) {
+		return errors.Errorf("resolv.conf template %q must be an absolute path", path)
+	}
+
+	var allowed bool
+	for _, dir := range daemonCfg.AllowedResolvConfTemplateDirs {
+		if rel, err := filepath.Rel(dir, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return errors.Errorf("resolv.conf template %q is not in an allowed directory; allowed directories can be configured with allowed-resolv-conf-template-dirs", path)
+	}
+
+	if err := resolvconf.Load(path); err != nil {
+		return errors.Wrapf(err, "invalid resolv.conf template %q", path)
+	}
+	return nil
+}
+
+func validateContainerConfig(config *containertypes.Config) (warnings []string, _ error) {
 	if config == nil {
-		return nil
+		return nil, nil
 	}
 	if err := translateWorkingDir(config); err != nil {
-		return err
+		return nil, err
 	}
+	var stopSig syscall.Signal
 	if config.StopSignal != "" {
-		if _, err := signal.ParseSignal(config.StopSignal); err != nil {
-			return err
+		sig, err := signal.ParseSignal(config.StopSignal)
+		if err != nil {
+			return nil, err
 		}
+		stopSig = sig
+	}
+	if config.StopTimeout != nil && *config.StopTimeout < 0 {
+		return nil, errdefs.InvalidParameter(fmt.Errorf("invalid StopTimeout: %d, must be >= 0", *config.StopTimeout))
+	}
+	if stopSig == syscall.SIGKILL && config.StopTimeout != nil && *config.StopTimeout != 0 {
+		warnings = append(warnings, fmt.Sprintf("StopSignal SIGKILL ignores StopTimeout (%ds); the container is killed immediately", *config.StopTimeout))
+	}
+	if config.StdinOnce && !config.OpenStdin {
+		return nil, errors.New("StdinOnce can only be set when OpenStdin is also enabled")
 	}
 	// Validate if Env contains empty variable or not (e.g., ``, `=foo`)
 	for _, env := range config.Env {
 		if _, err := opts.ValidateEnv(env); err != nil {
-			return err
+			return nil, err
 		}
 	}
-	return validateHealthCheck(config.Healthcheck)
+	warns, err := validateEnvNames(config.Env)
+	warnings = append(warnings, warns...)
+	if err != nil {
+		return warnings, err
+	}
+	// An empty Hostname is left for newContainer to default to the
+	// container's short-ID, which is always RFC 1123 compliant.
+	if config.Hostname != "" {
+		if err := validateHostname(config.Hostname); err != nil {
+			return warnings, err
+		}
+	}
+	warns, err = validateHealthCheck(config.Healthcheck)
+	warnings = append(warnings, warns...)
+	return warnings, err
+}
+
+// rfc1123Label matches a single hostname label: 1 to 63 characters, starting
+// and ending with an alphanumeric or underscore, with alphanumerics,
+// hyphens, and underscores in between. Strict RFC 1123 doesn't allow
+// underscores, but Docker has long accepted them in hostnames (e.g. to
+// match a Compose service name), so this permits that one deviation while
+// still catching the cases that actually break things downstream, such as
+// whitespace, most punctuation, and overlong labels.
+var rfc1123Label = regexp.MustCompile(`^[a-zA-Z0-9_]([a-zA-Z0-9_-]{0,61}[a-zA-Z0-9_])?$`)
+
+// validateHostname rejects hostnames that don't comply with rfc1123Label:
+// each dot-separated label must be 1-63 characters, made up of
+// alphanumerics, hyphens, and underscores, and not start or end with a
+// hyphen.
+func validateHostname(hostname string) error {
+	for _, label := range strings.Split(hostname, ".") {
+		if !rfc1123Label.MatchString(label) {
+			return errors.Errorf("invalid hostname %q: label %q does not conform to RFC 1123 (1-63 alphanumeric characters, hyphens, or underscores, not starting or ending with a hyphen)", hostname, label)
+		}
+	}
+	return nil
+}
+
+// posixEnvName matches POSIX portable environment variable names: a leading
+// letter or underscore, followed by any number of letters, digits, or
+// underscores.
+var posixEnvName = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// validateEnvNames rejects Env entries whose name doesn't conform to the
+// POSIX portable environment variable name rules, and warns when a name
+// duplicates an earlier entry differing only in case (the last value wins,
+// silently, today).
+func validateEnvNames(env []string) (warnings []string, _ error) {
+	seen := make(map[string]string, len(env))
+	for _, e := range env {
+		name, _, _ := strings.Cut(e, "=")
+		if !posixEnvName.MatchString(name) {
+			return warnings, errors.Errorf("invalid environment variable name %q: names must match POSIX portable character rules ([a-zA-Z_][a-zA-Z0-9_]*)", name)
+		}
+		key := strings.ToLower(name)
+		if prev, ok := seen[key]; ok && prev != name {
+			warnings = append(warnings, fmt.Sprintf("environment variable %q duplicates %q (differing only in case); the last value takes effect", name, prev))
+		}
+		seen[key] = name
+	}
+	return warnings, nil
+}
+
+// validateDevices rejects device mappings that map the same device more than
+// once with conflicting cgroup permissions, which would otherwise silently
+// apply whichever permission set happens to be applied last.
+func validateDevices(devices []containertypes.DeviceMapping) error {
+	perms := make(map[string]string, len(devices))
+	for _, d := range devices {
+		key := d.PathInContainer
+		if key == "" {
+			key = d.PathOnHost
+		}
+		if prev, ok := perms[key]; ok && prev != d.CgroupPermissions {
+			return errors.Errorf("conflicting permissions %q and %q for device %q", prev, d.CgroupPermissions, key)
+		}
+		perms[key] = d.CgroupPermissions
+	}
+	return nil
 }
 
 func validateHostConfig(hostConfig *containertypes.HostConfig) (warnings []string, _ error) {
@@ -285,6 +610,9 @@ func validateHostConfig(hostConfig *containertypes.HostConfig) (warnings []strin
 	if err := validateCapabilities(hostConfig); err != nil {
 		return warnings, err
 	}
+	if err := validateDevices(hostConfig.Devices); err != nil {
+		return warnings, err
+	}
 	if !hostConfig.Isolation.IsValid() {
 		return warnings, errors.Errorf("invalid isolation '%s' on %s", hostConfig.Isolation, runtime.GOOS)
 	}
@@ -293,9 +621,58 @@ func validateHostConfig(hostConfig *containertypes.HostConfig) (warnings []strin
 			return warnings, errors.Errorf("invalid Annotations: the empty string is not permitted as an annotation key")
 		}
 	}
+	if hostConfig.ForwardStopSignal && !hostConfig.PidMode.IsPrivate() {
+		warnings = append(warnings, "ForwardStopSignal has no effect when PidMode is not private")
+	}
+	if hostConfig.ReadonlyRootfs && !hasWritableMount(hostConfig, parser) {
+		warnings = append(warnings, "ReadonlyRootfs is set but no writable mounts (tmpfs, volumes, or bind mounts) were found; processes in the container will be unable to write anywhere, including to /tmp")
+	}
+	if len(hostConfig.InitArgs) > 0 && hostConfig.Init != nil && !*hostConfig.Init {
+		warnings = append(warnings, "InitArgs is set but Init is disabled; the arguments will be ignored")
+	}
+	if hostConfig.TimeNsOffsetSeconds != nil {
+		if offset := *hostConfig.TimeNsOffsetSeconds; offset < -maxTimeNsOffsetSeconds || offset > maxTimeNsOffsetSeconds {
+			return warnings, errors.Errorf("invalid TimeNsOffsetSeconds %d: must be between %d and %d", offset, -maxTimeNsOffsetSeconds, maxTimeNsOffsetSeconds)
+		}
+	}
+	if !hostConfig.DNSFailoverStrategy.Valid() {
+		return warnings, errors.Errorf("invalid DNS failover strategy: %q", hostConfig.DNSFailoverStrategy)
+	}
 	return warnings, nil
 }
 
+// maxTimeNsOffsetSeconds bounds HostConfig.TimeNsOffsetSeconds to a sane
+// range (roughly 100 years) to reject obviously mistaken values while still
+// allowing shifting the clock arbitrarily far for testing purposes.
+const maxTimeNsOffsetSeconds = 100 * 365 * 24 * 60 * 60
+
+// hasWritableMount reports whether hostConfig provides at least one writable
+// location inside the container: a tmpfs mount, a mount that isn't read-only,
+// or a legacy bind/volume that isn't mounted read-only.
+func hasWritableMount(hostConfig *containertypes.HostConfig, parser volumemounts.Parser) bool {
+	if len(hostConfig.Tmpfs) > 0 {
+		return true
+	}
+	for _, m := range hostConfig.Mounts {
+		if !m.ReadOnly {
+			return true
+		}
+	}
+	for _, b := range hostConfig.Binds {
+		bind, err := parser.ParseMountRaw(b, hostConfig.VolumeDriver)
+		if err != nil {
+			// Malformed binds are rejected when the container's mount
+			// points are registered; don't pile on with an unrelated
+			// warning here.
+			return true
+		}
+		if bind.RW {
+			return true
+		}
+	}
+	return false
+}
+
 func validateCapabilities(hostConfig *containertypes.HostConfig) error {
 	if _, err := caps.NormalizeLegacyCapabilities(hostConfig.CapAdd); err != nil {
 		return errors.Wrap(err, "invalid CapAdd")
@@ -307,27 +684,64 @@ func validateCapabilities(hostConfig *containertypes.HostConfig) error {
 	return nil
 }
 
-// validateHealthCheck validates the healthcheck params of Config
-func validateHealthCheck(healthConfig *containertypes.HealthConfig) error {
+// EffectiveCapabilities resolves the set of Linux capabilities a container
+// with the given hostConfig will run with, by applying hostConfig.CapAdd,
+// hostConfig.CapDrop, and hostConfig.Privileged to the daemon's default
+// capability set. It reuses the same normalization and resolution logic
+// used when assembling the container's OCI spec, so the result reflects
+// what the container will actually run with.
+func EffectiveCapabilities(hostConfig *containertypes.HostConfig) ([]string, error) {
+	return caps.TweakCapabilities(
+		caps.DefaultCapabilities(),
+		hostConfig.CapAdd,
+		hostConfig.CapDrop,
+		hostConfig.Privileged,
+	)
+}
+
+// maxHealthCheckTestLen is the maximum number of entries allowed in
+// HealthConfig.Test, to guard against absurdly large generated configs that
+// would otherwise be re-marshaled and rescheduled on every probe.
+const maxHealthCheckTestLen = 256
+
+// validateHealthCheck validates the healthcheck params of Config, returning
+// any non-fatal warnings alongside a fatal error, if any.
+func validateHealthCheck(healthConfig *containertypes.HealthConfig) (warnings []string, _ error) {
 	if healthConfig == nil {
-		return nil
+		return nil, nil
 	}
 	if healthConfig.Interval != 0 && healthConfig.Interval < containertypes.MinimumDuration {
-		return errors.Errorf("Interval in Healthcheck cannot be less than %s", containertypes.MinimumDuration)
+		return nil, errors.Errorf("Interval in Healthcheck cannot be less than %s", containertypes.MinimumDuration)
 	}
 	if healthConfig.Timeout != 0 && healthConfig.Timeout < containertypes.MinimumDuration {
-		return errors.Errorf("Timeout in Healthcheck cannot be less than %s", containertypes.MinimumDuration)
+		return nil, errors.Errorf("Timeout in Healthcheck cannot be less than %s", containertypes.MinimumDuration)
 	}
 	if healthConfig.Retries < 0 {
-		return errors.Errorf("Retries in Healthcheck cannot be negative")
+		return nil, errors.Errorf("Retries in Healthcheck cannot be negative")
 	}
 	if healthConfig.StartPeriod != 0 && healthConfig.StartPeriod < containertypes.MinimumDuration {
-		return errors.Errorf("StartPeriod in Healthcheck cannot be less than %s", containertypes.MinimumDuration)
+		return nil, errors.Errorf("StartPeriod in Healthcheck cannot be less than %s", containertypes.MinimumDuration)
 	}
 	if healthConfig.StartInterval != 0 && healthConfig.StartInterval < containertypes.MinimumDuration {
-		return errors.Errorf("StartInterval in Healthcheck cannot be less than %s", containertypes.MinimumDuration)
+		return nil, errors.Errorf("StartInterval in Healthcheck cannot be less than %s", containertypes.MinimumDuration)
 	}
-	return nil
+	if len(healthConfig.Test) > maxHealthCheckTestLen {
+		return nil, errdefs.InvalidParameter(errors.Errorf("Test in Healthcheck cannot have more than %d entries", maxHealthCheckTestLen))
+	}
+	if len(healthConfig.Test) > 0 && (healthConfig.Test[0] == "CMD" || healthConfig.Test[0] == "CMD-SHELL") {
+		for _, arg := range healthConfig.Test[1:] {
+			if arg == "" {
+				return nil, errdefs.InvalidParameter(errors.Errorf("Test in Healthcheck cannot contain an empty string"))
+			}
+		}
+	}
+	if len(healthConfig.Test) > 0 && healthConfig.Test[0] != "NONE" && healthConfig.Retries == 0 {
+		warnings = append(warnings, "Healthcheck has Retries: 0, which does not disable retries: the image's healthcheck Retries, or the default of 3, is used instead")
+	}
+	if healthConfig.StartInterval != 0 && healthConfig.Interval != 0 && healthConfig.StartInterval > healthConfig.Interval {
+		warnings = append(warnings, fmt.Sprintf("Healthcheck StartInterval (%s) is greater than Interval (%s), so start-period probing will be slower than steady-state probing", healthConfig.StartInterval, healthConfig.Interval))
+	}
+	return warnings, nil
 }
 
 func validatePortBindings(ports networktypes.PortMap) error {
@@ -98,6 +98,8 @@ type Container struct {
 	ImagePlatform ocispec.Platform
 
 	RestartCount             int
+	LastRestartAt            time.Time `json:",omitempty"`
+	LastRestartReason        string    `json:",omitempty"`
 	HasBeenStartedBefore     bool
 	HasBeenManuallyStopped   bool // used for unless-stopped restart policy
 	HasBeenManuallyRestarted bool `json:"-"` // used to distinguish restart caused by restart policy from the manual one
@@ -131,6 +133,10 @@ type SecurityOptions struct {
 	MountLabel      string
 	ProcessLabel    string
 	AppArmorProfile string
+	// AppArmorTweaks is a list of validated "kind:path" additive rules (e.g.
+	// "allow-write:/data/**") that the daemon compiles into a profile derived
+	// from AppArmorProfile (or the platform default) and loads in place of it.
+	AppArmorTweaks  []string
 	SeccompProfile  string
 	NoNewPrivileges bool
 	WritableCgroups *bool
@@ -518,6 +524,19 @@ func (container *Container) StartLogger() (logger.Logger, error) {
 	return l, nil
 }
 
+// LogDropStats returns the number of messages and bytes that have been
+// discarded from the container's log stream because the configured log
+// driver could not keep up (backpressure). ok is false if the driver does
+// not track this, which is the case unless logging mode is "non-blocking".
+func (container *Container) LogDropStats() (messages, bytes int64, ok bool) {
+	dc, isDropCounter := container.LogDriver.(logger.LogDropCounter)
+	if !isDropCounter {
+		return 0, 0, false
+	}
+	messages, bytes = dc.DroppedStats()
+	return messages, bytes, true
+}
+
 // GetProcessLabel returns the process label for the container.
 func (container *Container) GetProcessLabel() string {
 	// even if we have a process label return "" if we are running
@@ -550,7 +569,9 @@ func (container *Container) ShouldRestart() bool {
 	return shouldRestart
 }
 
-// AddMountPointWithVolume adds a new mount point configured with a volume to the container.
+// AddMountPointWithVolume adds a new mount point configured with a volume to
+// the container. It is used for anonymous volumes created for image-declared
+// VOLUME instructions that the user didn't already override.
 func (container *Container) AddMountPointWithVolume(destination string, vol volume.Volume, rw bool) {
 	volumeParser := volumemounts.NewParser()
 	container.MountPoints[destination] = &volumemounts.MountPoint{
@@ -561,6 +582,7 @@ func (container *Container) AddMountPointWithVolume(destination string, vol volu
 		RW:          rw,
 		Volume:      vol,
 		CopyData:    volumeParser.DefaultCopyMode(),
+		Origin:      volumemounts.MountOriginImageVolume,
 	}
 }
 
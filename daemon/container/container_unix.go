@@ -434,14 +434,16 @@ func (container *Container) GetMountPoints() []containertypes.MountPoint {
 	mountPoints := make([]containertypes.MountPoint, 0, len(container.MountPoints))
 	for _, m := range container.MountPoints {
 		mountPoints = append(mountPoints, containertypes.MountPoint{
-			Type:        m.Type,
-			Name:        m.Name,
-			Source:      m.Path(),
-			Destination: m.Destination,
-			Driver:      m.Driver,
-			Mode:        m.Mode,
-			RW:          m.RW,
-			Propagation: m.Propagation,
+			Type:          m.Type,
+			Name:          m.Name,
+			Source:        m.Path(),
+			Destination:   m.Destination,
+			Driver:        m.Driver,
+			Mode:          m.Mode,
+			RW:            m.RW,
+			Propagation:   m.Propagation,
+			Origin:        string(m.Origin),
+			FromContainer: m.FromContainer,
 		})
 	}
 	return mountPoints
@@ -0,0 +1,68 @@
+//go:build !windows
+
+package container
+
+import (
+	"testing"
+
+	mounttypes "github.com/moby/moby/api/types/mount"
+	"github.com/moby/moby/v2/daemon/volume/mounts"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+// TestGetMountPointsOrigin asserts that GetMountPoints carries the origin
+// classification (and, for volumes-from mounts, the source container ID)
+// through to the API-facing container.MountPoint for every kind of mount a
+// container can have.
+func TestGetMountPointsOrigin(t *testing.T) {
+	c := &Container{
+		MountPoints: map[string]*mounts.MountPoint{
+			"/image-vol": {
+				Destination: "/image-vol",
+				Type:        mounttypes.TypeVolume,
+				Origin:      mounts.MountOriginImageVolume,
+			},
+			"/user-vol": {
+				Destination: "/user-vol",
+				Type:        mounttypes.TypeVolume,
+				Origin:      mounts.MountOriginUserVolume,
+			},
+			"/bind": {
+				Destination: "/bind",
+				Type:        mounttypes.TypeBind,
+				Source:      "/host/path",
+				Origin:      mounts.MountOriginBind,
+			},
+			"/tmp": {
+				Destination: "/tmp",
+				Type:        mounttypes.TypeTmpfs,
+				Origin:      mounts.MountOriginTmpfs,
+			},
+			"/from-other": {
+				Destination:   "/from-other",
+				Type:          mounttypes.TypeVolume,
+				Origin:        mounts.MountOriginVolumesFrom,
+				FromContainer: "source-container-id",
+			},
+		},
+	}
+
+	got := map[string]containertypesMountPoint{}
+	for _, mp := range c.GetMountPoints() {
+		got[mp.Destination] = containertypesMountPoint{origin: mp.Origin, fromContainer: mp.FromContainer}
+	}
+
+	assert.Check(t, is.DeepEqual(got["/image-vol"], containertypesMountPoint{origin: "image-volume"}))
+	assert.Check(t, is.DeepEqual(got["/user-vol"], containertypesMountPoint{origin: "user-volume"}))
+	assert.Check(t, is.DeepEqual(got["/bind"], containertypesMountPoint{origin: "bind"}))
+	assert.Check(t, is.DeepEqual(got["/tmp"], containertypesMountPoint{origin: "tmpfs"}))
+	assert.Check(t, is.DeepEqual(got["/from-other"], containertypesMountPoint{origin: "volumes-from", fromContainer: "source-container-id"}))
+}
+
+// containertypesMountPoint narrows containertypes.MountPoint down to the two
+// fields under test, so failures print a small, readable diff.
+type containertypesMountPoint struct {
+	origin        string
+	fromContainer string
+}
@@ -100,36 +100,31 @@ func NewViewDB() (*ViewDB, error) {
 }
 
 // GetByPrefix returns a container with the given ID prefix. It returns an
-// error if an empty prefix was given or if multiple containers match the prefix.
-// It returns an [errdefs.NotFound] if the given s yielded no results.
+// error if an empty prefix was given. It returns an [errAmbiguousPrefix] if
+// more than one container matches the prefix, and an [errdefs.NotFound] if
+// the given s yielded no results.
+//
+// It takes a fresh [ViewDB.Snapshot]; callers resolving multiple references
+// should take their own Snapshot and call [View.GetByPrefix] on it instead,
+// so that all lookups are made against the same consistent view.
 func (db *ViewDB) GetByPrefix(s string) (string, error) {
-	if s == "" {
-		return "", errdefs.InvalidParameter(errors.New("prefix can't be empty"))
-	}
-	iter, err := db.store.Txn(false).Get(memdbContainersTable, memdbIDIndexPrefix, s)
-	if err != nil {
-		return "", errdefs.System(err)
-	}
-
-	var id string
-	for {
-		item := iter.Next()
-		if item == nil {
-			break
-		}
-		if id != "" {
-			return "", errdefs.InvalidParameter(errors.New("multiple IDs found with provided prefix: " + s))
-		}
-		id = item.(*Container).ID
-	}
+	return db.Snapshot().GetByPrefix(s)
+}
 
-	if id != "" {
-		return id, nil
-	}
+// errAmbiguousPrefix is returned by [ViewDB.GetByPrefix] when more than one
+// container matches the given prefix, so that callers can tell "ambiguous"
+// apart from "not found" and, e.g., surface a 409 instead of a 404.
+type errAmbiguousPrefix struct {
+	prefix string
+	ids    []string
+}
 
-	return "", errdefs.NotFound(errors.New("No such container: " + s))
+func (e errAmbiguousPrefix) Error() string {
+	return fmt.Sprintf("multiple IDs found with provided prefix: %s: %s", e.prefix, strings.Join(e.ids, ", "))
 }
 
+func (e errAmbiguousPrefix) Conflict() {}
+
 // Snapshot provides a consistent read-only view of the database.
 func (db *ViewDB) Snapshot() *View {
 	return &View{
@@ -255,6 +250,38 @@ func (v *View) getNames(containerID string) []string {
 	return names
 }
 
+// GetByPrefix returns a container with the given ID prefix. It returns an
+// error if an empty prefix was given. It returns an [errAmbiguousPrefix] if
+// more than one container matches the prefix, and an [errdefs.NotFound] if
+// the given s yielded no results.
+func (v *View) GetByPrefix(s string) (string, error) {
+	if s == "" {
+		return "", errdefs.InvalidParameter(errors.New("prefix can't be empty"))
+	}
+	iter, err := v.txn.Get(memdbContainersTable, memdbIDIndexPrefix, s)
+	if err != nil {
+		return "", errdefs.System(err)
+	}
+
+	var ids []string
+	for {
+		item := iter.Next()
+		if item == nil {
+			break
+		}
+		ids = append(ids, item.(*Container).ID)
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", errdefs.NotFound(errors.New("No such container: " + s))
+	case 1:
+		return ids[0], nil
+	default:
+		return "", errAmbiguousPrefix{prefix: s, ids: ids}
+	}
+}
+
 // GetID returns the container ID that the passed in name is reserved to.
 // It returns an [errdefs.NotFound] if the given id was not found.
 func (v *View) GetID(name string) (string, error) {
@@ -2,9 +2,11 @@
 
 import (
 	"context"
+	"errors"
 	"math/rand"
 	"os"
 	"path/filepath"
+	"slices"
 	"testing"
 
 	cerrdefs "github.com/containerd/errdefs"
@@ -335,6 +337,29 @@ type testacase struct {
 	}
 }
 
+// TestGetByPrefixAmbiguous verifies that GetByPrefix distinguishes an
+// ambiguous prefix (matching more than one container) from a prefix that
+// matches nothing, returning a [errAmbiguousPrefix] that satisfies
+// [cerrdefs.IsConflict] and lists the matching container IDs.
+func TestGetByPrefixAmbiguous(t *testing.T) {
+	db, err := NewViewDB()
+	assert.NilError(t, err)
+
+	const id1 = "aaaaaaaa1111111111111111111111111111111111111111111111111111"
+	const id2 = "aaaaaaaa2222222222222222222222222222222222222222222222222222"
+	assert.NilError(t, db.Save(&Container{ID: id1}))
+	assert.NilError(t, db.Save(&Container{ID: id2}))
+
+	_, err = db.GetByPrefix("aaaaaaaa")
+	assert.Check(t, is.ErrorType(err, cerrdefs.IsConflict))
+
+	var ambiguous errAmbiguousPrefix
+	assert.Check(t, errors.As(err, &ambiguous))
+	sortedIDs := slices.Clone(ambiguous.ids)
+	slices.Sort(sortedIDs)
+	assert.Check(t, is.DeepEqual(sortedIDs, []string{id1, id2}))
+}
+
 func assertIndexGet(t *testing.T, snapshot *ViewDB, input, expectedResult string, expectError bool) {
 	if result, err := snapshot.GetByPrefix(input); err != nil && !expectError {
 		t.Fatalf("Unexpected error getting '%s': %s", input, err)
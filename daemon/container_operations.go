@@ -9,6 +9,7 @@
 	"os"
 	"runtime"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -37,6 +38,43 @@
 
 const errSetupNetworking = "failed to set up container networking"
 
+// appendExtraHostSandboxOption appends a [libnetwork.OptionExtraHost] for a
+// validated "name:ip" entry, expanding the literal "host-gateway" IP into
+// the daemon's configured gateway address(es). extraHost is expected to
+// already have passed [opts.ValidateExtraHost] or [opts.ParseExtraHostsFile].
+func appendExtraHostSandboxOption(sboxOptions []libnetwork.SandboxOption, cfg *config.Config, containerID, extraHost string) ([]libnetwork.SandboxOption, error) {
+	// allow IPv6 addresses in extra hosts; only split on first ":"
+	host, ip, _ := strings.Cut(extraHost, ":")
+	// If the IP Address is the literal string "host-gateway", replace this
+	// value with the IP address(es) stored in the daemon level HostGatewayIP
+	// config variable
+	if ip == opts.HostGatewayName {
+		if len(cfg.HostGatewayIPs) == 0 {
+			return nil, errors.New("unable to derive the IP value for host-gateway")
+		}
+		for _, gip := range cfg.HostGatewayIPs {
+			sboxOptions = append(sboxOptions, libnetwork.OptionExtraHost(host, gip.Unmap()))
+		}
+		return sboxOptions, nil
+	}
+	if ipAddr, err := netip.ParseAddr(ip); err != nil {
+		// Value should already be validated if we arrive here, but
+		// handle invalid IP-addresses gracefully: they may be part
+		// of an existing container-config created by docker < v29.0.0.
+		//
+		// See https://github.com/moby/moby/issues/52274
+		// See https://github.com/moby/moby/pull/50956
+		log.G(context.TODO()).WithFields(log.Fields{
+			"error":      err,
+			"extra_host": extraHost,
+			"container":  containerID,
+		}).Warn("buildSandboxOptions: failed to parse IP address for extra hosts")
+	} else {
+		sboxOptions = append(sboxOptions, libnetwork.OptionExtraHost(host, ipAddr.Unmap()))
+	}
+	return sboxOptions, nil
+}
+
 func buildSandboxOptions(cfg *config.Config, ctr *container.Container) ([]libnetwork.SandboxOption, error) {
 	var sboxOptions []libnetwork.SandboxOption
 	sboxOptions = append(sboxOptions, libnetwork.OptionHostname(ctr.Config.Hostname), libnetwork.OptionDomainname(ctr.Config.Domainname))
@@ -72,38 +110,31 @@ func buildSandboxOptions(cfg *config.Config, ctr *container.Container) ([]libnet
 	} else if len(cfg.DNSOptions) > 0 {
 		sboxOptions = append(sboxOptions, libnetwork.OptionDNSOptions(cfg.DNSOptions))
 	}
+	if ctr.HostConfig.DNSFailoverStrategy != "" {
+		sboxOptions = append(sboxOptions, libnetwork.OptionDNSFailoverStrategy(string(ctr.HostConfig.DNSFailoverStrategy)))
+	}
+	if ctr.HostConfig.ResolvConfTemplate != "" {
+		sboxOptions = append(sboxOptions, libnetwork.OptionResolvConfTemplatePath(ctr.HostConfig.ResolvConfTemplate))
+	}
 
 	for _, extraHost := range ctr.HostConfig.ExtraHosts {
 		if _, err := opts.ValidateExtraHost(extraHost); err != nil {
 			return nil, err
 		}
-		// allow IPv6 addresses in extra hosts; only split on first ":"
-		host, ip, _ := strings.Cut(extraHost, ":")
-		// If the IP Address is the literal string "host-gateway", replace this
-		// value with the IP address(es) stored in the daemon level HostGatewayIP
-		// config variable
-		if ip == opts.HostGatewayName {
-			if len(cfg.HostGatewayIPs) == 0 {
-				return nil, errors.New("unable to derive the IP value for host-gateway")
-			}
-			for _, gip := range cfg.HostGatewayIPs {
-				sboxOptions = append(sboxOptions, libnetwork.OptionExtraHost(host, gip.Unmap()))
-			}
-		} else {
-			if ipAddr, err := netip.ParseAddr(ip); err != nil {
-				// Value should already be validated if we arrive here, but
-				// handle invalid IP-addresses gracefully: they may be part
-				// of an existing container-config created by docker < v29.0.0.
-				//
-				// See https://github.com/moby/moby/issues/52274
-				// See https://github.com/moby/moby/pull/50956
-				log.G(context.TODO()).WithFields(log.Fields{
-					"error":      err,
-					"extra_host": extraHost,
-					"container":  ctr.ID,
-				}).Warn("buildSandboxOptions: failed to parse IP address for extra hosts")
-			} else {
-				sboxOptions = append(sboxOptions, libnetwork.OptionExtraHost(host, ipAddr.Unmap()))
+		var err error
+		if sboxOptions, err = appendExtraHostSandboxOption(sboxOptions, cfg, ctr.ID, extraHost); err != nil {
+			return nil, err
+		}
+	}
+
+	if ctr.HostConfig.ExtraHostsFile != "" {
+		fileHosts, err := opts.ParseExtraHostsFile(ctr.HostConfig.ExtraHostsFile)
+		if err != nil {
+			return nil, err
+		}
+		for _, extraHost := range fileHosts {
+			if sboxOptions, err = appendExtraHostSandboxOption(sboxOptions, cfg, ctr.ID, extraHost); err != nil {
+				return nil, err
 			}
 		}
 	}
@@ -266,7 +297,20 @@ func (daemon *Daemon) updateNetwork(cfg *config.Config, ctr *container.Container
 	return nil
 }
 
-func (daemon *Daemon) findAndAttachNetwork(ctr *container.Container, idOrName string, epConfig *networktypes.EndpointSettings) (*libnetwork.Network, *networktypes.NetworkingConfig, error) {
+// errContainerNetworkNotFound is returned when a container's configured
+// network can't be found at start, for example because it was removed while
+// the container was stopped.
+type errContainerNetworkNotFound struct {
+	network string
+}
+
+func (e errContainerNetworkNotFound) Error() string {
+	return fmt.Sprintf("network %s, configured for this container, could not be found; it may have been removed while the container was stopped", e.network)
+}
+
+func (errContainerNetworkNotFound) NotFound() {}
+
+func (daemon *Daemon) findAndAttachNetwork(cfg *config.Config, ctr *container.Container, idOrName string, epConfig *networktypes.EndpointSettings) (*libnetwork.Network, *networktypes.NetworkingConfig, error) {
 	id := getNetworkID(idOrName, epConfig)
 
 	n, err := daemon.FindNetwork(id)
@@ -275,6 +319,22 @@ func (daemon *Daemon) findAndAttachNetwork(ctr *container.Container, idOrName st
 		if ctr.Managed {
 			return nil, nil, err
 		}
+		if containertypes.NetworkMode(idOrName).IsUserDefined() {
+			if !cfg.AutoCreateMissingNetwork {
+				return nil, nil, errContainerNetworkNotFound{network: idOrName}
+			}
+			log.G(context.TODO()).WithFields(log.Fields{
+				"container": ctr.ID,
+				"network":   idOrName,
+			}).Warn("container's network is missing, auto-creating it with default settings")
+			if _, createErr := daemon.CreateNetwork(context.TODO(), networktypes.CreateRequest{Name: idOrName}); createErr != nil {
+				return nil, nil, fmt.Errorf("failed to auto-create missing network %s: %w", idOrName, createErr)
+			}
+			n, err = daemon.FindNetwork(idOrName)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
 	}
 
 	// If we found a network and if it is not dynamically created
@@ -577,6 +637,12 @@ func validateEndpointSettings(nw *libnetwork.Network, nwName string, epConfig *n
 		}
 	}
 
+	if mtu, ok := epConfig.DriverOpts[netlabel.EndpointMTU]; ok {
+		if err := validateEndpointMTU(nw, mtu); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	if err := multierror.Join(errs...); err != nil {
 		return fmt.Errorf("invalid endpoint settings:\n%w", err)
 	}
@@ -584,6 +650,27 @@ func validateEndpointSettings(nw *libnetwork.Network, nwName string, epConfig *n
 	return nil
 }
 
+// validateEndpointMTU checks that mtu, the value of the
+// [netlabel.EndpointMTU] DriverOpt, is a positive integer that doesn't
+// exceed nw's own MTU (when nw is known, and has an MTU configured).
+func validateEndpointMTU(nw *libnetwork.Network, mtu string) error {
+	parsed, err := strconv.Atoi(mtu)
+	if err != nil || parsed <= 0 {
+		return fmt.Errorf("invalid endpoint MTU %q: must be a positive integer", mtu)
+	}
+	if nw == nil {
+		return nil
+	}
+	nwMTU, ok := nw.DriverOptions()[netlabel.DriverMTU]
+	if !ok {
+		return nil
+	}
+	if parsedNwMTU, err := strconv.Atoi(nwMTU); err == nil && parsed > parsedNwMTU {
+		return fmt.Errorf("endpoint MTU %d cannot exceed network %q's MTU of %d", parsed, nw.Name(), parsedNwMTU)
+	}
+	return nil
+}
+
 // normalizeEndpointIPAMConfig checks whether cfg is valid and normalizes cfg in-place.
 func normalizeEndpointIPAMConfig(errs []error, cfg *networktypes.EndpointIPAMConfig) []error {
 	if cfg == nil {
@@ -716,7 +803,7 @@ func (daemon *Daemon) connectToNetwork(ctx context.Context, cfg *config.Config,
 		}
 	}
 
-	n, nwCfg, err := daemon.findAndAttachNetwork(ctr, idOrName, endpointConfig.EndpointSettings)
+	n, nwCfg, err := daemon.findAndAttachNetwork(cfg, ctr, idOrName, endpointConfig.EndpointSettings)
 	if err != nil {
 		return err
 	}
@@ -1,21 +1,58 @@
 package daemon
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"net"
 	"net/netip"
+	"os"
 	"testing"
 
 	containertypes "github.com/moby/moby/api/types/container"
 	networktypes "github.com/moby/moby/api/types/network"
+	"github.com/moby/moby/v2/daemon/config"
 	"github.com/moby/moby/v2/daemon/container"
 	"github.com/moby/moby/v2/daemon/libnetwork"
 	"github.com/moby/moby/v2/daemon/libnetwork/driverapi"
+	"github.com/moby/moby/v2/daemon/libnetwork/netlabel"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
 )
 
+func TestFindAndAttachNetworkMissing(t *testing.T) {
+	d := &Daemon{}
+	ctr := &container.Container{
+		ID:         "ctr",
+		Name:       "/testctr",
+		HostConfig: &containertypes.HostConfig{},
+	}
+
+	t.Run("without auto-create returns a clear error", func(t *testing.T) {
+		_, _, err := d.findAndAttachNetwork(&config.Config{}, ctr, "mynet", nil)
+		assert.Check(t, is.ErrorContains(err, "network mynet"))
+		assert.Check(t, is.ErrorContains(err, "could not be found"))
+	})
+
+	t.Run("with auto-create attempts to recreate the network", func(t *testing.T) {
+		if os.Getuid() != 0 {
+			t.Skip("root required")
+		}
+		netOptions, err := d.networkOptions(&config.Config{CommonConfig: config.CommonConfig{Root: t.TempDir()}}, nil, "", nil)
+		assert.NilError(t, err)
+		controller, err := libnetwork.New(context.Background(), netOptions...)
+		assert.NilError(t, err)
+		defer controller.Stop()
+		d.netController = controller
+
+		cfg := &config.Config{CommonConfig: config.CommonConfig{AutoCreateMissingNetwork: true}}
+		n, _, err := d.findAndAttachNetwork(cfg, ctr, "mynet", nil)
+		assert.NilError(t, err)
+		assert.Check(t, n != nil)
+		assert.Check(t, is.Equal(n.Name(), "mynet"))
+	})
+}
+
 func TestDNSNamesOrder(t *testing.T) {
 	d := &Daemon{}
 	ctr := &container.Container{
@@ -199,3 +236,52 @@ func TestEndpointIPAMConfigWithInvalidConfig(t *testing.T) {
 		})
 	}
 }
+
+// TestValidateEndpointSettingsMTU covers the com.docker.network.endpoint.mtu
+// DriverOpt: it must be a positive integer, and can't exceed the network's
+// own MTU when the network's MTU is known.
+func TestValidateEndpointSettingsMTU(t *testing.T) {
+	nw := buildNetwork(t, map[string]any{
+		"id":          "1234567890",
+		"name":        "testnet",
+		"networkType": "bridge",
+		"enableIPv6":  false,
+		"generic": map[string]any{
+			netlabel.GenericData: map[string]string{netlabel.DriverMTU: "1400"},
+		},
+	})
+
+	tests := []struct {
+		name        string
+		mtu         string
+		expectedErr string
+	}{
+		{name: "under network MTU", mtu: "1200"},
+		{name: "equal to network MTU", mtu: "1400"},
+		{name: "over network MTU", mtu: "1500", expectedErr: "cannot exceed network"},
+		{name: "not a number", mtu: "abc", expectedErr: "must be a positive integer"},
+		{name: "zero", mtu: "0", expectedErr: "must be a positive integer"},
+		{name: "negative", mtu: "-1", expectedErr: "must be a positive integer"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			epConfig := &networktypes.EndpointSettings{
+				DriverOpts: map[string]string{netlabel.EndpointMTU: tc.mtu},
+			}
+			err := validateEndpointSettings(nw, "testnet", epConfig)
+			if tc.expectedErr == "" {
+				assert.NilError(t, err)
+				return
+			}
+			assert.Check(t, is.ErrorContains(err, tc.expectedErr))
+		})
+	}
+
+	t.Run("no network to validate against", func(t *testing.T) {
+		epConfig := &networktypes.EndpointSettings{
+			DriverOpts: map[string]string{netlabel.EndpointMTU: "9000"},
+		}
+		assert.NilError(t, validateEndpointSettings(nil, "testnet", epConfig))
+	})
+}
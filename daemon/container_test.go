@@ -0,0 +1,442 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/daemon/config"
+	"github.com/moby/moby/v2/daemon/container"
+	"github.com/moby/moby/v2/daemon/pkg/oci/caps"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+// TestValidateExtraHostsFile covers the create-time checks for
+// HostConfig.ExtraHostsFile: the path must be inside one of the daemon's
+// allowed directories, and its contents must parse as valid extra-hosts
+// entries.
+func TestValidateExtraHostsFile(t *testing.T) {
+	allowedDir := t.TempDir()
+	otherDir := t.TempDir()
+
+	daemonCfg := &configStore{
+		Config: config.Config{
+			CommonConfig: config.CommonConfig{
+				AllowedExtraHostsFileDirs: []string{allowedDir},
+			},
+		},
+	}
+
+	t.Run("valid file in an allowed directory", func(t *testing.T) {
+		path := filepath.Join(allowedDir, "hosts")
+		assert.NilError(t, os.WriteFile(path, []byte("myhost:192.168.0.1\n"), 0o644))
+
+		assert.NilError(t, validateExtraHostsFile(daemonCfg, path))
+	})
+
+	t.Run("path outside of any allowed directory is rejected", func(t *testing.T) {
+		path := filepath.Join(otherDir, "hosts")
+		assert.NilError(t, os.WriteFile(path, []byte("myhost:192.168.0.1\n"), 0o644))
+
+		err := validateExtraHostsFile(daemonCfg, path)
+		assert.Check(t, is.ErrorContains(err, "not in an allowed directory"))
+	})
+
+	t.Run("relative path is rejected", func(t *testing.T) {
+		err := validateExtraHostsFile(daemonCfg, "relative/hosts")
+		assert.Check(t, is.ErrorContains(err, "absolute path"))
+	})
+
+	t.Run("invalid entries are rejected", func(t *testing.T) {
+		path := filepath.Join(allowedDir, "bad-hosts")
+		assert.NilError(t, os.WriteFile(path, []byte("not-a-valid-entry\n"), 0o644))
+
+		err := validateExtraHostsFile(daemonCfg, path)
+		assert.Check(t, is.ErrorContains(err, "line 1"))
+	})
+}
+
+// TestValidateResolvConfTemplate covers the create-time checks for
+// HostConfig.ResolvConfTemplate: the path must be inside one of the
+// daemon's allowed directories, and its contents must parse as a valid
+// resolv.conf.
+func TestValidateResolvConfTemplate(t *testing.T) {
+	allowedDir := t.TempDir()
+	otherDir := t.TempDir()
+
+	daemonCfg := &configStore{
+		Config: config.Config{
+			CommonConfig: config.CommonConfig{
+				AllowedResolvConfTemplateDirs: []string{allowedDir},
+			},
+		},
+	}
+
+	t.Run("valid file in an allowed directory", func(t *testing.T) {
+		path := filepath.Join(allowedDir, "resolv.conf")
+		assert.NilError(t, os.WriteFile(path, []byte("nameserver 203.0.113.1\n"), 0o644))
+
+		assert.NilError(t, validateResolvConfTemplate(daemonCfg, path))
+	})
+
+	t.Run("path outside of any allowed directory is rejected", func(t *testing.T) {
+		path := filepath.Join(otherDir, "resolv.conf")
+		assert.NilError(t, os.WriteFile(path, []byte("nameserver 203.0.113.1\n"), 0o644))
+
+		err := validateResolvConfTemplate(daemonCfg, path)
+		assert.Check(t, is.ErrorContains(err, "not in an allowed directory"))
+	})
+
+	t.Run("relative path is rejected", func(t *testing.T) {
+		err := validateResolvConfTemplate(daemonCfg, "relative/resolv.conf")
+		assert.Check(t, is.ErrorContains(err, "absolute path"))
+	})
+}
+
+// TestValidateContainerConfigStdinOnce covers the create-time check that
+// rejects StdinOnce set on a container that doesn't also have OpenStdin
+// enabled, since there would be no attached stdin for it to close.
+func TestValidateContainerConfigStdinOnce(t *testing.T) {
+	t.Run("StdinOnce without OpenStdin is rejected", func(t *testing.T) {
+		_, err := validateContainerConfig(&containertypes.Config{StdinOnce: true})
+		assert.Check(t, is.ErrorContains(err, "StdinOnce can only be set when OpenStdin is also enabled"))
+	})
+
+	t.Run("StdinOnce with OpenStdin is allowed", func(t *testing.T) {
+		_, err := validateContainerConfig(&containertypes.Config{StdinOnce: true, OpenStdin: true})
+		assert.NilError(t, err)
+	})
+
+	t.Run("neither set is allowed", func(t *testing.T) {
+		_, err := validateContainerConfig(&containertypes.Config{})
+		assert.NilError(t, err)
+	})
+}
+
+// TestValidateContainerConfigStopTimeout covers the create-time checks that
+// StopTimeout must be non-negative, and that a StopSignal of SIGKILL combined
+// with a non-zero StopTimeout produces a warning since the timeout has no
+// effect in that case.
+func TestValidateContainerConfigStopTimeout(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+
+	tests := []struct {
+		name        string
+		config      *containertypes.Config
+		expectedErr string
+		warning     string
+	}{
+		{
+			name:   "nil StopTimeout is allowed",
+			config: &containertypes.Config{},
+		},
+		{
+			name:   "zero StopTimeout is allowed",
+			config: &containertypes.Config{StopTimeout: intPtr(0)},
+		},
+		{
+			name:   "positive StopTimeout is allowed",
+			config: &containertypes.Config{StopTimeout: intPtr(10)},
+		},
+		{
+			name:        "negative StopTimeout is rejected",
+			config:      &containertypes.Config{StopTimeout: intPtr(-1)},
+			expectedErr: "invalid StopTimeout",
+		},
+		{
+			name:    "SIGKILL with non-zero StopTimeout warns",
+			config:  &containertypes.Config{StopSignal: "SIGKILL", StopTimeout: intPtr(10)},
+			warning: "ignores StopTimeout",
+		},
+		{
+			name:   "SIGKILL with zero StopTimeout doesn't warn",
+			config: &containertypes.Config{StopSignal: "SIGKILL", StopTimeout: intPtr(0)},
+		},
+		{
+			name:   "SIGTERM with non-zero StopTimeout doesn't warn",
+			config: &containertypes.Config{StopSignal: "SIGTERM", StopTimeout: intPtr(10)},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			warnings, err := validateContainerConfig(tc.config)
+			if tc.expectedErr != "" {
+				assert.Check(t, is.ErrorContains(err, tc.expectedErr))
+				return
+			}
+			assert.NilError(t, err)
+			if tc.warning != "" {
+				assert.Assert(t, is.Len(warnings, 1))
+				assert.Check(t, is.Contains(warnings[0], tc.warning))
+			} else {
+				assert.Check(t, is.Len(warnings, 0))
+			}
+		})
+	}
+}
+
+// TestValidateContainerConfigHealthCheckRetries covers the create-time
+// warning produced when a non-NONE healthcheck is configured with
+// Retries: 0, since that value does not disable retries but falls back to
+// the image's healthcheck Retries or the default of 3.
+func TestValidateContainerConfigHealthCheckRetries(t *testing.T) {
+	tests := []struct {
+		name    string
+		health  *containertypes.HealthConfig
+		warning string
+	}{
+		{
+			name: "no healthcheck",
+		},
+		{
+			name:   "NONE healthcheck with zero retries doesn't warn",
+			health: &containertypes.HealthConfig{Test: []string{"NONE"}, Retries: 0},
+		},
+		{
+			name:   "CMD healthcheck with positive retries doesn't warn",
+			health: &containertypes.HealthConfig{Test: []string{"CMD-SHELL", "true"}, Retries: 3},
+		},
+		{
+			name:    "CMD healthcheck with zero retries warns",
+			health:  &containertypes.HealthConfig{Test: []string{"CMD-SHELL", "true"}, Retries: 0},
+			warning: "Retries: 0",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			warnings, err := validateContainerConfig(&containertypes.Config{Healthcheck: tc.health})
+			assert.NilError(t, err)
+			if tc.warning != "" {
+				assert.Assert(t, is.Len(warnings, 1))
+				assert.Check(t, is.Contains(warnings[0], tc.warning))
+			} else {
+				assert.Check(t, is.Len(warnings, 0))
+			}
+		})
+	}
+}
+
+// TestValidateContainerConfigHealthCheckStartInterval covers the
+// create-time warning produced when a healthcheck's StartInterval is
+// greater than its Interval, since start-period probing would then be
+// slower than steady-state probing.
+func TestValidateContainerConfigHealthCheckStartInterval(t *testing.T) {
+	tests := []struct {
+		name    string
+		health  *containertypes.HealthConfig
+		warning string
+	}{
+		{
+			name:   "StartInterval less than Interval doesn't warn",
+			health: &containertypes.HealthConfig{Interval: 30 * time.Second, StartInterval: 5 * time.Second},
+		},
+		{
+			name:   "StartInterval equal to Interval doesn't warn",
+			health: &containertypes.HealthConfig{Interval: 30 * time.Second, StartInterval: 30 * time.Second},
+		},
+		{
+			name:   "zero Interval doesn't warn",
+			health: &containertypes.HealthConfig{StartInterval: 30 * time.Second},
+		},
+		{
+			name:   "zero StartInterval doesn't warn",
+			health: &containertypes.HealthConfig{Interval: 30 * time.Second},
+		},
+		{
+			name:    "StartInterval greater than Interval warns",
+			health:  &containertypes.HealthConfig{Interval: 5 * time.Second, StartInterval: 30 * time.Second},
+			warning: "StartInterval (30s) is greater than Interval (5s)",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			warnings, err := validateContainerConfig(&containertypes.Config{Healthcheck: tc.health})
+			assert.NilError(t, err)
+			if tc.warning != "" {
+				assert.Assert(t, is.Len(warnings, 1))
+				assert.Check(t, is.Contains(warnings[0], tc.warning))
+			} else {
+				assert.Check(t, is.Len(warnings, 0))
+			}
+		})
+	}
+}
+
+// TestValidateContainerConfigHealthCheckTestBounds covers the create-time
+// checks that HealthConfig.Test doesn't exceed maxHealthCheckTestLen
+// entries, and that a CMD/CMD-SHELL test doesn't contain an empty-string
+// command element.
+func TestValidateContainerConfigHealthCheckTestBounds(t *testing.T) {
+	t.Run("Test at the maximum length is allowed", func(t *testing.T) {
+		test := append([]string{"CMD"}, make([]string, maxHealthCheckTestLen-1)...)
+		for i := range test[1:] {
+			test[i+1] = "true"
+		}
+		_, err := validateContainerConfig(&containertypes.Config{
+			Healthcheck: &containertypes.HealthConfig{Test: test, Retries: 1},
+		})
+		assert.NilError(t, err)
+	})
+
+	t.Run("Test beyond the maximum length is rejected", func(t *testing.T) {
+		test := append([]string{"CMD"}, make([]string, maxHealthCheckTestLen)...)
+		for i := range test[1:] {
+			test[i+1] = "true"
+		}
+		_, err := validateContainerConfig(&containertypes.Config{
+			Healthcheck: &containertypes.HealthConfig{Test: test},
+		})
+		assert.Check(t, is.ErrorContains(err, "cannot have more than"))
+	})
+
+	t.Run("empty-string element in a CMD test is rejected", func(t *testing.T) {
+		_, err := validateContainerConfig(&containertypes.Config{
+			Healthcheck: &containertypes.HealthConfig{Test: []string{"CMD", "true", ""}},
+		})
+		assert.Check(t, is.ErrorContains(err, "cannot contain an empty string"))
+	})
+
+	t.Run("empty-string element in a CMD-SHELL test is rejected", func(t *testing.T) {
+		_, err := validateContainerConfig(&containertypes.Config{
+			Healthcheck: &containertypes.HealthConfig{Test: []string{"CMD-SHELL", ""}},
+		})
+		assert.Check(t, is.ErrorContains(err, "cannot contain an empty string"))
+	})
+
+	t.Run("empty-string element in a NONE test is allowed", func(t *testing.T) {
+		_, err := validateContainerConfig(&containertypes.Config{
+			Healthcheck: &containertypes.HealthConfig{Test: []string{"NONE", ""}},
+		})
+		assert.NilError(t, err)
+	})
+}
+
+// TestEffectiveCapabilities covers the resolution of a container's
+// effective Linux capability set across privileged, cap-add, and cap-drop
+// combinations, matching the resolution used when assembling the
+// container's OCI spec.
+func TestEffectiveCapabilities(t *testing.T) {
+	t.Run("privileged gets every capability regardless of CapAdd/CapDrop", func(t *testing.T) {
+		capabilities, err := EffectiveCapabilities(&containertypes.HostConfig{
+			Privileged: true,
+			CapDrop:    []string{"CAP_CHOWN"},
+		})
+		assert.NilError(t, err)
+		assert.Check(t, is.Contains(capabilities, "CAP_CHOWN"))
+		assert.Check(t, is.Contains(capabilities, "CAP_SYS_ADMIN"))
+	})
+
+	t.Run("no CapAdd/CapDrop returns the default set", func(t *testing.T) {
+		capabilities, err := EffectiveCapabilities(&containertypes.HostConfig{})
+		assert.NilError(t, err)
+		assert.Check(t, is.Contains(capabilities, "CAP_CHOWN"))
+		assert.Check(t, is.Len(capabilities, len(caps.DefaultCapabilities())))
+	})
+
+	t.Run("CapDrop removes from the default set", func(t *testing.T) {
+		capabilities, err := EffectiveCapabilities(&containertypes.HostConfig{
+			CapDrop: []string{"CAP_CHOWN"},
+		})
+		assert.NilError(t, err)
+		assert.Check(t, !slices.Contains(capabilities, "CAP_CHOWN"))
+	})
+
+	t.Run("CapAdd adds beyond the default set", func(t *testing.T) {
+		capabilities, err := EffectiveCapabilities(&containertypes.HostConfig{
+			CapAdd: []string{"CAP_SYS_ADMIN"},
+		})
+		assert.NilError(t, err)
+		assert.Check(t, is.Contains(capabilities, "CAP_SYS_ADMIN"))
+		assert.Check(t, is.Contains(capabilities, "CAP_CHOWN"))
+	})
+
+	t.Run("CapDrop=ALL with CapAdd returns only the added capabilities", func(t *testing.T) {
+		capabilities, err := EffectiveCapabilities(&containertypes.HostConfig{
+			CapDrop: []string{"ALL"},
+			CapAdd:  []string{"CAP_NET_RAW"},
+		})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, capabilities, []string{"CAP_NET_RAW"})
+	})
+}
+
+// TestGetDependentContainersVolumesFrom covers the --volumes-from case of
+// GetDependentContainers, using a chain A<-B(volumes-from A)<-C(volumes-from
+// B) to confirm each container's dependent list is only its direct
+// volumes-from source, not the whole chain.
+func TestGetDependentContainersVolumesFrom(t *testing.T) {
+	a := &container.Container{
+		ID:         "aaaaaaaaaaaa",
+		Name:       "a",
+		HostConfig: &containertypes.HostConfig{},
+	}
+	b := &container.Container{
+		ID:         "bbbbbbbbbbbb",
+		Name:       "b",
+		HostConfig: &containertypes.HostConfig{VolumesFrom: []string{a.ID}},
+	}
+	c := &container.Container{
+		ID:         "cccccccccccc",
+		Name:       "c",
+		HostConfig: &containertypes.HostConfig{VolumesFrom: []string{b.ID}},
+	}
+
+	store := container.NewMemoryStore()
+	store.Add(a.ID, a)
+	store.Add(b.ID, b)
+	store.Add(c.ID, c)
+
+	daemon := &Daemon{
+		containers: store,
+		linkIndex:  newLinkIndex(),
+	}
+
+	assert.Check(t, is.Len(daemon.GetDependentContainers(a), 0))
+
+	depsOfB := daemon.GetDependentContainers(b)
+	assert.Assert(t, is.Len(depsOfB, 1))
+	assert.Check(t, depsOfB[0] == a)
+
+	depsOfC := daemon.GetDependentContainers(c)
+	assert.Assert(t, is.Len(depsOfC, 1))
+	assert.Check(t, depsOfC[0] == b)
+}
+
+// TestCheckDependencyCycleNetworkMode covers the case of two containers
+// referencing each other's network namespace via "--network=container:",
+// which can happen after a crash/restore leaves corrupted HostConfig state.
+// checkDependencyCycle must detect this instead of letting the startup
+// ordering logic in the restore loop wait on it indefinitely.
+func TestCheckDependencyCycleNetworkMode(t *testing.T) {
+	a := &container.Container{
+		ID:   "aaaaaaaaaaaa",
+		Name: "a",
+	}
+	b := &container.Container{
+		ID:   "bbbbbbbbbbbb",
+		Name: "b",
+	}
+	a.HostConfig = &containertypes.HostConfig{NetworkMode: containertypes.NetworkMode("container:" + b.ID)}
+	b.HostConfig = &containertypes.HostConfig{NetworkMode: containertypes.NetworkMode("container:" + a.ID)}
+
+	store := container.NewMemoryStore()
+	store.Add(a.ID, a)
+	store.Add(b.ID, b)
+
+	daemon := &Daemon{
+		containers: store,
+		linkIndex:  newLinkIndex(),
+	}
+
+	err := daemon.checkDependencyCycle(a)
+	assert.Assert(t, is.ErrorContains(err, "dependency cycle found"))
+	assert.Check(t, is.Contains(err.Error(), a.ID))
+	assert.Check(t, is.Contains(err.Error(), b.ID))
+}
@@ -6,6 +6,7 @@
 	"fmt"
 	"os"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -125,13 +126,22 @@ func (daemon *Daemon) containerCreate(ctx context.Context, daemonCfg *configStor
 		return containertypes.CreateResponse{Warnings: warnings}, errdefs.InvalidParameter(err)
 	}
 
+	logWarnings, err := daemon.mergeAndVerifyLogConfig(&opts.params.HostConfig.LogConfig)
+	if err != nil {
+		return containertypes.CreateResponse{Warnings: warnings}, errdefs.InvalidParameter(err)
+	}
+	warnings = append(warnings, logWarnings...)
+
 	if runtime.GOOS == "linux" && (opts.params.HostConfig.NetworkMode.IsDefault() || opts.params.HostConfig.NetworkMode.IsBridge()) && len(opts.params.HostConfig.Links) > 0 {
 		warnings = append(warnings, "Links on the default bridge network are deprecated and will be removed in a future release. Use a custom network instead.")
 	}
 
-	ctr, err := daemon.create(ctx, &daemonCfg.Config, opts)
+	createCtx, cancel := withCreateTimeout(ctx, time.Duration(daemonCfg.ContainerCreateTimeout)*time.Second)
+	defer cancel()
+
+	ctr, err := daemon.create(createCtx, &daemonCfg.Config, opts)
 	if err != nil {
-		return containertypes.CreateResponse{Warnings: warnings}, err
+		return containertypes.CreateResponse{Warnings: warnings}, wrapCreateTimeoutError(createCtx, err)
 	}
 	metrics.ContainerActions.WithValues("create").UpdateSince(start)
 
@@ -142,6 +152,26 @@ func (daemon *Daemon) containerCreate(ctx context.Context, daemonCfg *configStor
 	return containertypes.CreateResponse{ID: ctr.ID, Warnings: warnings}, nil
 }
 
+// withCreateTimeout returns a context bounded by timeout (the configured
+// container-create-timeout), and its cancel function, which the caller must
+// always call. A non-positive timeout (the default) leaves the returned
+// context unbounded.
+func withCreateTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// wrapCreateTimeoutError returns a clear deadline-exceeded error if ctx's
+// deadline is what caused err, otherwise it returns err unchanged.
+func wrapCreateTimeoutError(ctx context.Context, err error) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return errdefs.Deadline(fmt.Errorf("container create timed out: %w", err))
+	}
+	return err
+}
+
 var (
 	containerLabelsFilter     []string
 	containerLabelsFilterOnce sync.Once
@@ -200,13 +230,10 @@ func (daemon *Daemon) create(ctx context.Context, daemonCfg *config.Config, opts
 		opts.params.Config.ArgsEscaped = true
 	}
 
-	if err := daemon.mergeAndVerifyConfig(opts.params.Config, img); err != nil {
-		return nil, errdefs.InvalidParameter(err)
-	}
-
-	if err := daemon.mergeAndVerifyLogConfig(&opts.params.HostConfig.LogConfig); err != nil {
+	if err := daemon.mergeAndVerifyConfig(opts.params.Config, img, opts.params.HostConfig); err != nil {
 		return nil, errdefs.InvalidParameter(err)
 	}
+	applyImageStopTimeoutLabel(ctx, opts.params.Config, img)
 
 	if ctr, err = daemon.newContainer(opts.params.Name, platform, opts.params.Config, opts.params.HostConfig, imgID, opts.managed); err != nil {
 		return nil, err
@@ -282,7 +309,14 @@ func (daemon *Daemon) create(ctx context.Context, daemonCfg *config.Config, opts
 		return nil, err
 	}
 	metrics.StateCtr.Set(ctr.ID, "stopped")
-	daemon.LogContainerEvent(ctr, events.ActionCreate)
+	if ctr.HostConfig.Privileged {
+		daemon.LogContainerEventWithAttributes(ctr, events.ActionCreate, map[string]string{
+			"image":      ctr.Config.Image,
+			"privileged": "true",
+		})
+	} else {
+		daemon.LogContainerEvent(ctr, events.ActionCreate)
+	}
 	return ctr, nil
 }
 
@@ -351,9 +385,10 @@ func (daemon *Daemon) generateSecurityOpt(hostConfig *containertypes.HostConfig)
 	return nil, nil
 }
 
-func (daemon *Daemon) mergeAndVerifyConfig(config *containertypes.Config, img *image.Image) error {
+func (daemon *Daemon) mergeAndVerifyConfig(config *containertypes.Config, img *image.Image, hostConfig *containertypes.HostConfig) error {
 	if img != nil && img.Config != nil {
-		if err := merge(config, img.Config); err != nil {
+		inheritLabels := hostConfig.InheritImageLabels == nil || *hostConfig.InheritImageLabels
+		if err := merge(config, img.Config, inheritLabels); err != nil {
 			return err
 		}
 	}
@@ -367,6 +402,32 @@ func (daemon *Daemon) mergeAndVerifyConfig(config *containertypes.Config, img *i
 	return nil
 }
 
+// imageStopTimeoutLabel is an image label that provides a default container
+// stop timeout (in seconds), for images whose application knows how long it
+// needs to shut down gracefully.
+const imageStopTimeoutLabel = "com.example.stop-timeout"
+
+// applyImageStopTimeoutLabel sets config.StopTimeout from img's
+// imageStopTimeoutLabel, unless config already carries an explicit
+// user-specified StopTimeout, in which case that value always wins. A
+// malformed label value is warned about and ignored, leaving the container
+// to fall back to the platform default timeout.
+func applyImageStopTimeoutLabel(ctx context.Context, config *containertypes.Config, img *image.Image) {
+	if config.StopTimeout != nil || img == nil || img.Config == nil {
+		return
+	}
+	raw, ok := img.Config.Labels[imageStopTimeoutLabel]
+	if !ok {
+		return
+	}
+	timeout, err := strconv.Atoi(raw)
+	if err != nil {
+		log.G(ctx).WithError(err).Warnf("ignoring malformed %s image label value %q", imageStopTimeoutLabel, raw)
+		return
+	}
+	config.StopTimeout = &timeout
+}
+
 // validateNetworkingConfig checks whether a container's NetworkingConfig is valid.
 func (daemon *Daemon) validateNetworkingConfig(nwConfig *networktypes.NetworkingConfig) error {
 	if nwConfig == nil {
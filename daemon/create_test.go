@@ -0,0 +1,75 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/daemon/internal/image"
+	"gotest.tools/v3/assert"
+)
+
+func TestWithCreateTimeoutUnbounded(t *testing.T) {
+	ctx, cancel := withCreateTimeout(context.Background(), 0)
+	defer cancel()
+
+	_, hasDeadline := ctx.Deadline()
+	assert.Check(t, !hasDeadline)
+}
+
+// TestWithCreateTimeoutHitsSlowVolumePlugin simulates a container create that
+// hangs in a slow volume plugin call, asserting that a configured
+// container-create-timeout aborts it with a clear deadline error rather than
+// hanging indefinitely.
+func TestWithCreateTimeoutHitsSlowVolumePlugin(t *testing.T) {
+	ctx, cancel := withCreateTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	slowVolumePluginCreate := func(ctx context.Context) error {
+		select {
+		case <-time.After(time.Minute):
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	err := wrapCreateTimeoutError(ctx, slowVolumePluginCreate(ctx))
+	assert.ErrorContains(t, err, "container create timed out")
+}
+
+func TestApplyImageStopTimeoutLabel(t *testing.T) {
+	img := &image.Image{V1Image: image.V1Image{Config: &containertypes.Config{
+		Labels: map[string]string{imageStopTimeoutLabel: "30"},
+	}}}
+
+	config := &containertypes.Config{}
+	applyImageStopTimeoutLabel(context.Background(), config, img)
+	if assert.Check(t, config.StopTimeout != nil) {
+		assert.Equal(t, *config.StopTimeout, 30)
+	}
+}
+
+// TestApplyImageStopTimeoutLabelContainerOverride asserts that a
+// user-specified StopTimeout always wins over the image label.
+func TestApplyImageStopTimeoutLabelContainerOverride(t *testing.T) {
+	img := &image.Image{V1Image: image.V1Image{Config: &containertypes.Config{
+		Labels: map[string]string{imageStopTimeoutLabel: "30"},
+	}}}
+
+	userTimeout := 5
+	config := &containertypes.Config{StopTimeout: &userTimeout}
+	applyImageStopTimeoutLabel(context.Background(), config, img)
+	assert.Equal(t, *config.StopTimeout, 5)
+}
+
+func TestApplyImageStopTimeoutLabelMalformed(t *testing.T) {
+	img := &image.Image{V1Image: image.V1Image{Config: &containertypes.Config{
+		Labels: map[string]string{imageStopTimeoutLabel: "not-a-number"},
+	}}}
+
+	config := &containertypes.Config{}
+	applyImageStopTimeoutLabel(context.Background(), config, img)
+	assert.Check(t, config.StopTimeout == nil)
+}
@@ -7,11 +7,13 @@
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	cerrdefs "github.com/containerd/errdefs"
 	"github.com/containerd/log"
 	containertypes "github.com/moby/moby/api/types/container"
 	mounttypes "github.com/moby/moby/api/types/mount"
+	volumetypes "github.com/moby/moby/api/types/volume"
 	"github.com/moby/moby/v2/daemon/container"
 	"github.com/moby/moby/v2/daemon/internal/idtools"
 	"github.com/moby/moby/v2/daemon/pkg/oci"
@@ -64,7 +66,7 @@ func (daemon *Daemon) createContainerVolumesOS(ctx context.Context, ctr *contain
 			return fmt.Errorf("cannot mount volume over existing file, file exists %s", path)
 		}
 
-		v, err := daemon.volumes.Create(context.TODO(), "", ctr.HostConfig.VolumeDriver, volumeopts.WithCreateReference(ctr.ID))
+		v, err := daemon.createAnonymousVolumeWithRetry(ctx, ctr)
 		if err != nil {
 			return err
 		}
@@ -78,6 +80,59 @@ func (daemon *Daemon) createContainerVolumesOS(ctx context.Context, ctr *contain
 	return daemon.populateVolumes(ctx, ctr)
 }
 
+// createAnonymousVolumeWithRetry creates an anonymous volume for ctr, retrying
+// on transient volume-driver errors according to the daemon's configured
+// VolumeCreateRetries/VolumeCreateRetryBackoff. Errors that are not
+// transient (e.g. invalid driver options) are returned immediately without
+// retrying.
+func (daemon *Daemon) createAnonymousVolumeWithRetry(ctx context.Context, ctr *container.Container) (*volumetypes.Volume, error) {
+	maxRetries := daemon.config().VolumeCreateRetries
+	backoff := time.Duration(daemon.config().VolumeCreateRetryBackoff) * time.Millisecond
+
+	return retryVolumeCreate(ctx, maxRetries, backoff, func() (*volumetypes.Volume, error) {
+		return daemon.volumes.Create(ctx, "", ctr.HostConfig.VolumeDriver, volumeopts.WithCreateReference(ctr.ID))
+	}, func(attempt int, err error) {
+		log.G(ctx).WithFields(log.Fields{
+			"container": ctr.ID,
+			"attempt":   attempt,
+			"error":     err,
+		}).Warn("retrying anonymous volume create after transient error")
+	})
+}
+
+// retryVolumeCreate calls create, retrying up to maxRetries times with
+// exponential backoff (base delay backoff, doubling each attempt) when it
+// returns a transient error. onRetry is called before each retry with the
+// 1-based attempt number that just failed. It returns as soon as create
+// succeeds, returns a non-retryable error, or maxRetries is exhausted.
+func retryVolumeCreate(ctx context.Context, maxRetries int, backoff time.Duration, create func() (*volumetypes.Volume, error), onRetry func(attempt int, err error)) (*volumetypes.Volume, error) {
+	var (
+		v   *volumetypes.Volume
+		err error
+	)
+	for attempt := 0; ; attempt++ {
+		v, err = create()
+		if err == nil || attempt >= maxRetries || !isRetryableVolumeCreateError(err) {
+			return v, err
+		}
+		if onRetry != nil {
+			onRetry(attempt+1, err)
+		}
+		select {
+		case <-time.After(backoff * time.Duration(1<<attempt)):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// isRetryableVolumeCreateError reports whether err, returned by a volume
+// driver's Create call, is likely transient and worth retrying, as opposed
+// to a permanent error such as invalid driver options.
+func isRetryableVolumeCreateError(err error) bool {
+	return cerrdefs.IsUnavailable(err) || cerrdefs.IsInternal(err) || cerrdefs.IsAborted(err) || cerrdefs.IsDeadlineExceeded(err)
+}
+
 // populateVolumes copies data from the container's rootfs into the volume for non-binds.
 // this is only called when the container is created.
 func (daemon *Daemon) populateVolumes(ctx context.Context, c *container.Container) error {
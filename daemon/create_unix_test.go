@@ -0,0 +1,66 @@
+//go:build linux || freebsd
+
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	volumetypes "github.com/moby/moby/api/types/volume"
+	"gotest.tools/v3/assert"
+)
+
+func TestRetryVolumeCreateTransientThenSuccess(t *testing.T) {
+	want := &volumetypes.Volume{Name: "anon"}
+	attempts := 0
+	v, err := retryVolumeCreate(context.Background(), 3, time.Millisecond, func() (*volumetypes.Volume, error) {
+		attempts++
+		if attempts < 3 {
+			return nil, cerrdefs.ErrUnavailable
+		}
+		return want, nil
+	}, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, v, want)
+	assert.Equal(t, attempts, 3)
+}
+
+func TestRetryVolumeCreateExhaustsRetries(t *testing.T) {
+	attempts := 0
+	retries := 0
+	_, err := retryVolumeCreate(context.Background(), 2, time.Millisecond, func() (*volumetypes.Volume, error) {
+		attempts++
+		return nil, cerrdefs.ErrUnavailable
+	}, func(attempt int, err error) {
+		retries++
+	})
+	assert.ErrorIs(t, err, cerrdefs.ErrUnavailable)
+	assert.Equal(t, attempts, 3) // initial attempt + 2 retries
+	assert.Equal(t, retries, 2)
+}
+
+func TestRetryVolumeCreateNonRetryableError(t *testing.T) {
+	attempts := 0
+	_, err := retryVolumeCreate(context.Background(), 3, time.Millisecond, func() (*volumetypes.Volume, error) {
+		attempts++
+		return nil, cerrdefs.ErrInvalidArgument
+	}, func(attempt int, err error) {
+		t.Fatal("should not retry a non-retryable error")
+	})
+	assert.ErrorIs(t, err, cerrdefs.ErrInvalidArgument)
+	assert.Equal(t, attempts, 1)
+}
+
+func TestRetryVolumeCreateNoRetriesConfigured(t *testing.T) {
+	attempts := 0
+	_, err := retryVolumeCreate(context.Background(), 0, time.Millisecond, func() (*volumetypes.Volume, error) {
+		attempts++
+		return nil, cerrdefs.ErrUnavailable
+	}, func(attempt int, err error) {
+		t.Fatal("should not retry when VolumeCreateRetries is 0")
+	})
+	assert.ErrorIs(t, err, cerrdefs.ErrUnavailable)
+	assert.Equal(t, attempts, 1)
+}
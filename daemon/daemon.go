@@ -18,6 +18,7 @@
 	"path/filepath"
 	"runtime"
 	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -38,6 +39,7 @@
 	registrytypes "github.com/moby/moby/api/types/registry"
 	"github.com/moby/moby/api/types/swarm"
 	"github.com/moby/moby/v2/daemon/internal/nri"
+	"github.com/moby/pubsub"
 	"github.com/moby/sys/user"
 	"github.com/moby/sys/userns"
 	"github.com/opencontainers/selinux/go-selinux"
@@ -110,25 +112,29 @@ type Daemon struct {
 	defaultLogConfig  containertypes.LogConfig
 	registryService   *registry.Service
 	EventsService     *events.Events
-	netController     *libnetwork.Controller
-	volumes           *volumesservice.VolumesService
-	root              string
-	sysInfoOnce       sync.Once
-	sysInfo           *sysinfo.SysInfo
-	sysInfoErr        error
-	shutdown          bool
-	idMapping         user.IdentityMapping
-	PluginStore       *plugin.Store // TODO: remove
-	nri               *nri.NRI
-	pluginManager     *plugin.Manager
-	linkIndex         *linkIndex
-	containerdClient  *containerd.Client
-	containerd        libcontainerdtypes.Client
-	defaultIsolation  containertypes.Isolation // Default isolation mode on Windows
-	clusterProvider   cluster.Provider
-	cluster           Cluster
-	genericResources  []swarm.GenericResource
-	ReferenceStore    refstore.Store
+	healthEvents      *pubsub.Publisher
+	// stopLimiter bounds the number of container stops processed
+	// concurrently, per config.MaxConcurrentStops. Nil means unbounded.
+	stopLimiter      *semaphore.Weighted
+	netController    *libnetwork.Controller
+	volumes          *volumesservice.VolumesService
+	root             string
+	sysInfoOnce      sync.Once
+	sysInfo          *sysinfo.SysInfo
+	sysInfoErr       error
+	shutdown         bool
+	idMapping        user.IdentityMapping
+	PluginStore      *plugin.Store // TODO: remove
+	nri              *nri.NRI
+	pluginManager    *plugin.Manager
+	linkIndex        *linkIndex
+	containerdClient *containerd.Client
+	containerd       libcontainerdtypes.Client
+	defaultIsolation containertypes.Isolation // Default isolation mode on Windows
+	clusterProvider  cluster.Provider
+	cluster          Cluster
+	genericResources []swarm.GenericResource
+	ReferenceStore   refstore.Store
 
 	machineMemory uint64
 
@@ -199,6 +205,14 @@ func (daemon *Daemon) HasExperimental() bool {
 	return daemon.config().Experimental
 }
 
+// HasBuildSquashEnabled returns whether `docker build --squash` is permitted
+// independently of Experimental, either because EnableBuildSquash is set or
+// because Experimental itself is enabled.
+func (daemon *Daemon) HasBuildSquashEnabled() bool {
+	cfg := daemon.config()
+	return cfg.EnableBuildSquash || cfg.Experimental
+}
+
 // Features returns the features map from configStore
 func (daemon *Daemon) Features() map[string]bool {
 	return daemon.config().Features
@@ -272,6 +286,30 @@ func (daemon *Daemon) loadContainers(ctx context.Context) (map[string]map[string
 	return driverContainers, nil
 }
 
+// restorePriorityLabel names the container label used to influence the
+// order in which containers are restarted during daemon restore. Containers
+// with a higher priority are, dependencies permitting, started before
+// containers with a lower (or unset) priority.
+const restorePriorityLabel = "com.docker.restore-priority"
+
+// containerRestorePriority returns c's restore priority, as set through the
+// [restorePriorityLabel] label. It defaults to 0 for containers that don't
+// set the label, or set it to something that doesn't parse as an integer.
+func containerRestorePriority(c *container.Container) int {
+	if c.Config == nil {
+		return 0
+	}
+	v, ok := c.Config.Labels[restorePriorityLabel]
+	if !ok {
+		return 0
+	}
+	priority, err := strconv.Atoi(v)
+	if err != nil {
+		return 0
+	}
+	return priority
+}
+
 func (daemon *Daemon) restore(ctx context.Context, cfg *configStore, containers map[string]*container.Container) error {
 	var mapLock sync.Mutex
 
@@ -627,7 +665,21 @@ func (daemon *Daemon) restore(ctx context.Context, cfg *configStore, containers
 	}
 	group.Wait()
 
-	for c, notifyChan := range restartContainers {
+	// Launch semaphore acquisition in restore-priority order (highest first)
+	// so that, when restore concurrency is constrained, higher-priority
+	// containers tend to start first. This is only a secondary sort: the
+	// dependency wait below still takes precedence, so a low-priority
+	// dependency is never skipped ahead of the container waiting on it.
+	restartOrder := make([]*container.Container, 0, len(restartContainers))
+	for c := range restartContainers {
+		restartOrder = append(restartOrder, c)
+	}
+	slices.SortStableFunc(restartOrder, func(a, b *container.Container) int {
+		return containerRestorePriority(b) - containerRestorePriority(a)
+	})
+
+	for _, c := range restartOrder {
+		notifyChan := restartContainers[c]
 		group.Add(1)
 		go func(c *container.Container, chNotify chan struct{}) {
 			_ = sem.Acquire(context.Background(), 1)
@@ -636,9 +688,9 @@ func (daemon *Daemon) restore(ctx context.Context, cfg *configStore, containers
 
 			logger.Debug("starting container")
 
-			// ignore errors here as this is a best effort to wait for children
-			// (legacy links or container network) to be running before we try to start the container
-			if children := daemon.GetDependentContainers(c); len(children) > 0 {
+			if err := daemon.checkDependencyCycle(c); err != nil {
+				logger.WithError(err).Error("container dependency graph has a cycle, starting without waiting for dependencies")
+			} else if children := daemon.GetDependentContainers(c); len(children) > 0 {
 				timeout := time.NewTimer(5 * time.Second)
 				defer timeout.Stop()
 
@@ -914,6 +966,7 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 		Runtimes: rts,
 	}
 	d.configStore.Store(cfgStore)
+	metrics.SetFeatureFlags(config.Features)
 
 	imgStoreChoice, err := determineImageStoreChoice(config, determineImageStoreChoiceOptions{})
 	if err != nil {
@@ -1080,6 +1133,7 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 	if err != nil {
 		return nil, err
 	}
+	d.volumes.SetAnonymousVolumeUsageCap(cfgStore.AnonymousVolumeUsageCap)
 
 	// Check if Devices cgroup is mounted, it is hard requirement for container security,
 	// on Linux.
@@ -1106,6 +1160,10 @@ func NewDaemon(ctx context.Context, config *config.Config, pluginStore *plugin.S
 	d.statsCollector = d.newStatsCollector(1 * time.Second)
 
 	d.EventsService = events.New()
+	d.healthEvents = pubsub.NewPublisher(100*time.Millisecond, 1024)
+	if maxConcurrentStops := cfgStore.MaxConcurrentStops; maxConcurrentStops > 0 {
+		d.stopLimiter = semaphore.NewWeighted(int64(maxConcurrentStops))
+	}
 	d.root = cfgStore.Root
 	d.idMapping = idMapping
 
@@ -1447,6 +1505,12 @@ func (daemon *Daemon) waitForStartupDone() {
 func (daemon *Daemon) shutdownContainer(c *container.Container) error {
 	ctx := context.WithoutCancel(context.TODO())
 
+	if daemon.config().ShutdownStopSignalForwarding && !c.HostConfig.ForwardStopSignal {
+		// Give the container's stop signal the best chance of reaching every
+		// process before we fall back to SIGKILL at the shutdown timeout.
+		c.HostConfig.ForwardStopSignal = true
+	}
+
 	// If container failed to exit in stopTimeout seconds of SIGTERM, then using the force
 	if err := daemon.containerStop(ctx, c, backend.ContainerStopOptions{}); err != nil {
 		return fmt.Errorf("Failed to stop container %s with error: %v", c.ID, err)
@@ -1574,6 +1638,9 @@ func (daemon *Daemon) Shutdown(ctx context.Context) error {
 	if daemon.EventsService != nil {
 		daemon.EventsService.Close()
 	}
+	if daemon.healthEvents != nil {
+		daemon.healthEvents.Close()
+	}
 
 	return daemon.cleanupMounts(cfg)
 }
@@ -1836,6 +1903,40 @@ func fixMemorySwappiness(resources *containertypes.Resources) {
 	}
 }
 
+// storageDriversSupportingRootfsSizeQuota lists the storage drivers that
+// support a per-container writable-layer size quota via the "size"
+// storage-opt. Other drivers reject the option outright.
+var storageDriversSupportingRootfsSizeQuota = map[string]bool{
+	"overlay2":      true,
+	"btrfs":         true,
+	"zfs":           true,
+	"vfs":           true,
+	"windowsfilter": true,
+}
+
+// applyRootfsSizeQuota validates the container's "size" storage-opt (its
+// rootfs size quota) against what driverName supports, and applies
+// defaultSize when the container doesn't set one itself. An explicit,
+// user-set quota on a driver that doesn't support it is a hard error; the
+// daemon-wide default is silently skipped instead, so that configuring one
+// doesn't break every container on an incompatible driver. It returns the
+// possibly-updated storageOpt.
+func applyRootfsSizeQuota(storageOpt map[string]string, driverName, defaultSize string) (map[string]string, error) {
+	_, userSet := storageOpt["size"]
+	supported := storageDriversSupportingRootfsSizeQuota[driverName]
+
+	if userSet && !supported {
+		return storageOpt, errors.Errorf("the %q storage driver does not support a container rootfs size quota (storage-opt size); use a driver that does (e.g. overlay2, btrfs, zfs) or unset it", driverName)
+	}
+	if !userSet && defaultSize != "" && supported {
+		newOpt := make(map[string]string, len(storageOpt)+1)
+		maps.Copy(newOpt, storageOpt)
+		newOpt["size"] = defaultSize
+		storageOpt = newOpt
+	}
+	return storageOpt, nil
+}
+
 // GetAttachmentStore returns current attachment store associated with the daemon
 func (daemon *Daemon) GetAttachmentStore() *network.AttachmentStore {
 	return &daemon.attachmentStore
@@ -1864,6 +1965,55 @@ func (daemon *Daemon) RegistryService() *registry.Service {
 	return daemon.registryService
 }
 
+// BuildCommandAllowlist returns the configured list of binary names that RUN
+// instructions are permitted to invoke, or nil if unrestricted.
+func (daemon *Daemon) BuildCommandAllowlist() []string {
+	return daemon.config().BuildCommandAllowlist
+}
+
+// BuildMaxLayers returns the configured maximum number of layers a build may
+// produce, or 0 if unlimited.
+func (daemon *Daemon) BuildMaxLayers() int {
+	return daemon.config().BuildMaxLayers
+}
+
+// BuildContextExcludePatterns returns the daemon-wide .dockerignore-style
+// patterns applied to every received build context, in addition to (and
+// regardless of) the client's own .dockerignore. Empty (the default) applies
+// no extra exclusions.
+func (daemon *Daemon) BuildContextExcludePatterns() []string {
+	return daemon.config().BuildContextExcludePatterns
+}
+
+// BuildSymlinkPolicy returns the daemon-configured policy for how the
+// classic builder's context extraction handles symlinks that resolve
+// outside the build context: one of [config.BuildSymlinkPolicyReject],
+// [config.BuildSymlinkPolicyFollowWithin], or (the default, when empty)
+// [config.BuildSymlinkPolicyPreserve].
+func (daemon *Daemon) BuildSymlinkPolicy() string {
+	return daemon.config().BuildSymlinkPolicy
+}
+
+// SensitiveBuildArgPatterns returns the daemon-configured additional
+// substrings used to identify --build-arg values that look sensitive, so
+// they can be masked wherever build options are logged or echoed back to
+// the client for debugging.
+func (daemon *Daemon) SensitiveBuildArgPatterns() []string {
+	return daemon.config().SensitiveBuildArgPatterns
+}
+
+// CheckBuildMinFreeInodes checks that the build root's filesystem has at
+// least the configured build-min-free-inodes number of free inodes
+// available. It returns nil if the check is disabled (the default) or
+// passes.
+func (daemon *Daemon) CheckBuildMinFreeInodes() error {
+	minFreeInodes := daemon.config().BuildMinFreeInodes
+	if minFreeInodes == 0 {
+		return nil
+	}
+	return checkMinFreeInodes(daemon.root, minFreeInodes)
+}
+
 // BuilderBackend returns the backend used by builder
 func (daemon *Daemon) BuilderBackend() builder.Backend {
 	return struct {
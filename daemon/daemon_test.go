@@ -6,12 +6,14 @@
 	"path"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 
 	cerrdefs "github.com/containerd/errdefs"
 	containertypes "github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/api/types/network"
+	"github.com/moby/moby/v2/daemon/config"
 	"github.com/moby/moby/v2/daemon/container"
 	"github.com/moby/moby/v2/daemon/internal/idtools"
 	"github.com/moby/moby/v2/daemon/libnetwork"
@@ -110,6 +112,118 @@ func TestGetContainer(t *testing.T) {
 	}
 }
 
+// TestGetContainers covers the batch-lookup variant of TestGetContainer,
+// checking that it resolves a mix of exact IDs, exact names, and unique
+// prefixes the same way GetContainer does, while also reporting per-reference
+// errors for misses and ambiguous prefixes.
+func TestGetContainers(t *testing.T) {
+	c1 := &container.Container{
+		ID:   "5a4ff6a163ad4533d22d69a2b8960bf7fafdcba06e72d2febdba229008b0bf57",
+		Name: "tender_bardeen",
+	}
+
+	c2 := &container.Container{
+		ID:   "3cdbd1aa394fd68559fd1441d6eff2ab7c1e6363582c82febfaa8045df3bd8de",
+		Name: "drunk_hawking",
+	}
+
+	c3 := &container.Container{
+		ID:   "3cdbd1aa394fd68559fd1441d6eff2abfafdcba06e72d2febdba229008b0bf57",
+		Name: "3cdbd1aa",
+	}
+
+	store := container.NewMemoryStore()
+	store.Add(c1.ID, c1)
+	store.Add(c2.ID, c2)
+	store.Add(c3.ID, c3)
+
+	containersReplica, err := container.NewViewDB()
+	assert.NilError(t, err)
+
+	containersReplica.Save(c1)
+	containersReplica.Save(c2)
+	containersReplica.Save(c3)
+
+	daemon := &Daemon{
+		containers:        store,
+		containersReplica: containersReplica,
+	}
+
+	daemon.reserveName(c1.ID, c1.Name)
+	daemon.reserveName(c2.ID, c2.Name)
+	daemon.reserveName(c3.ID, c3.Name)
+
+	found, errs := daemon.GetContainers([]string{
+		c2.ID,            // exact ID match
+		"tender_bardeen", // exact name match
+		"3cdbd1aa",       // ambiguous prefix, but an exact name match for c3
+		"3cdbd1",         // ambiguous prefix (matches c2 and c3)
+		"nothing",        // no match at all
+	})
+
+	assert.Assert(t, is.Len(found, 3))
+	assert.Check(t, is.Contains(found, c1))
+	assert.Check(t, is.Contains(found, c2))
+	assert.Check(t, is.Contains(found, c3))
+
+	assert.Assert(t, is.Len(errs, 2))
+	assert.Check(t, cerrdefs.IsConflict(errs["3cdbd1"]))
+	assert.Check(t, cerrdefs.IsNotFound(errs["nothing"]))
+}
+
+// TestGetContainerByLabel covers resolving a container by a label
+// key/value pair: no match, a unique match, and an ambiguous match.
+func TestGetContainerByLabel(t *testing.T) {
+	c1 := &container.Container{
+		ID:     "5a4ff6a163ad4533d22d69a2b8960bf7fafdcba06e72d2febdba229008b0bf57",
+		Name:   "primary",
+		Config: &containertypes.Config{Labels: map[string]string{"com.example.role": "primary"}},
+	}
+
+	c2 := &container.Container{
+		ID:     "3cdbd1aa394fd68559fd1441d6eff2ab7c1e6363582c82febfaa8045df3bd8de",
+		Name:   "replica-1",
+		Config: &containertypes.Config{Labels: map[string]string{"com.example.role": "replica"}},
+	}
+
+	c3 := &container.Container{
+		ID:     "3cdbd1aa394fd68559fd1441d6eff2abfafdcba06e72d2febdba229008b0bf57",
+		Name:   "replica-2",
+		Config: &containertypes.Config{Labels: map[string]string{"com.example.role": "replica"}},
+	}
+
+	store := container.NewMemoryStore()
+	store.Add(c1.ID, c1)
+	store.Add(c2.ID, c2)
+	store.Add(c3.ID, c3)
+
+	containersReplica, err := container.NewViewDB()
+	assert.NilError(t, err)
+
+	containersReplica.Save(c1)
+	containersReplica.Save(c2)
+	containersReplica.Save(c3)
+
+	daemon := &Daemon{
+		containers:        store,
+		containersReplica: containersReplica,
+	}
+
+	daemon.reserveName(c1.ID, c1.Name)
+	daemon.reserveName(c2.ID, c2.Name)
+	daemon.reserveName(c3.ID, c3.Name)
+
+	ctr, err := daemon.GetContainerByLabel("com.example.role", "primary")
+	assert.NilError(t, err)
+	assert.Check(t, ctr == c1)
+
+	_, err = daemon.GetContainerByLabel("com.example.role", "replica")
+	assert.Check(t, cerrdefs.IsConflict(err))
+
+	_, err = daemon.GetContainerByLabel("com.example.role", "nothing")
+	assert.Check(t, cerrdefs.IsNotFound(err))
+}
+
 func initDaemonWithVolumeStore(tmp string) (*Daemon, error) {
 	var err error
 	daemon := &Daemon{
@@ -243,7 +357,7 @@ func TestMerge(t *testing.T) {
 		},
 	}
 
-	if err := merge(configUser, configImage); err != nil {
+	if err := merge(configUser, configImage, true); err != nil {
 		t.Error(err)
 	}
 
@@ -279,7 +393,7 @@ func TestMerge(t *testing.T) {
 		},
 	}
 
-	if err := merge(configUser, configImage2); err != nil {
+	if err := merge(configUser, configImage2, true); err != nil {
 		t.Error(err)
 	}
 
@@ -293,6 +407,251 @@ func TestMerge(t *testing.T) {
 	}
 }
 
+func TestMergeLabels(t *testing.T) {
+	configImage := &containertypes.Config{
+		Labels: map[string]string{"com.example.build": "42"},
+	}
+
+	t.Run("inherit", func(t *testing.T) {
+		configUser := &containertypes.Config{Labels: map[string]string{"user": "1"}}
+		assert.NilError(t, merge(configUser, configImage, true))
+		assert.DeepEqual(t, configUser.Labels, map[string]string{"user": "1", "com.example.build": "42"})
+	})
+
+	t.Run("no inherit", func(t *testing.T) {
+		configUser := &containertypes.Config{Labels: map[string]string{"user": "1"}}
+		assert.NilError(t, merge(configUser, configImage, false))
+		assert.DeepEqual(t, configUser.Labels, map[string]string{"user": "1"})
+	})
+}
+
+func TestValidateEnvNames(t *testing.T) {
+	testCases := []struct {
+		doc      string
+		env      []string
+		warnings []string
+		errStr   string
+	}{
+		{
+			doc: "valid names",
+			env: []string{"FOO=bar", "_underscored=1", "PATH2=x"},
+		},
+		{
+			doc:    "invalid character",
+			env:    []string{"FOO-BAR=baz"},
+			errStr: `invalid environment variable name "FOO-BAR"`,
+		},
+		{
+			doc:    "leading digit",
+			env:    []string{"2FOO=baz"},
+			errStr: `invalid environment variable name "2FOO"`,
+		},
+		{
+			doc:      "case-duplicate keys",
+			env:      []string{"Foo=1", "FOO=2"},
+			warnings: []string{`environment variable "FOO" duplicates "Foo" (differing only in case); the last value takes effect`},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.doc, func(t *testing.T) {
+			warnings, err := validateEnvNames(tc.env)
+			if tc.errStr != "" {
+				assert.ErrorContains(t, err, tc.errStr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tc.warnings, warnings)
+		})
+	}
+}
+
+func TestValidateDevices(t *testing.T) {
+	testCases := []struct {
+		doc     string
+		devices []containertypes.DeviceMapping
+		errStr  string
+	}{
+		{
+			doc: "distinct devices",
+			devices: []containertypes.DeviceMapping{
+				{PathOnHost: "/dev/foo", PathInContainer: "/dev/foo", CgroupPermissions: "rwm"},
+				{PathOnHost: "/dev/bar", PathInContainer: "/dev/bar", CgroupPermissions: "r"},
+			},
+		},
+		{
+			doc: "duplicate device, same permissions",
+			devices: []containertypes.DeviceMapping{
+				{PathOnHost: "/dev/foo", PathInContainer: "/dev/foo", CgroupPermissions: "rwm"},
+				{PathOnHost: "/dev/foo", PathInContainer: "/dev/foo", CgroupPermissions: "rwm"},
+			},
+		},
+		{
+			doc: "duplicate device, conflicting permissions",
+			devices: []containertypes.DeviceMapping{
+				{PathOnHost: "/dev/foo", PathInContainer: "/dev/foo", CgroupPermissions: "rwm"},
+				{PathOnHost: "/dev/foo", PathInContainer: "/dev/foo", CgroupPermissions: "r"},
+			},
+			errStr: `conflicting permissions "rwm" and "r" for device "/dev/foo"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.doc, func(t *testing.T) {
+			err := validateDevices(tc.devices)
+			if tc.errStr != "" {
+				assert.ErrorContains(t, err, tc.errStr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestValidateHostname(t *testing.T) {
+	testCases := []struct {
+		doc      string
+		hostname string
+		errStr   string
+	}{
+		{
+			doc:      "valid hostname",
+			hostname: "my-host.example.com",
+		},
+		{
+			doc:      "over-long label",
+			hostname: strings.Repeat("a", 64),
+			errStr:   `label "` + strings.Repeat("a", 64) + `" does not conform to RFC 1123`,
+		},
+		{
+			doc:      "underscore is allowed for backward compatibility",
+			hostname: "my_host",
+		},
+		{
+			doc:      "invalid character",
+			hostname: "my host",
+			errStr:   `label "my host" does not conform to RFC 1123`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.doc, func(t *testing.T) {
+			err := validateHostname(tc.hostname)
+			if tc.errStr != "" {
+				assert.ErrorContains(t, err, tc.errStr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestContainerRestorePriority(t *testing.T) {
+	newContainer := func(priority string) *container.Container {
+		c := &container.Container{Config: &containertypes.Config{}}
+		if priority != "" {
+			c.Config.Labels = map[string]string{restorePriorityLabel: priority}
+		}
+		return c
+	}
+
+	for _, tc := range []struct {
+		doc      string
+		priority string
+		want     int
+	}{
+		{doc: "unset defaults to 0", priority: "", want: 0},
+		{doc: "positive priority", priority: "10", want: 10},
+		{doc: "negative priority", priority: "-5", want: -5},
+		{doc: "not a number defaults to 0", priority: "critical", want: 0},
+	} {
+		t.Run(tc.doc, func(t *testing.T) {
+			assert.Equal(t, containerRestorePriority(newContainer(tc.priority)), tc.want)
+		})
+	}
+}
+
+// TestRestartOrderRespectsPriority asserts that sorting containers with the
+// same comparator used by [Daemon.restore] orders them by descending
+// restore priority. Dependency correctness itself is enforced separately, by
+// the pre-existing wait-for-children logic in restore, which still runs
+// regardless of this ordering.
+func TestRestartOrderRespectsPriority(t *testing.T) {
+	critical := &container.Container{ID: "critical", Config: &containertypes.Config{Labels: map[string]string{restorePriorityLabel: "10"}}}
+	normal := &container.Container{ID: "normal", Config: &containertypes.Config{}}
+	low := &container.Container{ID: "low", Config: &containertypes.Config{Labels: map[string]string{restorePriorityLabel: "-5"}}}
+
+	order := []*container.Container{normal, low, critical}
+	slices.SortStableFunc(order, func(a, b *container.Container) int {
+		return containerRestorePriority(b) - containerRestorePriority(a)
+	})
+
+	ids := make([]string, 0, len(order))
+	for _, c := range order {
+		ids = append(ids, c.ID)
+	}
+	assert.DeepEqual(t, ids, []string{"critical", "normal", "low"})
+}
+
+func TestApplyRootfsSizeQuota(t *testing.T) {
+	tests := []struct {
+		name        string
+		storageOpt  map[string]string
+		driverName  string
+		defaultSize string
+		wantSize    string
+		expectedErr string
+	}{
+		{name: "unset, no default, unsupported driver", driverName: "fuse-overlayfs"},
+		{name: "unset, no default, supported driver", driverName: "overlay2"},
+		{
+			name:       "user-set on supported driver",
+			storageOpt: map[string]string{"size": "5G"},
+			driverName: "overlay2",
+			wantSize:   "5G",
+		},
+		{
+			name:        "user-set on unsupported driver",
+			storageOpt:  map[string]string{"size": "5G"},
+			driverName:  "fuse-overlayfs",
+			expectedErr: `"fuse-overlayfs" storage driver does not support a container rootfs size quota`,
+		},
+		{
+			name:        "default applied on supported driver",
+			driverName:  "vfs",
+			defaultSize: "10G",
+			wantSize:    "10G",
+		},
+		{
+			name:        "default silently skipped on unsupported driver",
+			driverName:  "fuse-overlayfs",
+			defaultSize: "10G",
+		},
+		{
+			name:        "user value wins over default",
+			storageOpt:  map[string]string{"size": "5G"},
+			driverName:  "btrfs",
+			defaultSize: "10G",
+			wantSize:    "5G",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			storageOpt, err := applyRootfsSizeQuota(tc.storageOpt, tc.driverName, tc.defaultSize)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.NilError(t, err)
+			if tc.wantSize == "" {
+				assert.Equal(t, storageOpt["size"], "")
+			} else {
+				assert.Equal(t, storageOpt["size"], tc.wantSize)
+			}
+		})
+	}
+}
+
 func TestValidateContainerIsolation(t *testing.T) {
 	d := Daemon{}
 
@@ -300,6 +659,49 @@ func TestValidateContainerIsolation(t *testing.T) {
 	assert.Check(t, is.Error(err, "invalid isolation 'invalid' on "+runtime.GOOS))
 }
 
+func TestHasBuildSquashEnabled(t *testing.T) {
+	t.Run("disabled by default", func(t *testing.T) {
+		d := Daemon{}
+		d.configStore.Store(&configStore{})
+		assert.Check(t, !d.HasBuildSquashEnabled())
+	})
+
+	t.Run("enabled via dedicated flag", func(t *testing.T) {
+		d := Daemon{}
+		d.configStore.Store(&configStore{Config: config.Config{CommonConfig: config.CommonConfig{EnableBuildSquash: true}}})
+		assert.Check(t, d.HasBuildSquashEnabled())
+	})
+
+	t.Run("enabled via experimental", func(t *testing.T) {
+		d := Daemon{}
+		d.configStore.Store(&configStore{Config: config.Config{CommonConfig: config.CommonConfig{Experimental: true}}})
+		assert.Check(t, d.HasBuildSquashEnabled())
+	})
+}
+
+func TestPrivilegedContainersPolicy(t *testing.T) {
+	d := Daemon{}
+	hc := &containertypes.HostConfig{Privileged: true}
+
+	t.Run("warn (default)", func(t *testing.T) {
+		warnings, err := d.verifyContainerSettings(&configStore{}, hc, &containertypes.Config{}, false)
+		assert.NilError(t, err)
+		assert.Check(t, is.Contains(warnings, "This container is privileged, which grants it full access to the host and disables most container isolation. Privileged containers should be avoided on shared or multi-tenant hosts."))
+	})
+
+	t.Run("forbid", func(t *testing.T) {
+		cfg := &configStore{Config: config.Config{CommonConfig: config.CommonConfig{PrivilegedContainersPolicy: config.PrivilegedContainersPolicyForbid}}}
+		_, err := d.verifyContainerSettings(cfg, hc, &containertypes.Config{}, false)
+		assert.Check(t, is.ErrorContains(err, "privileged containers are forbidden"))
+	})
+
+	t.Run("forbid does not affect unprivileged containers", func(t *testing.T) {
+		cfg := &configStore{Config: config.Config{CommonConfig: config.CommonConfig{PrivilegedContainersPolicy: config.PrivilegedContainersPolicyForbid}}}
+		_, err := d.verifyContainerSettings(cfg, &containertypes.HostConfig{}, &containertypes.Config{}, false)
+		assert.NilError(t, err)
+	})
+}
+
 func TestInvalidContainerPort0(t *testing.T) {
 	d := Daemon{}
 
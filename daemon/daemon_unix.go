@@ -10,6 +10,7 @@
 	"net/netip"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"runtime/debug"
 	"slices"
@@ -36,6 +37,7 @@
 	"github.com/moby/moby/v2/daemon/libnetwork/netlabel"
 	"github.com/moby/moby/v2/daemon/libnetwork/nlwrap"
 	lntypes "github.com/moby/moby/v2/daemon/libnetwork/types"
+	"github.com/moby/moby/v2/daemon/pkg/cpuquota"
 	"github.com/moby/moby/v2/daemon/pkg/opts"
 	volumemounts "github.com/moby/moby/v2/daemon/volume/mounts"
 	"github.com/moby/moby/v2/errdefs"
@@ -66,6 +68,12 @@
 
 	// It's not kernel limit, we want this 6M limit to account for overhead during startup, and to supply a reasonable functional container
 	linuxMinMemory = 6291456
+
+	// maxCPUBurstMultiplier bounds HostConfig.Resources.CPUBurst to a
+	// reasonable multiple of the CPU quota: a burst allowance far beyond the
+	// quota it's meant to smooth out is almost always a misconfiguration
+	// rather than an intentional choice.
+	maxCPUBurstMultiplier = 10
 	// constants for remapped root settings
 	defaultIDSpecifier = "default"
 	defaultRemappedID  = "dockremap"
@@ -161,6 +169,11 @@ func getCPUResources(config containertypes.Resources) (*specs.LinuxCPU, error) {
 		cpu.Quota = &q
 	}
 
+	if config.CPUBurst != 0 {
+		burst := uint64(config.CPUBurst)
+		cpu.Burst = &burst
+	}
+
 	if config.CPURealtimePeriod != 0 {
 		period := uint64(config.CPURealtimePeriod)
 		cpu.RealtimePeriod = &period
@@ -239,6 +252,12 @@ func parseSecurityOpt(securityOptions *container.SecurityOptions, config *contai
 			labelOpts = append(labelOpts, v)
 		case "apparmor":
 			securityOptions.AppArmorProfile = v
+		case "apparmor-tweaks":
+			tweaks, err := parseAppArmorTweaks(v)
+			if err != nil {
+				return err
+			}
+			securityOptions.AppArmorTweaks = tweaks
 		case "seccomp":
 			securityOptions.SeccompProfile = v
 		case "no-new-privileges":
@@ -262,6 +281,27 @@ func parseSecurityOpt(securityOptions *container.SecurityOptions, config *contai
 	return err
 }
 
+// appArmorTweakPattern matches a single "kind:path" apparmor-tweaks entry.
+// Only a narrow, safe set of additive rule kinds is supported; path must be
+// an absolute path (optionally containing AppArmor glob characters).
+var appArmorTweakPattern = regexp.MustCompile(`^(allow-read|allow-write):(/[A-Za-z0-9_./*?{}\[\]-]*)$`)
+
+// parseAppArmorTweaks validates a comma-separated "apparmor-tweaks"
+// security-opt value (e.g. "allow-read:/data/**,allow-write:/tmp/out"),
+// returning the individual, still-unexpanded "kind:path" entries. Anything
+// outside the supported set of tweak kinds or path syntax is rejected.
+func parseAppArmorTweaks(raw string) ([]string, error) {
+	parts := strings.Split(raw, ",")
+	tweaks := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if !appArmorTweakPattern.MatchString(part) {
+			return nil, fmt.Errorf("invalid --security-opt apparmor-tweaks entry %q: expected \"allow-read:<path>\" or \"allow-write:<path>\"", part)
+		}
+		tweaks = append(tweaks, part)
+	}
+	return tweaks, nil
+}
+
 func getBlkioThrottleDevices(devs []*blkiodev.ThrottleDevice) ([]specs.LinuxThrottleDevice, error) {
 	var throttleDevices []specs.LinuxThrottleDevice
 	var stat unix.Stat_t
@@ -357,6 +397,10 @@ func (daemon *Daemon) adaptContainerSettings(daemonCfg *config.Config, hostConfi
 
 	adaptSharedNamespaceContainer(daemon, hostConfig)
 
+	if daemonCfg != nil {
+		hostConfig.SecurityOpt = mergeDefaultSecurityOpts(hostConfig.SecurityOpt, daemonCfg.DefaultSecurityOpts)
+	}
+
 	var err error
 	secOpts, err := daemon.generateSecurityOpt(hostConfig)
 	if err != nil {
@@ -371,6 +415,39 @@ func (daemon *Daemon) adaptContainerSettings(daemonCfg *config.Config, hostConfi
 	return nil
 }
 
+// mergeDefaultSecurityOpts appends the daemon's configured default security
+// options to secOpts, skipping any default whose key (e.g. "apparmor" or
+// "no-new-privileges") is already set in secOpts, so the container's own
+// value always wins over the daemon-wide default.
+func mergeDefaultSecurityOpts(secOpts, defaults []string) []string {
+	if len(defaults) == 0 {
+		return secOpts
+	}
+	seen := make(map[string]bool, len(secOpts))
+	for _, opt := range secOpts {
+		seen[securityOptKey(opt)] = true
+	}
+	for _, opt := range defaults {
+		if !seen[securityOptKey(opt)] {
+			secOpts = append(secOpts, opt)
+		}
+	}
+	return secOpts
+}
+
+// securityOptKey returns the key portion of a --security-opt value (e.g.
+// "seccomp" for "seccomp=unconfined"), or the option itself for opts with no
+// key=value or key:value form (e.g. "no-new-privileges").
+func securityOptKey(opt string) string {
+	if k, _, ok := strings.Cut(opt, "="); ok {
+		return k
+	}
+	if k, _, ok := strings.Cut(opt, ":"); ok {
+		return k
+	}
+	return opt
+}
+
 // adaptSharedNamespaceContainer replaces container name with its ID in hostConfig.
 // To be more precisely, it modifies `container:name` to `container:ID` of PidMode, IpcMode
 // and NetworkMode.
@@ -402,8 +479,14 @@ func adaptSharedNamespaceContainer(daemon containerGetter, hostConfig *container
 }
 
 // verifyPlatformContainerResources performs platform-specific validation of the container's resource-configuration
-func verifyPlatformContainerResources(resources *containertypes.Resources, sysInfo *sysinfo.SysInfo, update bool) (warnings []string, _ error) {
+func verifyPlatformContainerResources(resources *containertypes.Resources, sysInfo *sysinfo.SysInfo, update bool, defaultMemorySwappiness *int64, defaultBlkioWeight *uint16) (warnings []string, _ error) {
 	fixMemorySwappiness(resources)
+	if resources.MemorySwappiness == nil {
+		resources.MemorySwappiness = defaultMemorySwappiness
+	}
+	if resources.BlkioWeight == 0 && defaultBlkioWeight != nil {
+		resources.BlkioWeight = *defaultBlkioWeight
+	}
 
 	// memory subsystem checks and adjustments
 	if resources.Memory != 0 && resources.Memory < linuxMinMemory {
@@ -441,8 +524,13 @@ func verifyPlatformContainerResources(resources *containertypes.Resources, sysIn
 	if resources.MemoryReservation > 0 && resources.MemoryReservation < linuxMinMemory {
 		return warnings, errors.New("Minimum memory reservation allowed is 6MB")
 	}
-	if resources.Memory > 0 && resources.MemoryReservation > 0 && resources.Memory < resources.MemoryReservation {
-		return warnings, errors.New("Minimum memory limit can not be less than memory reservation limit, see usage")
+	if resources.Memory > 0 && resources.MemoryReservation > 0 {
+		if resources.Memory < resources.MemoryReservation {
+			return warnings, fmt.Errorf("Minimum memory limit can not be less than memory reservation limit, see usage: memory limit %d is less than memory reservation %d", resources.Memory, resources.MemoryReservation)
+		}
+		if resources.Memory == resources.MemoryReservation {
+			warnings = append(warnings, "Memory limit is equal to memory reservation. This defeats the purpose of a memory reservation soft limit; consider setting memory reservation lower than the memory limit.")
+		}
 	}
 	if resources.OomKillDisable != nil && !sysInfo.OomKillDisable {
 		// only produce warnings if the setting wasn't to *disable* the OOM Kill; no point
@@ -501,6 +589,20 @@ func verifyPlatformContainerResources(resources *containertypes.Resources, sysIn
 	if resources.CPUQuota > 0 && resources.CPUQuota < 1000 {
 		return warnings, errors.New("CPU cfs quota can not be less than 1ms (i.e. 1000)")
 	}
+	if err := cpuquota.ValidateCPUQuotaPeriod(resources.CPUPeriod, resources.CPUQuota, runtime.NumCPU()); err != nil {
+		return warnings, err
+	}
+	if resources.CPUBurst > 0 {
+		if !sysInfo.CPUBurst {
+			return warnings, errors.New("CPU burst can not be set, as your kernel does not support CPU CFS burst or the cgroup is not mounted")
+		}
+		if resources.CPUQuota <= 0 {
+			return warnings, errors.New("CPU burst requires a CPU quota to be set")
+		}
+		if resources.CPUBurst > resources.CPUQuota*maxCPUBurstMultiplier {
+			return warnings, fmt.Errorf("CPU burst (%d) can not be more than %d times the CPU quota (%d)", resources.CPUBurst, maxCPUBurstMultiplier, resources.CPUQuota)
+		}
+	}
 	if resources.CPUPercent > 0 {
 		warnings = append(warnings, fmt.Sprintf("%s does not support CPU percent. Percent discarded.", runtime.GOOS))
 		resources.CPUPercent = 0
@@ -562,6 +664,62 @@ func verifyPlatformContainerResources(resources *containertypes.Resources, sysIn
 	return warnings, nil
 }
 
+// validateCPURealtimeBudget checks that a container's requested real-time
+// CPU period/runtime don't exceed the daemon-wide budget configured via
+// --cpu-rt-period/--cpu-rt-runtime. A container's real-time cgroup is a
+// child of the daemon's own cgroup, so it can never be granted more
+// real-time bandwidth than the daemon reserved for itself; catching this at
+// create time gives a clear error instead of an opaque cgroup write failure
+// later, and prevents one container from being configured in a way that
+// would starve the real-time budget for others.
+func validateCPURealtimeBudget(daemonPeriod, daemonRuntime, ctrPeriod, ctrRuntime int64) error {
+	if ctrPeriod == 0 && ctrRuntime == 0 {
+		return nil
+	}
+	if daemonPeriod == 0 && daemonRuntime == 0 {
+		return errors.New("cpu real-time scheduling requested, but the daemon does not have a real-time budget configured (see --cpu-rt-period and --cpu-rt-runtime)")
+	}
+	if ctrRuntime > daemonRuntime {
+		return errors.Errorf("cpu real-time runtime of %d exceeds the daemon's configured real-time runtime budget of %d", ctrRuntime, daemonRuntime)
+	}
+	if ctrPeriod > daemonPeriod {
+		return errors.Errorf("cpu real-time period of %d exceeds the daemon's configured real-time period budget of %d", ctrPeriod, daemonPeriod)
+	}
+	return nil
+}
+
+// validShmMountOptions are the tmpfs mount options accepted in
+// HostConfig.ShmOptions, in addition to "mode=" options, whose value is not
+// checked here.
+var validShmMountOptions = map[string]bool{
+	"defaults": true,
+	"ro":       true,
+	"rw":       true,
+	"suid":     true,
+	"nosuid":   true,
+	"dev":      true,
+	"nodev":    true,
+	"exec":     true,
+	"noexec":   true,
+	"sync":     true,
+	"async":    true,
+}
+
+// validateShmOptions checks that opts only contains tmpfs mount options
+// recognized for the /dev/shm mount, so that unknown values aren't silently
+// passed through to the OCI spec.
+func validateShmOptions(opts []string) error {
+	for _, opt := range opts {
+		if strings.HasPrefix(opt, "mode=") {
+			continue
+		}
+		if !validShmMountOptions[opt] {
+			return errors.Errorf("invalid shm mount option %q", opt)
+		}
+	}
+	return nil
+}
+
 func cgroupDriver(cfg *config.Config) string {
 	if UsingSystemd(cfg) {
 		return cgroupSystemdDriver
@@ -636,7 +794,7 @@ func verifyPlatformContainerSettings(daemon *Daemon, daemonCfg *configStore, hos
 		return nil, err
 	}
 
-	w, err := verifyPlatformContainerResources(&hostConfig.Resources, sysInfo, update)
+	w, err := verifyPlatformContainerResources(&hostConfig.Resources, sysInfo, update, daemonCfg.DefaultMemorySwappiness, daemonCfg.DefaultBlkioWeight)
 
 	// no matter err is nil or not, w could have data in itself.
 	warnings = append(warnings, w...)
@@ -645,6 +803,24 @@ func verifyPlatformContainerSettings(daemon *Daemon, daemonCfg *configStore, hos
 		return warnings, err
 	}
 
+	w, err = reconcileUlimitCgroupConflicts(&hostConfig.Resources, daemonCfg.RejectUlimitCgroupConflicts)
+	warnings = append(warnings, w...)
+	if err != nil {
+		return warnings, err
+	}
+
+	warnings = append(warnings, reconcileUlimitMemoryConflicts(&hostConfig.Resources)...)
+
+	storageOpt, err := applyRootfsSizeQuota(hostConfig.StorageOpt, daemon.ImageService().StorageDriver(), daemonCfg.DefaultRootfsSize)
+	if err != nil {
+		return warnings, err
+	}
+	hostConfig.StorageOpt = storageOpt
+
+	if err := validateDeviceRequestCounts(context.TODO(), &daemonCfg.Config, hostConfig.DeviceRequests); err != nil {
+		return warnings, err
+	}
+
 	if !hostConfig.IpcMode.Valid() {
 		return warnings, errors.Errorf("invalid IPC mode: %v", hostConfig.IpcMode)
 	}
@@ -654,6 +830,12 @@ func verifyPlatformContainerSettings(daemon *Daemon, daemonCfg *configStore, hos
 	if hostConfig.ShmSize < 0 {
 		return warnings, errors.New("SHM size can not be less than 0")
 	}
+	if err := validateShmOptions(hostConfig.ShmOptions); err != nil {
+		return warnings, err
+	}
+	if err := validateCPURealtimeBudget(daemonCfg.CPURealtimePeriod, daemonCfg.CPURealtimeRuntime, hostConfig.Resources.CPURealtimePeriod, hostConfig.Resources.CPURealtimeRuntime); err != nil {
+		return warnings, err
+	}
 	if !hostConfig.UTSMode.Valid() {
 		return warnings, errors.Errorf("invalid UTS mode: %v", hostConfig.UTSMode)
 	}
@@ -662,6 +844,10 @@ func verifyPlatformContainerSettings(daemon *Daemon, daemonCfg *configStore, hos
 		return warnings, fmt.Errorf("Invalid value %d, range for oom score adj is [-1000, 1000]", hostConfig.OomScoreAdj)
 	}
 
+	if err := validateSysctls(hostConfig.Sysctls, daemonCfg.AllowedSysctls, hostConfig.Privileged); err != nil {
+		return warnings, err
+	}
+
 	// ip-forwarding does not affect container with '--net=host' (or '--net=none')
 	if sysInfo.IPv4ForwardingDisabled && (!hostConfig.NetworkMode.IsHost() && !hostConfig.NetworkMode.IsNone()) {
 		warnings = append(warnings, "IPv4 forwarding is disabled. Networking will not work.")
@@ -712,9 +898,132 @@ func verifyPlatformContainerSettings(daemon *Daemon, daemonCfg *configStore, hos
 		}
 	}
 
+	if hostConfig.RngDevice != "" {
+		if err := validateRngDevice(hostConfig.RngDevice); err != nil {
+			return warnings, err
+		}
+	}
+
+	if hostConfig.TimeNsOffsetSeconds != nil && !sysInfo.TimeNamespaces {
+		return warnings, errors.New("time namespace offset was requested, but the kernel does not support time namespaces")
+	}
+
+	return warnings, nil
+}
+
+// defaultNamespacedSysctls are sysctls that are always namespaced (IPC
+// namespace), and so are safe to set from a non-privileged container
+// regardless of daemon configuration. Any "net.*" sysctl is namespaced too
+// (network namespace) and is checked separately in isDefaultNamespacedSysctl.
+var defaultNamespacedSysctls = map[string]bool{
+	"kernel.msgmax":          true,
+	"kernel.msgmnb":          true,
+	"kernel.msgmni":          true,
+	"kernel.sem":             true,
+	"kernel.shmall":          true,
+	"kernel.shmmax":          true,
+	"kernel.shmmni":          true,
+	"kernel.shm_rmid_forced": true,
+}
+
+// isDefaultNamespacedSysctl reports whether key is namespaced (per-container)
+// regardless of daemon configuration.
+func isDefaultNamespacedSysctl(key string) bool {
+	if strings.HasPrefix(key, "net.") {
+		return true
+	}
+	return defaultNamespacedSysctls[key]
+}
+
+// validateSysctls checks sysctls (from HostConfig.Sysctls) against the
+// default namespaced set and the daemon-configured allowedSysctls allowlist.
+// Privileged containers may set any sysctl, since they already have broader
+// access to the host. A non-privileged container that requests a sysctl that
+// is neither namespaced nor allowlisted is rejected: setting a non-namespaced
+// sysctl from a container can affect the host or other containers.
+func validateSysctls(sysctls map[string]string, allowedSysctls []string, privileged bool) error {
+	if privileged || len(sysctls) == 0 {
+		return nil
+	}
+	allowed := make(map[string]bool, len(allowedSysctls))
+	for _, s := range allowedSysctls {
+		allowed[s] = true
+	}
+	for k := range sysctls {
+		if isDefaultNamespacedSysctl(k) || allowed[k] {
+			continue
+		}
+		return fmt.Errorf("sysctl %q is not allowed: it is not namespaced and is not in the allow-sysctl allowlist", k)
+	}
+	return nil
+}
+
+// reconcileUlimitCgroupConflicts checks resources for a container that sets
+// both an "nproc" ulimit and a cgroup PidsLimit: the two enforce process
+// counts through different mechanisms (an rlimit inherited per-process vs. a
+// cgroup-wide counter), and having both set is a common source of confusion
+// about which one actually took effect. When strict is true, the conflict is
+// rejected outright. Otherwise, it is allowed with a warning: the cgroup
+// PidsLimit wins, since (unlike the ulimit) it is enforced cgroup-wide and
+// can't be bypassed by exec'ing a new process tree.
+func reconcileUlimitCgroupConflicts(resources *containertypes.Resources, strict bool) (warnings []string, _ error) {
+	if resources.PidsLimit == nil || *resources.PidsLimit <= 0 {
+		return nil, nil
+	}
+	for _, ulimit := range resources.Ulimits {
+		if ulimit.Name != "nproc" {
+			continue
+		}
+		if strict {
+			return nil, fmt.Errorf("conflicting options: --ulimit nproc=%d and --pids-limit=%d both set; set only one", ulimit.Soft, *resources.PidsLimit)
+		}
+		warnings = append(warnings, fmt.Sprintf("both --ulimit nproc=%d and --pids-limit=%d are set; the cgroup PIDs limit takes precedence", ulimit.Soft, *resources.PidsLimit))
+	}
 	return warnings, nil
 }
 
+// minBytesPerOpenFile is a conservative, deliberately rough estimate of the
+// kernel memory (struct file, dentry and inode cache entries) consumed by a
+// single open file descriptor. It is only used to size the heuristic in
+// reconcileUlimitMemoryConflicts, never to enforce an actual limit.
+const minBytesPerOpenFile = 1024
+
+// reconcileUlimitMemoryConflicts warns when a container's "nofile" ulimit is
+// set high enough, relative to its memory limit, that a process actually
+// reaching the nofile limit could account for a large share of the memory
+// limit just from file-descriptor bookkeeping. This is advisory only: actual
+// per-file-descriptor cost varies by workload, so it never blocks container
+// creation.
+func reconcileUlimitMemoryConflicts(resources *containertypes.Resources) (warnings []string) {
+	if resources.Memory <= 0 {
+		return nil
+	}
+	for _, ulimit := range resources.Ulimits {
+		if ulimit.Name != "nofile" || ulimit.Hard <= 0 {
+			continue
+		}
+		if estimated := ulimit.Hard * minBytesPerOpenFile; estimated > resources.Memory/2 {
+			warnings = append(warnings, fmt.Sprintf(
+				"--ulimit nofile=%d is set with --memory=%d; a process that reaches the nofile limit could use an estimated %d bytes just for open file bookkeeping, more than half the memory limit; consider lowering nofile or raising the memory limit",
+				ulimit.Hard, resources.Memory, estimated))
+		}
+	}
+	return warnings
+}
+
+// validateRngDevice checks that path exists on the host and is a character
+// device, suitable for use as HostConfig.RngDevice.
+func validateRngDevice(path string) error {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return errors.Wrap(err, "invalid rng device")
+	}
+	if fi.Mode()&os.ModeCharDevice == 0 {
+		return errors.Errorf("invalid rng device %q: not a character device", path)
+	}
+	return nil
+}
+
 // verifyDaemonSettings performs validation of daemon config struct
 func verifyDaemonSettings(conf *config.Config) error {
 	if conf.ContainerdNamespace == conf.ContainerdPluginNamespace {
@@ -750,6 +1059,21 @@ func verifyDaemonSettings(conf *config.Config) error {
 	if conf.Rootless && UsingSystemd(conf) && cgroups.Mode() != cgroups.Unified {
 		return errors.New("exec-opt native.cgroupdriver=systemd requires cgroup v2 for rootless mode")
 	}
+	if conf.DefaultMemorySwappiness != nil {
+		if v := *conf.DefaultMemorySwappiness; v < 0 || v > 100 {
+			return errors.Errorf("invalid default-memory-swappiness: %d, valid range is 0-100", v)
+		}
+	}
+	if conf.DefaultBlkioWeight != nil {
+		if v := *conf.DefaultBlkioWeight; v < 10 || v > 1000 {
+			return errors.Errorf("invalid default-blkio-weight: %d, valid range is 10-1000", v)
+		}
+	}
+	if len(conf.DefaultSecurityOpts) > 0 {
+		if err := parseSecurityOpt(&container.SecurityOptions{}, &containertypes.HostConfig{SecurityOpt: conf.DefaultSecurityOpts}); err != nil {
+			return errors.Wrap(err, "invalid default-security-opts")
+		}
+	}
 	return nil
 }
 
@@ -912,6 +1236,7 @@ func networkPlatformOptions(conf *config.Config) []nwconfig.Option {
 	return []nwconfig.Option{
 		nwconfig.OptionRootless(conf.Rootless),
 		nwconfig.OptionUserlandProxy(conf.EnableUserlandProxy, conf.UserlandProxyPath),
+		nwconfig.OptionEmbeddedDNSAddress(conf.EmbeddedDNSAddress),
 		nwconfig.OptionBridgeConfig(bridge.Configuration{
 			EnableIPForwarding:       conf.BridgeConfig.EnableIPForward,
 			DisableFilterForwardDrop: conf.BridgeConfig.DisableFilterForwardDrop,
@@ -919,6 +1244,7 @@ func networkPlatformOptions(conf *config.Config) []nwconfig.Option {
 			EnableIP6Tables:          conf.BridgeConfig.EnableIP6Tables,
 			EnableProxy:              conf.EnableUserlandProxy && conf.UserlandProxyPath != "",
 			ProxyPath:                conf.UserlandProxyPath,
+			ProxyBackend:             conf.BridgeConfig.UserlandProxyBackend,
 			AllowDirectRouting:       conf.BridgeConfig.AllowDirectRouting,
 			AcceptFwMark:             conf.BridgeConfig.BridgeAcceptFwMark,
 		}),
@@ -1393,9 +1719,15 @@ func setupDaemonRoot(config *config.Config, rootDir string, uid, gid int) error
 	}
 
 	curuid := os.Getuid()
-	// First make sure the current root dir has the correct perms.
-	if err := user.MkdirAllAndChown(config.Root, 0o710, curuid, gid); err != nil {
-		return errors.Wrapf(err, "could not create or set daemon root permissions: %s", config.Root)
+	skipChown, err := checkDataRootOwnership(rootDir, curuid, gid, config.DataRootPermissionPolicy)
+	if err != nil {
+		return err
+	}
+	if !skipChown {
+		// First make sure the current root dir has the correct perms.
+		if err := user.MkdirAllAndChown(config.Root, 0o710, curuid, gid); err != nil {
+			return errors.Wrapf(err, "could not create or set daemon root permissions: %s", config.Root)
+		}
 	}
 
 	// if user namespaces are enabled we will create a subtree underneath the specified root
@@ -1431,6 +1763,33 @@ func setupDaemonRoot(config *config.Config, rootDir string, uid, gid int) error
 	return nil
 }
 
+// checkDataRootOwnership compares an existing data root directory's ownership
+// against the uid:gid the daemon expects to own it, and applies policy
+// (one of the config.DataRootPermissionPolicy* constants) when they differ:
+// fixup lets the caller proceed with its normal chown, warn logs and tells
+// the caller to leave ownership alone, and fail refuses to start. A
+// directory that doesn't exist yet, or already has the expected ownership,
+// is always left to the caller's normal chown.
+func checkDataRootOwnership(rootDir string, uid, gid int, policy string) (skipChown bool, _ error) {
+	fi, err := os.Stat(rootDir)
+	if err != nil {
+		return false, nil
+	}
+	st, ok := fi.Sys().(*syscall.Stat_t)
+	if !ok || (int(st.Uid) == uid && int(st.Gid) == gid) {
+		return false, nil
+	}
+	switch policy {
+	case config.DataRootPermissionPolicyFail:
+		return false, errors.Errorf("data root %s is owned by %d:%d, expected %d:%d; refusing to start (data-root-permission-policy=%s)", rootDir, st.Uid, st.Gid, uid, gid, policy)
+	case config.DataRootPermissionPolicyWarn:
+		log.G(context.TODO()).Warnf("data root %s is owned by %d:%d, expected %d:%d; leaving ownership unchanged (data-root-permission-policy=warn)", rootDir, st.Uid, st.Gid, uid, gid)
+		return true, nil
+	default: // "" or DataRootPermissionPolicyFixup
+		return false, nil
+	}
+}
+
 // canAccess takes a valid (existing) directory and a uid, gid pair and determines
 // if that uid, gid pair has access (execute bit) to the directory.
 //
@@ -4,6 +4,7 @@
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -118,6 +119,21 @@ func TestParseSecurityOpt(t *testing.T) {
 		assert.Check(t, err)
 		assert.Equal(t, secOpts.AppArmorProfile, "test_profile")
 	})
+	t.Run("apparmor-tweaks", func(t *testing.T) {
+		secOpts := &container.SecurityOptions{}
+		err := parseSecurityOpt(secOpts, &containertypes.HostConfig{
+			SecurityOpt: []string{"apparmor-tweaks=allow-read:/data/**,allow-write:/tmp/out"},
+		})
+		assert.Check(t, err)
+		assert.Check(t, is.DeepEqual(secOpts.AppArmorTweaks, []string{"allow-read:/data/**", "allow-write:/tmp/out"}))
+	})
+	t.Run("apparmor-tweaks unsupported kind", func(t *testing.T) {
+		secOpts := &container.SecurityOptions{}
+		err := parseSecurityOpt(secOpts, &containertypes.HostConfig{
+			SecurityOpt: []string{"apparmor-tweaks=allow-exec:/bin/sh"},
+		})
+		assert.Check(t, is.ErrorContains(err, "invalid --security-opt apparmor-tweaks entry"))
+	})
 	t.Run("seccomp", func(t *testing.T) {
 		secOpts := &container.SecurityOptions{}
 		err := parseSecurityOpt(secOpts, &containertypes.HostConfig{
@@ -200,6 +216,25 @@ func TestParseNNPSecurityOptions(t *testing.T) {
 	}
 }
 
+func TestMergeDefaultSecurityOpts(t *testing.T) {
+	t.Run("no defaults", func(t *testing.T) {
+		got := mergeDefaultSecurityOpts([]string{"seccomp=unconfined"}, nil)
+		assert.Check(t, is.DeepEqual(got, []string{"seccomp=unconfined"}))
+	})
+	t.Run("default applied", func(t *testing.T) {
+		got := mergeDefaultSecurityOpts(nil, []string{"no-new-privileges"})
+		assert.Check(t, is.DeepEqual(got, []string{"no-new-privileges"}))
+	})
+	t.Run("container value overrides default with the same key", func(t *testing.T) {
+		got := mergeDefaultSecurityOpts([]string{"apparmor=custom_profile"}, []string{"apparmor=default_profile"})
+		assert.Check(t, is.DeepEqual(got, []string{"apparmor=custom_profile"}))
+	})
+	t.Run("defaults and container opts for different keys are both kept", func(t *testing.T) {
+		got := mergeDefaultSecurityOpts([]string{"seccomp=unconfined"}, []string{"no-new-privileges", "apparmor=default_profile"})
+		assert.Check(t, is.DeepEqual(got, []string{"seccomp=unconfined", "no-new-privileges", "apparmor=default_profile"}))
+	})
+}
+
 func TestVerifyPlatformContainerResources(t *testing.T) {
 	t.Parallel()
 	var (
@@ -284,7 +319,7 @@ func TestVerifyPlatformContainerResources(t *testing.T) {
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
-			warnings, err := verifyPlatformContainerResources(&tc.resources, &tc.sysInfo, tc.update)
+			warnings, err := verifyPlatformContainerResources(&tc.resources, &tc.sysInfo, tc.update, nil, nil)
 			assert.NilError(t, err)
 			for _, w := range tc.expectedWarnings {
 				assert.Assert(t, is.Contains(warnings, w))
@@ -293,6 +328,202 @@ func TestVerifyPlatformContainerResources(t *testing.T) {
 	}
 }
 
+func TestVerifyPlatformContainerResourcesMemorySwappiness(t *testing.T) {
+	t.Parallel()
+	withMemorySwappiness := func(si *sysinfo.SysInfo) {
+		si.MemorySwappiness = true
+	}
+
+	tests := []struct {
+		name        string
+		swappiness  *int64
+		expectedErr string
+	}{
+		{name: "unset"},
+		{name: "min", swappiness: int64Ptr(0)},
+		{name: "max", swappiness: int64Ptr(100)},
+		{name: "mid", swappiness: int64Ptr(60)},
+		{name: "below-range", swappiness: int64Ptr(-2), expectedErr: "valid memory swappiness range is 0-100"},
+		{name: "above-range", swappiness: int64Ptr(101), expectedErr: "valid memory swappiness range is 0-100"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			resources := containertypes.Resources{MemorySwappiness: tc.swappiness}
+			si := sysInfo(t, withMemorySwappiness)
+			_, err := verifyPlatformContainerResources(&resources, &si, false, nil, nil)
+			if tc.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestVerifyPlatformContainerResourcesCPUBurst(t *testing.T) {
+	t.Parallel()
+	withCPUCfs := func(si *sysinfo.SysInfo) {
+		si.CPUCfs = true
+	}
+	withCPUBurst := func(si *sysinfo.SysInfo) {
+		si.CPUCfs = true
+		si.CPUBurst = true
+	}
+
+	tests := []struct {
+		name        string
+		resources   containertypes.Resources
+		sysInfo     sysinfo.SysInfo
+		expectedErr string
+	}{
+		{
+			name:      "unset",
+			resources: containertypes.Resources{},
+			sysInfo:   sysInfo(t, withCPUCfs),
+		},
+		{
+			name:      "burst applied with quota, kernel support",
+			resources: containertypes.Resources{CPUQuota: 100000, CPUBurst: 200000},
+			sysInfo:   sysInfo(t, withCPUBurst),
+		},
+		{
+			name:        "burst without kernel support",
+			resources:   containertypes.Resources{CPUQuota: 100000, CPUBurst: 200000},
+			sysInfo:     sysInfo(t, withCPUCfs),
+			expectedErr: "does not support CPU CFS burst",
+		},
+		{
+			name:        "burst without quota",
+			resources:   containertypes.Resources{CPUBurst: 200000},
+			sysInfo:     sysInfo(t, withCPUBurst),
+			expectedErr: "requires a CPU quota",
+		},
+		{
+			name:        "burst too far beyond quota",
+			resources:   containertypes.Resources{CPUQuota: 100000, CPUBurst: 100000*maxCPUBurstMultiplier + 1},
+			sysInfo:     sysInfo(t, withCPUBurst),
+			expectedErr: "can not be more than",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := verifyPlatformContainerResources(&tc.resources, &tc.sysInfo, false, nil, nil)
+			if tc.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			}
+		})
+	}
+}
+
+func TestVerifyPlatformContainerResourcesMemoryReservation(t *testing.T) {
+	t.Parallel()
+	si := sysInfo(t, func(si *sysinfo.SysInfo) {
+		si.MemoryLimit = true
+		si.MemoryReservation = true
+	})
+
+	tests := []struct {
+		name             string
+		memory           int64
+		memoryReserve    int64
+		expectedErr      string
+		expectedWarnings []string
+	}{
+		{
+			name:          "only-reservation-set",
+			memoryReserve: linuxMinMemory,
+		},
+		{
+			name:   "only-limit-set",
+			memory: linuxMinMemory,
+		},
+		{
+			name:          "valid-pair",
+			memory:        linuxMinMemory * 2,
+			memoryReserve: linuxMinMemory,
+		},
+		{
+			name:          "inverted-pair",
+			memory:        linuxMinMemory,
+			memoryReserve: linuxMinMemory * 2,
+			expectedErr:   fmt.Sprintf("memory limit %d is less than memory reservation %d", linuxMinMemory, linuxMinMemory*2),
+		},
+		{
+			name:          "equal-pair",
+			memory:        linuxMinMemory,
+			memoryReserve: linuxMinMemory,
+			expectedWarnings: []string{
+				"Memory limit is equal to memory reservation. This defeats the purpose of a memory reservation soft limit; consider setting memory reservation lower than the memory limit.",
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			resources := containertypes.Resources{Memory: tc.memory, MemoryReservation: tc.memoryReserve}
+			warnings, err := verifyPlatformContainerResources(&resources, &si, false, nil, nil)
+			if tc.expectedErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			}
+			for _, w := range tc.expectedWarnings {
+				assert.Assert(t, is.Contains(warnings, w))
+			}
+		})
+	}
+}
+
+func TestVerifyPlatformContainerResourcesDefaultMemorySwappiness(t *testing.T) {
+	t.Parallel()
+	si := sysInfo(t, func(si *sysinfo.SysInfo) { si.MemorySwappiness = true })
+	defaultSwappiness := int64Ptr(42)
+
+	// The daemon default applies when the container doesn't set a value.
+	resources := containertypes.Resources{}
+	_, err := verifyPlatformContainerResources(&resources, &si, false, defaultSwappiness, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, resources.MemorySwappiness != nil)
+	assert.Equal(t, *resources.MemorySwappiness, *defaultSwappiness)
+
+	// An explicit user value wins over the daemon default.
+	userSwappiness := int64Ptr(10)
+	resources = containertypes.Resources{MemorySwappiness: userSwappiness}
+	_, err = verifyPlatformContainerResources(&resources, &si, false, defaultSwappiness, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, *resources.MemorySwappiness, *userSwappiness)
+}
+
+func TestVerifyPlatformContainerResourcesDefaultBlkioWeight(t *testing.T) {
+	t.Parallel()
+	si := sysInfo(t, func(si *sysinfo.SysInfo) { si.BlkioWeight = true })
+	defaultWeight := uint16Ptr(300)
+
+	// The daemon default applies when the container doesn't set a value.
+	resources := containertypes.Resources{}
+	_, err := verifyPlatformContainerResources(&resources, &si, false, nil, defaultWeight)
+	assert.NilError(t, err)
+	assert.Equal(t, resources.BlkioWeight, *defaultWeight)
+
+	// An explicit user value wins over the daemon default.
+	resources = containertypes.Resources{BlkioWeight: 50}
+	_, err = verifyPlatformContainerResources(&resources, &si, false, nil, defaultWeight)
+	assert.NilError(t, err)
+	assert.Equal(t, resources.BlkioWeight, uint16(50))
+
+	// The default itself is still subject to the usual range validation.
+	_, err = verifyPlatformContainerResources(&containertypes.Resources{}, &si, false, nil, uint16Ptr(5))
+	assert.ErrorContains(t, err, "Range of blkio weight is from 10 to 1000")
+}
+
+func int64Ptr(v int64) *int64 { return &v }
+
+func uint16Ptr(v uint16) *uint16 { return &v }
+
 func sysInfo(t *testing.T, opts ...func(*sysinfo.SysInfo)) sysinfo.SysInfo {
 	t.Helper()
 	si := sysinfo.SysInfo{}
@@ -335,6 +566,85 @@ func deviceTypeMock(t *testing.T, testAndCheck func(string)) {
 	testAndCheck(tempFile)
 }
 
+func TestValidateShmOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		opts      []string
+		expectErr string
+	}{
+		{name: "unset"},
+		{name: "known options", opts: []string{"noexec", "nosuid", "nodev"}},
+		{name: "mode option", opts: []string{"mode=1770"}},
+		{name: "unknown option", opts: []string{"bogus"}, expectErr: `invalid shm mount option "bogus"`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateShmOptions(tc.opts)
+			if tc.expectErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectErr)
+			}
+		})
+	}
+}
+
+func TestValidateCPURealtimeBudget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name                        string
+		daemonPeriod, daemonRuntime int64
+		ctrPeriod, ctrRuntime       int64
+		expectErr                   string
+	}{
+		{name: "no realtime requested"},
+		{
+			name:       "realtime requested but daemon has no budget",
+			ctrPeriod:  1000000,
+			ctrRuntime: 950000,
+			expectErr:  "daemon does not have a real-time budget configured",
+		},
+		{
+			name:          "within budget",
+			daemonPeriod:  1000000,
+			daemonRuntime: 950000,
+			ctrPeriod:     1000000,
+			ctrRuntime:    500000,
+		},
+		{
+			name:          "runtime over budget",
+			daemonPeriod:  1000000,
+			daemonRuntime: 950000,
+			ctrPeriod:     1000000,
+			ctrRuntime:    980000,
+			expectErr:     "exceeds the daemon's configured real-time runtime budget",
+		},
+		{
+			name:          "period over budget",
+			daemonPeriod:  500000,
+			daemonRuntime: 400000,
+			ctrPeriod:     1000000,
+			ctrRuntime:    100000,
+			expectErr:     "exceeds the daemon's configured real-time period budget",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := validateCPURealtimeBudget(tc.daemonPeriod, tc.daemonRuntime, tc.ctrPeriod, tc.ctrRuntime)
+			if tc.expectErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectErr)
+			}
+		})
+	}
+}
+
 func TestGetBlkioWeightDevices(t *testing.T) {
 	deviceTypeMock(t, func(tempFile string) {
 		mockResource := containertypes.Resources{
@@ -364,3 +674,147 @@ func TestGetBlkioThrottleDevices(t *testing.T) {
 		assert.Check(t, retDevs[0].Rate == WEIGHT, "get device rate")
 	})
 }
+
+func TestCheckDataRootOwnership(t *testing.T) {
+	dir := t.TempDir()
+	uid, gid := os.Getuid(), os.Getgid()
+
+	t.Run("matching ownership never blocks, regardless of policy", func(t *testing.T) {
+		for _, policy := range []string{"", config.DataRootPermissionPolicyFixup, config.DataRootPermissionPolicyWarn, config.DataRootPermissionPolicyFail} {
+			skip, err := checkDataRootOwnership(dir, uid, gid, policy)
+			assert.NilError(t, err)
+			assert.Check(t, !skip)
+		}
+	})
+
+	t.Run("mismatched ownership with fixup (default) proceeds to chown", func(t *testing.T) {
+		skip, err := checkDataRootOwnership(dir, uid+1, gid, config.DataRootPermissionPolicyFixup)
+		assert.NilError(t, err)
+		assert.Check(t, !skip)
+	})
+
+	t.Run("mismatched ownership with warn logs and skips chown", func(t *testing.T) {
+		skip, err := checkDataRootOwnership(dir, uid+1, gid, config.DataRootPermissionPolicyWarn)
+		assert.NilError(t, err)
+		assert.Check(t, skip)
+	})
+
+	t.Run("mismatched ownership with fail refuses to start", func(t *testing.T) {
+		_, err := checkDataRootOwnership(dir, uid+1, gid, config.DataRootPermissionPolicyFail)
+		assert.ErrorContains(t, err, "refusing to start")
+	})
+
+	t.Run("non-existent directory is left to the caller", func(t *testing.T) {
+		skip, err := checkDataRootOwnership(filepath.Join(dir, "does-not-exist"), uid+1, gid, config.DataRootPermissionPolicyFail)
+		assert.NilError(t, err)
+		assert.Check(t, !skip)
+	})
+}
+
+func TestValidateRngDeviceValid(t *testing.T) {
+	deviceTypeMock(t, func(tempFile string) {
+		assert.NilError(t, validateRngDevice(tempFile))
+	})
+}
+
+func TestValidateRngDeviceMissing(t *testing.T) {
+	err := validateRngDevice(filepath.Join(t.TempDir(), "does-not-exist"))
+	assert.ErrorContains(t, err, "invalid rng device")
+}
+
+func TestValidateRngDeviceNotCharDevice(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "regular-file")
+	assert.NilError(t, os.WriteFile(path, nil, 0o644))
+
+	err := validateRngDevice(path)
+	assert.ErrorContains(t, err, "not a character device")
+}
+
+func TestReconcileUlimitCgroupConflicts(t *testing.T) {
+	pidsLimit := int64(50)
+	resources := containertypes.Resources{
+		PidsLimit: &pidsLimit,
+		Ulimits:   []*containertypes.Ulimit{{Name: "nproc", Soft: 100, Hard: 100}},
+	}
+
+	warnings, err := reconcileUlimitCgroupConflicts(&resources, false)
+	assert.NilError(t, err)
+	assert.Equal(t, len(warnings), 1)
+	assert.Check(t, is.Contains(warnings[0], "cgroup PIDs limit takes precedence"))
+
+	_, err = reconcileUlimitCgroupConflicts(&resources, true)
+	assert.ErrorContains(t, err, "conflicting options")
+}
+
+func TestReconcileUlimitCgroupConflictsNoConflict(t *testing.T) {
+	pidsLimit := int64(50)
+	resources := containertypes.Resources{
+		PidsLimit: &pidsLimit,
+		Ulimits:   []*containertypes.Ulimit{{Name: "nofile", Soft: 100, Hard: 100}},
+	}
+
+	warnings, err := reconcileUlimitCgroupConflicts(&resources, true)
+	assert.NilError(t, err)
+	assert.Check(t, len(warnings) == 0)
+}
+
+func TestReconcileUlimitMemoryConflicts(t *testing.T) {
+	resources := containertypes.Resources{
+		Memory:  64 * 1024 * 1024,
+		Ulimits: []*containertypes.Ulimit{{Name: "nofile", Soft: 1048576, Hard: 1048576}},
+	}
+
+	warnings := reconcileUlimitMemoryConflicts(&resources)
+	assert.Equal(t, len(warnings), 1)
+	assert.Check(t, is.Contains(warnings[0], "nofile"))
+}
+
+func TestReconcileUlimitMemoryConflictsNoConflict(t *testing.T) {
+	resources := containertypes.Resources{
+		Memory:  64 * 1024 * 1024,
+		Ulimits: []*containertypes.Ulimit{{Name: "nofile", Soft: 1024, Hard: 1024}},
+	}
+
+	warnings := reconcileUlimitMemoryConflicts(&resources)
+	assert.Check(t, len(warnings) == 0)
+}
+
+func TestValidateSysctls(t *testing.T) {
+	tests := []struct {
+		doc            string
+		sysctls        map[string]string
+		allowedSysctls []string
+		privileged     bool
+		expectErr      string
+	}{
+		{
+			doc:     "default namespaced sysctl is always allowed",
+			sysctls: map[string]string{"net.ipv4.ip_forward": "1"},
+		},
+		{
+			doc:            "allowlisted sysctl is allowed",
+			sysctls:        map[string]string{"fs.mqueue.msg_max": "10"},
+			allowedSysctls: []string{"fs.mqueue.msg_max"},
+		},
+		{
+			doc:       "non-namespaced sysctl in a non-privileged container is denied",
+			sysctls:   map[string]string{"fs.mqueue.msg_max": "10"},
+			expectErr: `sysctl "fs.mqueue.msg_max" is not allowed`,
+		},
+		{
+			doc:        "non-namespaced sysctl in a privileged container is allowed",
+			sysctls:    map[string]string{"fs.mqueue.msg_max": "10"},
+			privileged: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.doc, func(t *testing.T) {
+			err := validateSysctls(tc.sysctls, tc.allowedSysctls, tc.privileged)
+			if tc.expectErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectErr)
+			}
+		})
+	}
+}
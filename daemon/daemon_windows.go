@@ -171,7 +171,22 @@ func verifyPlatformContainerSettings(daemon *Daemon, daemonCfg *configStore, hos
 	if hostConfig == nil {
 		return nil, nil
 	}
-	return verifyPlatformContainerResources(&hostConfig.Resources, daemon.runAsHyperVContainer(hostConfig))
+	warnings, err := verifyPlatformContainerResources(&hostConfig.Resources, daemon.runAsHyperVContainer(hostConfig))
+	if err != nil {
+		return warnings, err
+	}
+
+	storageOpt, err := applyRootfsSizeQuota(hostConfig.StorageOpt, daemon.ImageService().StorageDriver(), daemonCfg.DefaultRootfsSize)
+	if err != nil {
+		return warnings, err
+	}
+	hostConfig.StorageOpt = storageOpt
+
+	if err := validateDeviceRequestCounts(context.TODO(), &daemonCfg.Config, hostConfig.DeviceRequests); err != nil {
+		return warnings, err
+	}
+
+	return warnings, nil
 }
 
 // verifyDaemonSettings performs validation of daemon config struct
@@ -51,6 +51,10 @@ func (daemon *Daemon) containerRm(cfg *config.Config, name string, opts *backend
 		return daemon.rmLink(cfg, ctr, name)
 	}
 
+	if err := daemon.checkRemovableDependents(ctr, opts); err != nil {
+		return err
+	}
+
 	err = daemon.cleanupContainer(ctr, *opts)
 	metrics.ContainerActions.WithValues("delete").UpdateSince(start)
 	if err != nil {
@@ -59,6 +63,39 @@ func (daemon *Daemon) containerRm(cfg *config.Config, name string, opts *backend
 	return nil
 }
 
+// checkRemovableDependents enforces the removal policy for a container that
+// has running dependents connected through --network=container:<ctr>. By
+// default, removal is refused with a clear error listing those dependents.
+// If opts.ForceDependents is set, they are stopped instead of blocking the
+// removal.
+func (daemon *Daemon) checkRemovableDependents(ctr *container.Container, opts *backend.ContainerRmConfig) error {
+	var running []*container.Container
+	for _, dep := range daemon.GetDependentsOf(ctr) {
+		if dep.State.IsRunning() {
+			running = append(running, dep)
+		}
+	}
+	if len(running) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(running))
+	for _, dep := range running {
+		names = append(names, strings.TrimPrefix(dep.Name, "/"))
+	}
+
+	if !opts.ForceDependents {
+		return errdefs.Conflict(fmt.Errorf("container %s has running dependents that share its network namespace: %s; stop them first, or retry with --force-dependents", strings.TrimPrefix(ctr.Name, "/"), strings.Join(names, ", ")))
+	}
+
+	for _, dep := range running {
+		if err := daemon.containerStop(context.TODO(), dep, backend.ContainerStopOptions{}); err != nil {
+			return fmt.Errorf("failed to stop dependent container %s: %w", strings.TrimPrefix(dep.Name, "/"), err)
+		}
+	}
+	return nil
+}
+
 func (daemon *Daemon) rmLink(cfg *config.Config, ctr *container.Container, name string) error {
 	if name[0] != '/' {
 		name = "/" + name
@@ -178,6 +215,7 @@ func (daemon *Daemon) cleanupContainer(ctr *container.Container, config backend.
 	}
 	ctr.State.SetRemoved()
 	metrics.StateCtr.Delete(ctr.ID)
+	metrics.ContainerRestarts.Delete(ctr.ID)
 
 	daemon.LogContainerEvent(ctr, events.ActionDestroy)
 	return nil
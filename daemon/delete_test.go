@@ -80,6 +80,80 @@ func TestContainerDelete(t *testing.T) {
 	}
 }
 
+// TestCheckRemovableDependents covers the removal policy for a container
+// that has a running dependent connected through --network=container:<id>:
+// by default the removal is refused while the dependent is running, and a
+// stopped dependent does not block it. The ForceDependents path is not
+// exercised here.
+func TestCheckRemovableDependents(t *testing.T) {
+	target := newContainerWithState(&container.State{})
+	target.ID = "target"
+	target.Name = "/target"
+	target.HostConfig = &containertypes.HostConfig{}
+
+	dependent := newContainerWithState(&container.State{Running: true})
+	dependent.ID = "dependent"
+	dependent.Name = "/dependent"
+	dependent.HostConfig = &containertypes.HostConfig{
+		NetworkMode: containertypes.NetworkMode("container:" + target.ID),
+	}
+
+	d, cleanup := newDaemonWithTmpRoot(t)
+	defer cleanup()
+	d.containers.Add(target.ID, target)
+	d.containers.Add(dependent.ID, dependent)
+
+	t.Run("refuses by default", func(t *testing.T) {
+		err := d.checkRemovableDependents(target, &backend.ContainerRmConfig{})
+		assert.Check(t, is.ErrorType(err, cerrdefs.IsConflict))
+		assert.Check(t, is.ErrorContains(err, "running dependents"))
+		assert.Check(t, is.ErrorContains(err, "dependent"))
+	})
+
+	t.Run("does not refuse a stopped dependent", func(t *testing.T) {
+		stopped := newContainerWithState(&container.State{})
+		stopped.ID = "stopped-dependent"
+		stopped.Name = "/stopped-dependent"
+		stopped.HostConfig = &containertypes.HostConfig{
+			NetworkMode: containertypes.NetworkMode("container:" + target.ID),
+		}
+		d2, cleanup2 := newDaemonWithTmpRoot(t)
+		defer cleanup2()
+		d2.containers.Add(target.ID, target)
+		d2.containers.Add(stopped.ID, stopped)
+
+		err := d2.checkRemovableDependents(target, &backend.ContainerRmConfig{})
+		assert.NilError(t, err)
+	})
+}
+
+// TestGetDependentsOf verifies the network-mode dependency lookup used to
+// decide whether a container is safe to remove.
+func TestGetDependentsOf(t *testing.T) {
+	target := newContainerWithState(&container.State{})
+	target.ID = "target"
+
+	dependent := newContainerWithState(&container.State{Running: true})
+	dependent.ID = "dependent"
+	dependent.HostConfig = &containertypes.HostConfig{
+		NetworkMode: containertypes.NetworkMode("container:" + target.ID),
+	}
+
+	unrelated := newContainerWithState(&container.State{Running: true})
+	unrelated.ID = "unrelated"
+	unrelated.HostConfig = &containertypes.HostConfig{}
+
+	d, cleanup := newDaemonWithTmpRoot(t)
+	defer cleanup()
+	d.containers.Add(target.ID, target)
+	d.containers.Add(dependent.ID, dependent)
+	d.containers.Add(unrelated.ID, unrelated)
+
+	dependents := d.GetDependentsOf(target)
+	assert.Check(t, is.Len(dependents, 1))
+	assert.Check(t, is.Equal(dependents[0].ID, dependent.ID))
+}
+
 func TestContainerDoubleDelete(t *testing.T) {
 	c := newContainerWithState(&container.State{})
 
@@ -3,6 +3,7 @@
 import (
 	"context"
 	"errors"
+	"fmt"
 	"slices"
 
 	"github.com/containerd/log"
@@ -82,3 +83,43 @@ func (daemon *Daemon) handleDevice(req container.DeviceRequest, spec *specs.Spec
 
 	return incompatibleDeviceRequest{req.Driver, req.Capabilities}
 }
+
+// validateDeviceRequestCounts checks device requests with a positive count
+// against the number of devices reported by the matching driver's
+// ListDevices, catching an over-request at container-create time rather than
+// at start. A negative count ("all") or an unset count (zero) is always
+// valid, since neither can exceed what's available. Requests whose driver
+// can't be resolved yet (no driver name and no registered driver matches its
+// capabilities) or that don't implement ListDevices are left for handleDevice
+// to validate at container start.
+func validateDeviceRequestCounts(ctx context.Context, cfg *config.Config, reqs []container.DeviceRequest) error {
+	for _, req := range reqs {
+		if req.Count <= 0 {
+			continue
+		}
+
+		var dd *deviceDriver
+		if req.Driver == "" {
+			for _, candidate := range deviceDrivers {
+				if candidate.capset.Match(req.Capabilities) != nil {
+					dd = candidate
+					break
+				}
+			}
+		} else {
+			dd = deviceDrivers[req.Driver]
+		}
+		if dd == nil || dd.ListDevices == nil {
+			continue
+		}
+
+		listing, err := dd.ListDevices(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to list available devices for driver %q: %w", req.Driver, err)
+		}
+		if available := len(listing.Devices); req.Count > available {
+			return fmt.Errorf("requested %d devices, but only %d are available", req.Count, available)
+		}
+	}
+	return nil
+}
@@ -1,8 +1,12 @@
 package daemon
 
 import (
+	"context"
 	"testing"
 
+	"github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/api/types/system"
+	"github.com/moby/moby/v2/daemon/config"
 	"gotest.tools/v3/assert"
 )
 
@@ -53,3 +57,37 @@ func TestGetFirstAvailableVendor(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateDeviceRequestCounts(t *testing.T) {
+	const driverName = "test-gpu"
+	stubDriver := &deviceDriver{
+		ListDevices: func(ctx context.Context, cfg *config.Config) (deviceListing, error) {
+			return deviceListing{Devices: []system.DeviceInfo{{ID: "0"}, {ID: "1"}}}, nil
+		},
+	}
+	deviceDrivers[driverName] = stubDriver
+	defer delete(deviceDrivers, driverName)
+
+	tests := []struct {
+		name        string
+		count       int
+		expectError string
+	}{
+		{name: "within limit", count: 2},
+		{name: "unset count", count: 0},
+		{name: "all devices", count: -1},
+		{name: "over-request", count: 3, expectError: "requested 3 devices, but only 2 are available"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reqs := []container.DeviceRequest{{Driver: driverName, Count: tt.count}}
+			err := validateDeviceRequestCounts(context.Background(), &config.Config{}, reqs)
+			if tt.expectError != "" {
+				assert.Error(t, err, tt.expectError)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
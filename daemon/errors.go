@@ -65,6 +65,35 @@ func (e nameConflictError) Error() string {
 
 func (nameConflictError) Conflict() {}
 
+// errAmbiguousLabel is returned by [Daemon.GetContainerByLabel] when more
+// than one container has the requested label key/value.
+type errAmbiguousLabel struct {
+	key, value string
+	ids        []string
+}
+
+func (e errAmbiguousLabel) Error() string {
+	return fmt.Sprintf("multiple containers found with label %s=%s: %s", e.key, e.value, strings.Join(e.ids, ", "))
+}
+
+func (errAmbiguousLabel) Conflict() {}
+
+// errDependencyCycle is returned by [Daemon.checkDependencyCycle] when a
+// container's dependency graph (network-mode, legacy link and volumes-from
+// edges) loops
+// back on itself, e.g. two containers using "--network=container:" to point
+// at each other. This represents corrupted or inconsistent on-disk state
+// rather than a normal API-input validation failure.
+type errDependencyCycle struct {
+	ids []string
+}
+
+func (e errDependencyCycle) Error() string {
+	return fmt.Sprintf("dependency cycle found: %s", strings.Join(e.ids, " -> "))
+}
+
+func (errDependencyCycle) InvalidParameter() {}
+
 type invalidIdentifier string
 
 func (e invalidIdentifier) Error() string {
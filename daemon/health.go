@@ -16,6 +16,34 @@
 	"github.com/moby/moby/v2/daemon/server/backend"
 )
 
+// HealthEvent describes a single container health-status transition, as
+// detected by the health monitor. Unlike the general events stream, it's
+// only emitted on an actual status change, and carries the output of the
+// check that triggered it.
+type HealthEvent struct {
+	ContainerID string
+	From        containertypes.HealthStatus
+	To          containertypes.HealthStatus
+	Output      string
+	Time        time.Time
+}
+
+// SubscribeHealthEvents returns a channel on which every future health
+// status transition, across all containers, is sent as it's detected. The
+// returned cancel function must be called once the caller is done reading,
+// to unsubscribe and avoid leaking the subscription.
+func (daemon *Daemon) SubscribeHealthEvents() (ch chan any, cancel func()) {
+	ch = daemon.healthEvents.Subscribe()
+	return ch, func() { daemon.healthEvents.Evict(ch) }
+}
+
+// publishHealthEvent broadcasts a health-status transition to subscribers
+// registered via SubscribeHealthEvents. Each subscriber has 100 milliseconds
+// to receive the event, or it's skipped.
+func (daemon *Daemon) publishHealthEvent(ev HealthEvent) {
+	daemon.healthEvents.Publish(ev)
+}
+
 const (
 	// Longest healthcheck probe output message to store. Longer messages will be truncated.
 	maxOutputLen = 4096
@@ -248,6 +276,13 @@ func handleProbeResult(d *Daemon, c *container.Container, result *containertypes
 	current := h.Status()
 	if oldStatus != current {
 		d.LogContainerEvent(c, events.Action(string(events.ActionHealthStatus)+": "+string(current)))
+		d.publishHealthEvent(HealthEvent{
+			ContainerID: c.ID,
+			From:        oldStatus,
+			To:          current,
+			Output:      result.Output,
+			Time:        result.End,
+		})
 	}
 }
 
@@ -10,6 +10,7 @@
 	eventtypes "github.com/moby/moby/api/types/events"
 	"github.com/moby/moby/v2/daemon/container"
 	"github.com/moby/moby/v2/daemon/events"
+	"github.com/moby/pubsub"
 )
 
 func reset(c *container.Container) {
@@ -155,6 +156,76 @@ func TestHealthStates(t *testing.T) {
 	}
 }
 
+// TestHealthEventsFlapping asserts that a dedicated HealthEvent is emitted
+// on the healthEvents subscription for every status transition of a flapping
+// healthcheck, and that unsubscribing stops further delivery.
+func TestHealthEventsFlapping(t *testing.T) {
+	c := &container.Container{
+		ID:   "container_id",
+		Name: "container_name",
+		Config: &containertypes.Config{
+			Image:       "image_name",
+			Healthcheck: &containertypes.HealthConfig{Retries: 1},
+		},
+	}
+
+	store, err := container.NewViewDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	daemon := &Daemon{
+		EventsService:     events.New(),
+		healthEvents:      pubsub.NewPublisher(100*time.Millisecond, 16),
+		containersReplica: store,
+	}
+	muteLogs(t)
+
+	reset(c)
+
+	ch, cancel := daemon.SubscribeHealthEvents()
+	defer cancel()
+
+	expect := func(from, to containertypes.HealthStatus) {
+		select {
+		case raw := <-ch:
+			ev := raw.(HealthEvent)
+			if ev.ContainerID != c.ID || ev.From != from || ev.To != to {
+				t.Errorf("expected transition %s->%s for %s, got %#v", from, to, c.ID, ev)
+			}
+		case <-time.After(1 * time.Second):
+			t.Errorf("expected transition %s->%s, but got nothing", from, to)
+		}
+	}
+
+	handleResult := func(startTime time.Time, exitCode int) {
+		handleProbeResult(daemon, c, &containertypes.HealthcheckResult{
+			Start:    startTime,
+			End:      startTime,
+			ExitCode: exitCode,
+			Output:   "probe output",
+		}, nil)
+	}
+
+	// starting -> unhealthy -> healthy -> unhealthy
+	handleResult(c.State.StartedAt.Add(1*time.Second), 1)
+	expect(containertypes.Starting, containertypes.Unhealthy)
+
+	handleResult(c.State.StartedAt.Add(2*time.Second), 0)
+	expect(containertypes.Unhealthy, containertypes.Healthy)
+
+	handleResult(c.State.StartedAt.Add(3*time.Second), 1)
+	expect(containertypes.Healthy, containertypes.Unhealthy)
+
+	cancel()
+	handleResult(c.State.StartedAt.Add(4*time.Second), 0)
+	select {
+	case raw := <-ch:
+		t.Errorf("expected no event after unsubscribing, got %#v", raw)
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
 func TestCmdProbeEmptyCommand(t *testing.T) {
 	c := &container.Container{
 		ID: "container_id",
@@ -130,21 +130,34 @@ func (daemon *Daemon) getInspectData(daemonCfg *config.Config, ctr *container.Co
 			FinishedAt: ctr.State.FinishedAt.Format(time.RFC3339Nano),
 			Health:     containerHealth,
 		},
-		Image:           ctr.ImageID.String(),
-		ResolvConfPath:  ctr.ResolvConfPath, // Only used on Linux.
-		HostnamePath:    ctr.HostnamePath,   // Only used on Linux.
-		HostsPath:       ctr.HostsPath,      // Only used on Linux.
-		LogPath:         ctr.LogPath,
-		Name:            ctr.Name,
-		RestartCount:    ctr.RestartCount,
-		Driver:          ctr.Driver,
-		Platform:        ctr.ImagePlatform.OS,
-		MountLabel:      ctr.MountLabel,      // Only used on Linux.
-		ProcessLabel:    ctr.ProcessLabel,    // Only used on Linux.
-		AppArmorProfile: ctr.AppArmorProfile, // Only used on Linux.
-		ExecIDs:         ctr.GetExecIDs(),
-		HostConfig:      &hostConfig,
-		Config:          ctr.Config,
+		Image:             ctr.ImageID.String(),
+		ResolvConfPath:    ctr.ResolvConfPath, // Only used on Linux.
+		HostnamePath:      ctr.HostnamePath,   // Only used on Linux.
+		HostsPath:         ctr.HostsPath,      // Only used on Linux.
+		LogPath:           ctr.LogPath,
+		Name:              ctr.Name,
+		RestartCount:      ctr.RestartCount,
+		LastRestartReason: ctr.LastRestartReason,
+		Driver:            ctr.Driver,
+		Platform:          ctr.ImagePlatform.OS,
+		MountLabel:        ctr.MountLabel,      // Only used on Linux.
+		ProcessLabel:      ctr.ProcessLabel,    // Only used on Linux.
+		AppArmorProfile:   ctr.AppArmorProfile, // Only used on Linux.
+		ExecIDs:           ctr.GetExecIDs(),
+		HostConfig:        &hostConfig,
+		Config:            ctr.Config,
+	}
+
+	if !ctr.LastRestartAt.IsZero() {
+		inspectResponse.LastRestartAt = ctr.LastRestartAt.Format(time.RFC3339Nano)
+	}
+
+	if messages, bytes, ok := ctr.LogDropStats(); ok {
+		inspectResponse.LogDropped = &containertypes.LogDropStats{Messages: messages, Bytes: bytes}
+	}
+
+	if effectiveCaps, err := EffectiveCapabilities(&hostConfig); err == nil {
+		inspectResponse.EffectiveCapabilities = effectiveCaps
 	}
 
 	// TODO(thaJeztah): do we need a deep copy here? Otherwise we could use maps.Clone (see https://github.com/moby/moby/commit/7917a36cc787ada58987320e67cc6d96858f3b55)
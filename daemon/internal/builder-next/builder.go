@@ -36,6 +36,7 @@
 	"github.com/moby/moby/v2/daemon/server/buildbackend"
 	"github.com/moby/moby/v2/errdefs"
 	"github.com/moby/sys/user"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/pkg/errors"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
@@ -45,6 +46,14 @@
 	"tags.cncf.io/container-device-interface/pkg/cdi"
 )
 
+// platformProvider is satisfied by both local worker implementations used by
+// the two controller backends (snapshotter and graphdriver-based), letting
+// Builder report which platforms it can build for without depending on
+// either concrete worker type.
+type platformProvider interface {
+	Platforms(noCache bool) []ocispec.Platform
+}
+
 type errMultipleFilterValues struct{}
 
 func (errMultipleFilterValues) Error() string { return "filters expect only one value" }
@@ -107,12 +116,14 @@ type Opt struct {
 // Builder can build using BuildKit backend
 type Builder struct {
 	controller     *control.Controller
+	worker         platformProvider
 	dnsconfig      config.DNSConfig
 	reqBodyHandler *reqBodyHandler
 	diskUsage      singleflight.Group[buildbackend.DiskUsageOptions, *buildbackend.DiskUsage]
 
 	mu             sync.Mutex
 	jobs           map[string]*buildJob
+	sessions       map[string]map[string]struct{} // sessionID -> set of buildID
 	useSnapshotter bool
 }
 
@@ -120,20 +131,35 @@ type Builder struct {
 func New(ctx context.Context, opt Opt) (*Builder, error) {
 	reqHandler := newReqBodyHandler(tracing.DefaultTransport)
 
-	c, err := newController(ctx, reqHandler, opt)
+	var w platformProvider
+	c, err := newController(ctx, reqHandler, opt, &w)
 	if err != nil {
 		return nil, err
 	}
 	b := &Builder{
 		controller:     c,
+		worker:         w,
 		dnsconfig:      opt.DNSConfig,
 		reqBodyHandler: reqHandler,
 		jobs:           map[string]*buildJob{},
+		sessions:       map[string]map[string]struct{}{},
 		useSnapshotter: opt.UseSnapshotter,
 	}
 	return b, nil
 }
 
+// SupportedPlatforms returns the platforms this builder can build for: the
+// daemon's native platform, plus any platform it can emulate through a
+// registered binfmt handler (e.g. via QEMU). When noCache is true, the
+// binfmt handler registration is re-checked instead of using a cached
+// result.
+func (b *Builder) SupportedPlatforms(noCache bool) []ocispec.Platform {
+	if b.worker == nil {
+		return nil
+	}
+	return b.worker.Platforms(noCache)
+}
+
 func (b *Builder) Close() error {
 	return b.controller.Close()
 }
@@ -153,6 +179,23 @@ func (b *Builder) Cancel(ctx context.Context, id string) error {
 	return nil
 }
 
+// CancelSession cancels every in-flight build associated with sessionID and
+// returns how many builds were cancelled. It's used to clean up after a
+// client session dies without explicitly cancelling its builds one by one.
+func (b *Builder) CancelSession(ctx context.Context, sessionID string) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var cancelled int
+	for id := range b.sessions[sessionID] {
+		if j, ok := b.jobs[id]; ok && j.cancel != nil {
+			j.cancel()
+			cancelled++
+		}
+	}
+	return cancelled, nil
+}
+
 // DiskUsage returns a report about space used by build cache
 func (b *Builder) DiskUsage(ctx context.Context, options buildbackend.DiskUsageOptions) (*buildbackend.DiskUsage, error) {
 	res, _, err := b.diskUsage.Do(ctx, options, func(ctx context.Context) (*buildbackend.DiskUsage, error) {
@@ -279,6 +322,13 @@ func (b *Builder) Build(ctx context.Context, opt buildbackend.BuildConfig) (*bui
 		var cancel func()
 		ctx, cancel = context.WithCancel(ctx)
 		j.cancel = cancel
+		if sessionID := opt.Options.SessionID; sessionID != "" {
+			j.sessionID = sessionID
+			if b.sessions[sessionID] == nil {
+				b.sessions[sessionID] = map[string]struct{}{}
+			}
+			b.sessions[sessionID][buildID] = struct{}{}
+		}
 		b.mu.Unlock()
 
 		if upload {
@@ -302,6 +352,12 @@ func (b *Builder) Build(ctx context.Context, opt buildbackend.BuildConfig) (*bui
 		defer func() {
 			b.mu.Lock()
 			delete(b.jobs, buildID)
+			if j.sessionID != "" {
+				delete(b.sessions[j.sessionID], buildID)
+				if len(b.sessions[j.sessionID]) == 0 {
+					delete(b.sessions, j.sessionID)
+				}
+			}
 			b.mu.Unlock()
 		}()
 	}
@@ -388,6 +444,17 @@ func (b *Builder) Build(ctx context.Context, opt buildbackend.BuildConfig) (*bui
 		frontendAttrs["ulimit"] = ulimits
 	}
 
+	for _, a := range opt.Options.Attestations {
+		switch a {
+		case "sbom":
+			frontendAttrs["attest:sbom"] = ""
+		case "provenance":
+			frontendAttrs["attest:provenance"] = "mode=min"
+		default:
+			return nil, errdefs.InvalidParameter(errors.Errorf("unsupported attestation type %q", a))
+		}
+	}
+
 	exporterName := ""
 	exporterAttrs := map[string]string{}
 	if len(opt.Options.Outputs) == 0 {
@@ -411,6 +478,22 @@ func (b *Builder) Build(ctx context.Context, opt buildbackend.BuildConfig) (*bui
 		exporterAttrs["name"] = strings.Join(nameAttr, ",")
 	}
 
+	if exporterName == client.ExporterImage || exporterName == exporter.Moby {
+		if exporterAttrs == nil {
+			exporterAttrs = make(map[string]string)
+		}
+		if opt.Options.Compression != "" {
+			if _, ok := exporterAttrs["compression"]; !ok {
+				exporterAttrs["compression"] = opt.Options.Compression
+			}
+		}
+		if opt.Options.CompressionLevel != nil {
+			if _, ok := exporterAttrs["compression-level"]; !ok {
+				exporterAttrs["compression-level"] = strconv.FormatInt(*opt.Options.CompressionLevel, 10)
+			}
+		}
+	}
+
 	cache := &controlapi.CacheOptions{}
 	if inlineCache := opt.Options.BuildArgs["BUILDKIT_INLINE_CACHE"]; inlineCache != nil {
 		if b, err := strconv.ParseBool(*inlineCache); err == nil && b {
@@ -580,8 +663,9 @@ func (w *wrapRC) wait() error {
 }
 
 type buildJob struct {
-	cancel func()
-	waitCh chan func(io.ReadCloser) error
+	cancel    func()
+	waitCh    chan func(io.ReadCloser) error
+	sessionID string
 }
 
 func newBuildJob() *buildJob {
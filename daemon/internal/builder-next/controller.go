@@ -61,11 +61,14 @@
 	"go.opentelemetry.io/otel/sdk/trace"
 )
 
-func newController(ctx context.Context, rt http.RoundTripper, opt Opt) (*control.Controller, error) {
+// newController builds the buildkit controller. If wOut is non-nil, it is
+// set to the underlying local worker once constructed, so the caller can
+// query it for supported platforms.
+func newController(ctx context.Context, rt http.RoundTripper, opt Opt, wOut *platformProvider) (*control.Controller, error) {
 	if opt.UseSnapshotter {
-		return newSnapshotterController(ctx, rt, opt)
+		return newSnapshotterController(ctx, rt, opt, wOut)
 	}
-	return newGraphDriverController(ctx, rt, opt)
+	return newGraphDriverController(ctx, rt, opt, wOut)
 }
 
 func getTraceExporter(ctx context.Context) trace.SpanExporter {
@@ -82,7 +85,7 @@ func getTraceExporter(ctx context.Context) trace.SpanExporter {
 	return tc
 }
 
-func newSnapshotterController(ctx context.Context, rt http.RoundTripper, opt Opt) (_ *control.Controller, retErr error) {
+func newSnapshotterController(ctx context.Context, rt http.RoundTripper, opt Opt, wOut *platformProvider) (_ *control.Controller, retErr error) {
 	if err := os.MkdirAll(opt.Root, 0o711); err != nil {
 		return nil, err
 	}
@@ -190,6 +193,9 @@ func newSnapshotterController(ctx context.Context, rt http.RoundTripper, opt Opt
 	if err != nil {
 		return nil, err
 	}
+	if wOut != nil {
+		*wOut = w
+	}
 
 	wc := &worker.Controller{}
 
@@ -252,7 +258,7 @@ func openHistoryDB(root string, fn string, cfg *config.BuilderHistoryConfig) (*b
 	return db, conf, nil
 }
 
-func newGraphDriverController(ctx context.Context, rt http.RoundTripper, opt Opt) (_ *control.Controller, retErr error) {
+func newGraphDriverController(ctx context.Context, rt http.RoundTripper, opt Opt, wOut *platformProvider) (_ *control.Controller, retErr error) {
 	if err := os.MkdirAll(opt.Root, 0o711); err != nil {
 		return nil, err
 	}
@@ -476,6 +482,9 @@ func newGraphDriverController(ctx context.Context, rt http.RoundTripper, opt Opt
 	if err != nil {
 		return nil, err
 	}
+	if wOut != nil {
+		*wOut = w
+	}
 	wc.Add(w)
 
 	gwf, err := gateway.NewGatewayFrontend(wc.Infos(), nil)
@@ -700,7 +700,7 @@ func (p *process) Pid() uint32 {
 	return uint32(hcsProcess.Pid())
 }
 
-func (p *process) Kill(_ context.Context, signal syscall.Signal) error {
+func (p *process) Kill(_ context.Context, signal syscall.Signal, _ ...containerd.KillOpts) error {
 	p.mu.Lock()
 	hcsProcess := p.hcsProcess
 	p.mu.Unlock()
@@ -713,7 +713,7 @@ func (p *process) Kill(_ context.Context, signal syscall.Signal) error {
 // Kill handles `docker stop` on Windows. While Linux has support for
 // the full range of signals, signals aren't really implemented on Windows.
 // We fake supporting regular stop and -9 to force kill.
-func (t *task) Kill(_ context.Context, signal syscall.Signal) error {
+func (t *task) Kill(_ context.Context, signal syscall.Signal, _ ...containerd.KillOpts) error {
 	hcsContainer, err := t.getHCSContainer()
 	if err != nil {
 		return err
@@ -310,12 +310,12 @@ func (t *task) Exec(ctx context.Context, execID string, spec *specs.Process, wit
 	return process{p}, nil
 }
 
-func (t *task) Kill(ctx context.Context, signal syscall.Signal) error {
-	return t.Task.Kill(ctx, signal)
+func (t *task) Kill(ctx context.Context, signal syscall.Signal, opts ...containerd.KillOpts) error {
+	return t.Task.Kill(ctx, signal, opts...)
 }
 
-func (p process) Kill(ctx context.Context, signal syscall.Signal) error {
-	return p.Process.Kill(ctx, signal)
+func (p process) Kill(ctx context.Context, signal syscall.Signal, opts ...containerd.KillOpts) error {
+	return p.Process.Kill(ctx, signal, opts...)
 }
 
 func (t *task) Pause(ctx context.Context) error {
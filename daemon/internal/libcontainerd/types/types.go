@@ -45,8 +45,10 @@ type Backend interface {
 type Process interface {
 	// Pid is the system specific process id
 	Pid() uint32
-	// Kill sends the provided signal to the process
-	Kill(ctx context.Context, signal syscall.Signal) error
+	// Kill sends the provided signal to the process. Passing containerd.WithKillAll
+	// broadcasts the signal to every process in the task instead of just this one,
+	// which is useful when the process does not forward signals to its children.
+	Kill(ctx context.Context, signal syscall.Signal, opts ...containerd.KillOpts) error
 	// Resize changes the width and height of the process's terminal
 	Resize(ctx context.Context, width, height uint32) error
 	// Delete removes the process and any resources allocated returning the exit status
@@ -50,8 +50,49 @@
 
 	// EventSubscribers tracks the number of current subscribers to events
 	EventSubscribers = metricsNS.NewGauge("events_subscribers", "The number of current subscribers to events", gometrics.Total)
+
+	// AnonymousVolumesUsage tracks the total disk usage, in bytes, of all anonymous volumes
+	AnonymousVolumesUsage = metricsNS.NewGauge("anonymous_volumes_usage", "The total disk usage of anonymous volumes", gometrics.Bytes)
+
+	// ContainerStopsInFlight tracks the number of container stops the daemon
+	// is currently processing, including those queued behind
+	// max-concurrent-stops.
+	ContainerStopsInFlight = metricsNS.NewGauge("container_stops_in_flight", "The number of container stops currently being processed or queued", gometrics.Total)
+
+	// FeatureFlags reports the daemon's effective feature flags, one gauge
+	// per feature set to 1 if enabled or 0 if disabled.
+	FeatureFlags = metricsNS.NewLabeledGauge("features", "The effective feature flags of the daemon", gometrics.Unit("enabled"), "feature")
+
+	// StatsCollectorGoroutines tracks the number of active stats collection
+	// goroutines, i.e. the number of clients currently streaming container
+	// stats. It is incremented when a client subscribes and decremented
+	// when it disconnects and the collector tears down, so a stuck or
+	// leaked subscription shows up as a gauge that never returns to zero.
+	StatsCollectorGoroutines = metricsNS.NewGauge("stats_collector_goroutines", "The number of active stats collection goroutines", gometrics.Total)
+
+	// ContainerRestarts tracks, per container, the number of times it has
+	// been automatically restarted by its restart policy. It's updated
+	// alongside [container.Container.RestartCount], and removed once the
+	// container is removed, so that a container stuck in a restart loop
+	// stands out without having to inspect every container individually.
+	ContainerRestarts = newRestartsCounter(metricsNS, metricsNS.NewDesc("container_restarts", "The number of times a container has been restarted by its restart policy", gometrics.Total, "container_id"))
 )
 
+// SetFeatureFlags updates the FeatureFlags metric to reflect features. It is
+// called once at daemon startup, and again whenever the daemon's feature
+// flags change on config reload. It only sets the features present in
+// features: a feature reported by an earlier call but absent from features
+// keeps its previously reported value.
+func SetFeatureFlags(features map[string]bool) {
+	for name, enabled := range features {
+		v := 0.0
+		if enabled {
+			v = 1.0
+		}
+		FeatureFlags.WithValues(name).Set(v)
+	}
+}
+
 func init() {
 	for _, a := range []string{
 		"start",
@@ -133,3 +174,51 @@ func (ctr *StateCounter) Collect(ch chan<- prometheus.Metric) {
 	ch <- prometheus.MustNewConstMetric(ctr.desc, prometheus.GaugeValue, float64(paused), "paused")
 	ch <- prometheus.MustNewConstMetric(ctr.desc, prometheus.GaugeValue, float64(stopped), "stopped")
 }
+
+// RestartsCounter tracks, per container, the number of times it has been
+// restarted by its restart policy.
+type RestartsCounter struct {
+	mu     sync.RWMutex
+	counts map[string]int
+	desc   *prometheus.Desc
+}
+
+func newRestartsCounter(ns *gometrics.Namespace, desc *prometheus.Desc) *RestartsCounter {
+	c := &RestartsCounter{
+		counts: make(map[string]int),
+		desc:   desc,
+	}
+	ns.Add(c)
+	return c
+}
+
+// Set records the current restart count for the given container.
+func (ctr *RestartsCounter) Set(id string, count int) {
+	ctr.mu.Lock()
+	defer ctr.mu.Unlock()
+
+	ctr.counts[id] = count
+}
+
+// Delete removes a container's restart count.
+func (ctr *RestartsCounter) Delete(id string) {
+	ctr.mu.Lock()
+	defer ctr.mu.Unlock()
+
+	delete(ctr.counts, id)
+}
+
+// Describe implements prometheus.Collector
+func (ctr *RestartsCounter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- ctr.desc
+}
+
+// Collect implements prometheus.Collector
+func (ctr *RestartsCounter) Collect(ch chan<- prometheus.Metric) {
+	ctr.mu.RLock()
+	defer ctr.mu.RUnlock()
+
+	for id, count := range ctr.counts {
+		ch <- prometheus.MustNewConstMetric(ctr.desc, prometheus.GaugeValue, float64(count), id)
+	}
+}
@@ -22,6 +22,59 @@ func TestRestartManagerTimeout(t *testing.T) {
 	}
 }
 
+func TestRestartManagerExitCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   container.RestartPolicy
+		exitCode uint32
+		want     bool
+	}{
+		{
+			name:     "ExitCodes restarts for a listed code",
+			policy:   container.RestartPolicy{Name: container.RestartPolicyOnFailure, ExitCodes: []int{42}},
+			exitCode: 42,
+			want:     true,
+		},
+		{
+			name:     "ExitCodes does not restart for an unlisted code",
+			policy:   container.RestartPolicy{Name: container.RestartPolicyOnFailure, ExitCodes: []int{42}},
+			exitCode: 1,
+			want:     false,
+		},
+		{
+			name:     "ExcludeExitCodes does not restart for a listed code",
+			policy:   container.RestartPolicy{Name: container.RestartPolicyOnFailure, ExcludeExitCodes: []int{1}},
+			exitCode: 1,
+			want:     false,
+		},
+		{
+			name:     "ExcludeExitCodes restarts for an unlisted code",
+			policy:   container.RestartPolicy{Name: container.RestartPolicyOnFailure, ExcludeExitCodes: []int{1}},
+			exitCode: 42,
+			want:     true,
+		},
+		{
+			name:     "no restart on success regardless of ExitCodes",
+			policy:   container.RestartPolicy{Name: container.RestartPolicyOnFailure, ExitCodes: []int{0}},
+			exitCode: 0,
+			want:     false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rm := New(tc.policy, 0)
+			should, _, err := rm.ShouldRestart(tc.exitCode, false, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if should != tc.want {
+				t.Fatalf("ShouldRestart(%d) = %v, want %v", tc.exitCode, should, tc.want)
+			}
+		})
+	}
+}
+
 func TestRestartManagerTimeoutReset(t *testing.T) {
 	rm := New(container.RestartPolicy{Name: "always"}, 0)
 	rm.timeout = 5 * time.Second
@@ -8,6 +8,7 @@
 	"syscall"
 	"time"
 
+	containerd "github.com/containerd/containerd/v2/client"
 	cerrdefs "github.com/containerd/errdefs"
 	"github.com/containerd/log"
 	containertypes "github.com/moby/moby/api/types/container"
@@ -108,7 +109,14 @@ func (daemon *Daemon) killWithSignal(container *containerpkg.Container, stopSign
 		return nil
 	}
 
-	if err := task.Kill(context.Background(), stopSignal); err != nil {
+	var killOpts []containerd.KillOpts
+	if container.HostConfig.ForwardStopSignal {
+		// PID 1 in the container may not forward signals to its children, so
+		// broadcast the signal to every process in the container instead of
+		// relying on PID 1 to do it.
+		killOpts = append(killOpts, containerd.WithKillAll)
+	}
+	if err := task.Kill(context.Background(), stopSignal, killOpts...); err != nil {
 		if cerrdefs.IsNotFound(err) {
 			unpause = false
 			log.G(context.TODO()).WithFields(log.Fields{
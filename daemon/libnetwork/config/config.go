@@ -43,6 +43,10 @@ type Config struct {
 	Rootless               bool
 	EnableUserlandProxy    bool
 	UserlandProxyPath      string
+	// EmbeddedDNSAddress is the IP address the embedded DNS resolver listens
+	// on inside container network namespaces. If empty, the driver-specific
+	// default is used (e.g. 127.0.0.11 for the bridge driver).
+	EmbeddedDNSAddress string
 }
 
 // New creates a new Config and initializes it with the given Options.
@@ -156,3 +160,12 @@ func OptionUserlandProxy(enabled bool, proxyPath string) Option {
 		c.UserlandProxyPath = proxyPath
 	}
 }
+
+// OptionEmbeddedDNSAddress returns an option setter for the listen address of
+// the embedded DNS resolver offered to containers. An empty address leaves
+// the driver-specific default in place.
+func OptionEmbeddedDNSAddress(address string) Option {
+	return func(c *Config) {
+		c.EmbeddedDNSAddress = address
+	}
+}
@@ -73,8 +73,12 @@ type Configuration struct {
 	// EnableProxy indicates whether the userland proxy should be used for NAT
 	// port-mappings that can't be fulfilled with firewall rules alone. This
 	// must not be true if ProxyPath is empty.
-	EnableProxy        bool
-	ProxyPath          string
+	EnableProxy bool
+	ProxyPath   string
+	// ProxyBackend selects the userland-proxy backend, by the name it was
+	// registered with via portmapper.RegisterProxyBackend. Empty, or a name
+	// that isn't registered, falls back to portmapper.BinaryProxyBackend.
+	ProxyBackend       string
 	AllowDirectRouting bool
 	AcceptFwMark       string
 }
@@ -1047,7 +1051,7 @@ func setHairpinMode(nlh nlwrap.Handle, link netlink.Link, enable bool) error {
 	return nil
 }
 
-func (d *driver) CreateEndpoint(ctx context.Context, nid, eid string, ifInfo driverapi.InterfaceInfo, _ map[string]any) error {
+func (d *driver) CreateEndpoint(ctx context.Context, nid, eid string, ifInfo driverapi.InterfaceInfo, epOptions map[string]any) error {
 	if ifInfo == nil {
 		return errors.New("invalid interface info passed")
 	}
@@ -1166,6 +1170,17 @@ func (d *driver) CreateEndpoint(ctx context.Context, nid, eid string, ifInfo dri
 		}
 	}
 
+	// A per-endpoint MTU override (com.docker.network.endpoint.mtu) applies
+	// only to the container-facing side of the pipe; the daemon has already
+	// validated it doesn't exceed the network's own MTU.
+	if epMTU, ok := epOptions[netlabel.EndpointMTU].(string); ok {
+		if mtu, err := strconv.Atoi(epMTU); err == nil && mtu > 0 {
+			if err := nlhSb.LinkSetMTU(sbox, mtu); err != nil {
+				return types.InternalErrorf("failed to set endpoint MTU on sandbox interface %s: %v", containerIfName, err)
+			}
+		}
+	}
+
 	// Attach host side pipe interface into the bridge
 	if err = addToBridge(ctx, d.nlh, hostIfName, config.BridgeName); err != nil {
 		return fmt.Errorf("adding interface %s to bridge %s failed: %v", hostIfName, config.BridgeName, err)
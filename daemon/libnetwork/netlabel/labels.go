@@ -33,6 +33,10 @@
 	// where the interface name is represented by the string "IFNAME".
 	EndpointSysctls = Prefix + ".endpoint.sysctls"
 
+	// EndpointMTU overrides the network's MTU for a single endpoint's
+	// interface. It must not exceed the network's own MTU.
+	EndpointMTU = Prefix + ".endpoint.mtu"
+
 	// Ifname can be used to set the interface name used inside the container. It takes precedence over ContainerIfacePrefix.
 	Ifname = Prefix + ".endpoint.ifname"
 
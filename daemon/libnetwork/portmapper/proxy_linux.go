@@ -5,6 +5,7 @@
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"os"
 	"os/exec"
 	"runtime"
@@ -18,16 +19,148 @@
 	"github.com/moby/moby/v2/daemon/libnetwork/types"
 )
 
-// StartProxy starts the proxy process at proxyPath.
+// ProxyBackend is a running userland proxy for a single port mapping. Start
+// begins proxying traffic for the mapping, and Stop tears it down, waiting
+// for any resources it holds (such as a subprocess) to be released.
+type ProxyBackend interface {
+	Start() error
+	Stop() error
+}
+
+// ProxyBackendFactory creates a [ProxyBackend] for a single port mapping.
+// It's called with the same information that would otherwise be passed to
+// the userland-proxy binary on the command line.
+type ProxyBackendFactory func(proto string, hostIP net.IP, hostPort int, containerIP net.IP, containerPort int) (ProxyBackend, error)
+
+// BinaryProxyBackend is the name of the built-in userland-proxy backend that
+// shells out to a separate binary (normally docker-proxy) for every port
+// mapping. It's always available, and used as a fallback for a requested
+// backend name that isn't registered with [RegisterProxyBackend].
+const BinaryProxyBackend = "binary"
+
+var proxyBackends = map[string]ProxyBackendFactory{}
+
+// RegisterProxyBackend registers a userland-proxy backend under name, so it
+// can be selected through the daemon's userland-proxy-backend setting. This
+// lets an in-process proxy that multiplexes many mappings in a shared
+// goroutine pool be plugged in instead of the default, process-per-mapping
+// backend. Registering under an already-used name replaces the previous
+// registration. RegisterProxyBackend is not safe to call concurrently with
+// itself or with StartProxy.
+func RegisterProxyBackend(name string, factory ProxyBackendFactory) {
+	proxyBackends[name] = factory
+}
+
+// StartProxy starts a userland proxy for pb, using the backend registered as
+// backendName. If backendName is empty, or isn't registered, it falls back
+// to [BinaryProxyBackend].
+//
+// The binary backend is special-cased here rather than going through the
+// registry: unlike a pluggable [ProxyBackendFactory], it needs the path to
+// the proxy binary, and, when listenSock is not nil, a pre-bound socket to
+// hand to that binary for it to listen on.
+func StartProxy(pb types.PortBinding, backendName, proxyPath string, listenSock *os.File) (stop func() error, retErr error) {
+	if backendName == "" || backendName == BinaryProxyBackend {
+		return startBinaryProxy(pb, proxyPath, listenSock)
+	}
+	factory, ok := proxyBackends[backendName]
+	if !ok {
+		log.G(context.Background()).WithField("backend", backendName).Warn("Unknown userland-proxy backend, falling back to binary")
+		return startBinaryProxy(pb, proxyPath, listenSock)
+	}
+
+	backend, err := factory(pb.Proto.String(), pb.HostIP, int(pb.HostPort), pb.IP, int(pb.Port))
+	if err != nil {
+		return nil, err
+	}
+	if err := backend.Start(); err != nil {
+		return nil, err
+	}
+	return backend.Stop, nil
+}
+
+// readProxyStartupStatus reads the 2-byte startup status written by the proxy
+// process to r ("0\n" on success, or nothing/anything else on failure,
+// followed by an error message). usedListenFd should be true if the proxy
+// was passed a pre-bound listening socket on the command line, which affects
+// how an empty response is interpreted.
+func readProxyStartupStatus(r io.Reader, usedListenFd bool) error {
+	buf := make([]byte, 2)
+	n, err := io.ReadFull(r, buf)
+	if err == nil && string(buf[:n]) == "0\n" {
+		return nil
+	}
+
+	// Drain whatever's left on the pipe so it can be included in the error
+	// message, regardless of whether the short read above was itself an
+	// error.
+	errStr, readErr := io.ReadAll(r)
+	if readErr != nil {
+		return fmt.Errorf("error reading exit status from userland proxy: %v", readErr)
+	}
+	// If the user has an old docker-proxy in their PATH, and we passed
+	// "-use-listen-fd" on the command line, it exits with no response on
+	// the pipe.
+	if usedListenFd && n == 0 && len(errStr) == 0 {
+		return errors.New("failed to start docker-proxy, check that the current version is in your $PATH")
+	}
+	if len(errStr) == 0 {
+		return fmt.Errorf("error reading exit status from userland proxy: %w", err)
+	}
+	return fmt.Errorf("error starting userland proxy: %s", errStr)
+}
+
+// newProxyCommandArgs builds the docker-proxy command-line arguments for pb,
+// validating and normalizing its addresses first. It rejects a nil HostIP or
+// IP (container IP), and passes both addresses through normalizeProxyIP, so
+// that an IPv4-mapped IPv6 address (including the unspecified one) is passed
+// to the proxy in its 4-byte IPv4 form.
+func newProxyCommandArgs(pb types.PortBinding) ([]string, error) {
+	if pb.HostIP == nil {
+		return nil, fmt.Errorf("no host IP provided for userland-proxy %s binding (host port %d, container port %d)", pb.Proto, pb.HostPort, pb.Port)
+	}
+	if pb.IP == nil {
+		return nil, fmt.Errorf("no container IP provided for userland-proxy %s binding (host port %d, container port %d)", pb.Proto, pb.HostPort, pb.Port)
+	}
+	return []string{
+		"-proto", pb.Proto.String(),
+		"-host-ip", normalizeProxyIP(pb.HostIP).String(),
+		"-host-port", strconv.FormatUint(uint64(pb.HostPort), 10),
+		"-container-ip", normalizeProxyIP(pb.IP).String(),
+		"-container-port", strconv.FormatUint(uint64(pb.Port), 10),
+	}, nil
+}
+
+// normalizeProxyIP returns ip in its canonical form: an IPv4-mapped IPv6
+// address, including ::ffff:0.0.0.0, is unwrapped to its 4-byte form (so
+// the IPv4-mapped unspecified address becomes 0.0.0.0), and the IPv6
+// unspecified address is returned as net.IPv6unspecified, so that
+// dual-stack bindings behave predictably regardless of which form the
+// caller supplied.
+func normalizeProxyIP(ip net.IP) net.IP {
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4
+	}
+	if ip.Equal(net.IPv6unspecified) {
+		return net.IPv6unspecified
+	}
+	return ip
+}
+
+// startBinaryProxy starts the proxy process at proxyPath.
 // If listenSock is not nil, it must be a bound socket that can be passed to
 // the proxy process for it to listen on.
-func StartProxy(pb types.PortBinding,
+func startBinaryProxy(pb types.PortBinding,
 	proxyPath string,
 	listenSock *os.File,
 ) (stop func() error, retErr error) {
 	if proxyPath == "" {
 		return nil, errors.New("no path provided for userland-proxy binary")
 	}
+	cmdArgs, err := newProxyCommandArgs(pb)
+	if err != nil {
+		return nil, err
+	}
 	r, w, err := os.Pipe()
 	if err != nil {
 		return nil, fmt.Errorf("proxy unable to open os.Pipe %s", err)
@@ -40,15 +173,8 @@ func StartProxy(pb types.PortBinding,
 	}()
 
 	cmd := &exec.Cmd{
-		Path: proxyPath,
-		Args: []string{
-			proxyPath,
-			"-proto", pb.Proto.String(),
-			"-host-ip", pb.HostIP.String(),
-			"-host-port", strconv.FormatUint(uint64(pb.HostPort), 10),
-			"-container-ip", pb.IP.String(),
-			"-container-port", strconv.FormatUint(uint64(pb.Port), 10),
-		},
+		Path:       proxyPath,
+		Args:       append([]string{proxyPath}, cmdArgs...),
 		ExtraFiles: []*os.File{w},
 		SysProcAttr: &syscall.SysProcAttr{
 			Pdeathsig: syscall.SIGTERM, // send a sigterm to the proxy if the creating thread in the daemon process dies (https://go.dev/issue/27505)
@@ -113,25 +239,7 @@ func StartProxy(pb types.PortBinding,
 
 	errchan := make(chan error, 1)
 	go func() {
-		buf := make([]byte, 2)
-		r.Read(buf)
-
-		if string(buf) != "0\n" {
-			errStr, err := io.ReadAll(r)
-			if err != nil {
-				errchan <- fmt.Errorf("error reading exit status from userland proxy: %v", err)
-				return
-			}
-			// If the user has an old docker-proxy in their PATH, and we passed "-use-listen-fd"
-			// on the command line, it exits with no response on the pipe.
-			if listenSock != nil && buf[0] == 0 && len(errStr) == 0 {
-				errchan <- errors.New("failed to start docker-proxy, check that the current version is in your $PATH")
-				return
-			}
-			errchan <- fmt.Errorf("error starting userland proxy: %s", errStr)
-			return
-		}
-		errchan <- nil
+		errchan <- readProxyStartupStatus(r, listenSock != nil)
 	}()
 
 	select {
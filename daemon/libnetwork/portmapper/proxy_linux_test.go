@@ -0,0 +1,168 @@
+package portmapper
+
+import (
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/moby/moby/v2/daemon/libnetwork/types"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+type fakeProxyBackend struct {
+	started, stopped bool
+	startErr         error
+}
+
+func (f *fakeProxyBackend) Start() error {
+	f.started = true
+	return f.startErr
+}
+
+func (f *fakeProxyBackend) Stop() error {
+	f.stopped = true
+	return nil
+}
+
+func TestStartProxyRegisteredBackend(t *testing.T) {
+	backend := &fakeProxyBackend{}
+	var gotProto string
+	var gotHostPort, gotContainerPort int
+	RegisterProxyBackend("test-backend", func(proto string, hostIP net.IP, hostPort int, containerIP net.IP, containerPort int) (ProxyBackend, error) {
+		gotProto = proto
+		gotHostPort = hostPort
+		gotContainerPort = containerPort
+		return backend, nil
+	})
+	defer delete(proxyBackends, "test-backend")
+
+	pb := types.PortBinding{
+		Proto:    types.TCP,
+		IP:       net.ParseIP("172.17.0.2"),
+		Port:     80,
+		HostIP:   net.ParseIP("0.0.0.0"),
+		HostPort: 8080,
+	}
+	stop, err := StartProxy(pb, "test-backend", "", nil)
+	assert.NilError(t, err)
+	assert.Check(t, backend.started)
+	assert.Check(t, is.Equal(gotProto, "tcp"))
+	assert.Check(t, is.Equal(gotHostPort, 8080))
+	assert.Check(t, is.Equal(gotContainerPort, 80))
+
+	assert.NilError(t, stop())
+	assert.Check(t, backend.stopped)
+}
+
+func TestStartProxyUnknownBackendFallsBackToBinary(t *testing.T) {
+	pb := types.PortBinding{Proto: types.TCP}
+	_, err := StartProxy(pb, "does-not-exist", "", nil)
+	// The binary backend requires a proxyPath, so falling back to it (rather
+	// than an unregistered "does-not-exist" backend) is what produces this
+	// specific error.
+	assert.ErrorContains(t, err, "no path provided for userland-proxy binary")
+}
+
+// TestReadProxyStartupStatusPartialRead writes the "0\n" success status one
+// byte at a time, with a delay between bytes, to make sure a short first
+// read of the pipe isn't mistaken for a failed startup.
+func TestReadProxyStartupStatusPartialRead(t *testing.T) {
+	r, w := io.Pipe()
+	go func() {
+		_, _ = w.Write([]byte("0"))
+		time.Sleep(10 * time.Millisecond)
+		_, _ = w.Write([]byte("\n"))
+		w.Close()
+	}()
+
+	assert.NilError(t, readProxyStartupStatus(r, false))
+}
+
+func TestReadProxyStartupStatusError(t *testing.T) {
+	r, w := io.Pipe()
+	go func() {
+		_, _ = w.Write([]byte("1\n"))
+		_, _ = w.Write([]byte("listen tcp :80: bind: address already in use"))
+		w.Close()
+	}()
+
+	err := readProxyStartupStatus(r, false)
+	assert.ErrorContains(t, err, "address already in use")
+}
+
+func TestReadProxyStartupStatusEmptyWithListenFd(t *testing.T) {
+	r, w := io.Pipe()
+	w.Close()
+
+	err := readProxyStartupStatus(r, true)
+	assert.ErrorContains(t, err, "check that the current version is in your $PATH")
+}
+
+func TestNewProxyCommandArgs(t *testing.T) {
+	tests := []struct {
+		doc         string
+		hostIP      net.IP
+		containerIP net.IP
+		wantHostIP  string
+		wantErr     string
+	}{
+		{
+			doc:         "v4",
+			hostIP:      net.ParseIP("192.168.0.1"),
+			containerIP: net.ParseIP("172.17.0.2"),
+			wantHostIP:  "192.168.0.1",
+		},
+		{
+			doc:         "v6",
+			hostIP:      net.ParseIP("2001:db8::1"),
+			containerIP: net.ParseIP("2001:db8::2"),
+			wantHostIP:  "2001:db8::1",
+		},
+		{
+			doc:         "nil host IP",
+			hostIP:      nil,
+			containerIP: net.ParseIP("172.17.0.2"),
+			wantErr:     "no host IP provided",
+		},
+		{
+			doc:         "nil container IP",
+			hostIP:      net.ParseIP("192.168.0.1"),
+			containerIP: nil,
+			wantErr:     "no container IP provided",
+		},
+		{
+			doc:         "IPv4-mapped unspecified host IP is normalized",
+			hostIP:      net.ParseIP("::ffff:0.0.0.0"),
+			containerIP: net.ParseIP("172.17.0.2"),
+			wantHostIP:  "0.0.0.0",
+		},
+		{
+			doc:         "IPv6 unspecified host IP",
+			hostIP:      net.IPv6unspecified,
+			containerIP: net.ParseIP("2001:db8::2"),
+			wantHostIP:  "::",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.doc, func(t *testing.T) {
+			pb := types.PortBinding{
+				Proto:    types.TCP,
+				HostIP:   tc.hostIP,
+				HostPort: 8080,
+				IP:       tc.containerIP,
+				Port:     80,
+			}
+			args, err := newProxyCommandArgs(pb)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Check(t, is.Contains(args, "-host-ip"))
+			assert.Check(t, is.Contains(args, tc.wantHostIP))
+		})
+	}
+}
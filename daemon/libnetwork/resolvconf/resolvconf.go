@@ -12,3 +12,12 @@
 func Path() string {
 	return resolvconf.Path()
 }
+
+// Load reads and parses the resolv.conf file at path, returning an error if
+// it doesn't parse as a valid resolv.conf. It's used to validate a
+// resolv.conf template before it's accepted at container create time; see
+// [github.com/moby/moby/v2/daemon.validateResolvConfTemplate].
+func Load(path string) error {
+	_, err := resolvconf.Load(path)
+	return err
+}
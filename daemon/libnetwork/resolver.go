@@ -7,6 +7,7 @@
 	"math/rand"
 	"net"
 	"net/netip"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -61,6 +62,16 @@ type DNSBackend interface {
 	logInterval   = 2 * time.Second
 )
 
+// Failover strategies for choosing the order in which a resolver's external
+// DNS servers are tried. These mirror the values of
+// [github.com/moby/moby/api/types/container.DNSFailoverStrategy]; an empty
+// string is equivalent to dnsFailoverInOrder.
+const (
+	dnsFailoverInOrder      = "in-order"
+	dnsFailoverRotate       = "rotate"
+	dnsFailoverFastestFirst = "fastest-first"
+)
+
 type extDNSEntry struct {
 	IPStr        string
 	port         uint16 // for testing
@@ -92,6 +103,11 @@ type Resolver struct {
 
 	fwdSem      *semaphore.Weighted // Limit the number of concurrent external DNS requests in-flight
 	logInterval rate.Sometimes      // Rate-limit logging about hitting the fwdSem limit
+
+	failoverStrategy string                   // One of the dnsFailover* constants.
+	rrCounter        atomic.Uint32            // Next starting offset to use for the "rotate" strategy.
+	latencyMu        sync.Mutex               // Protects latency, below.
+	latency          map[string]time.Duration // Observed exchange latency by extDNSEntry.IPStr, for the "fastest-first" strategy.
 }
 
 // NewResolver creates a new instance of the Resolver
@@ -233,6 +249,14 @@ func (r *Resolver) SetForwardingPolicy(policy bool) {
 	r.proxyDNS.Store(policy)
 }
 
+// SetFailoverStrategy sets the order in which forwardExtDNS tries the
+// resolver's external DNS servers. strategy is one of the dnsFailover*
+// constants; an unrecognized value (including "") behaves like
+// dnsFailoverInOrder.
+func (r *Resolver) SetFailoverStrategy(strategy string) {
+	r.failoverStrategy = strategy
+}
+
 // SetExtServersForSrc configures the external nameservers the resolver should
 // use when forwarding queries from srcAddr. If set, these servers will be used
 // in preference to servers set by SetExtServers. Supplying a nil or empty extDNS
@@ -599,9 +623,57 @@ func (r *Resolver) forwardExtDNS(ctx context.Context, proto string, remoteAddr n
 
 func (r *Resolver) extDNS(remoteAddr netip.AddrPort) []extDNSEntry {
 	if res, ok := r.ipToExtDNS.get(remoteAddr.Addr()); ok {
-		return res[:]
+		return r.orderExtDNS(res[:])
+	}
+	return r.orderExtDNS(r.extDNSList[:])
+}
+
+// orderExtDNS reorders entries (a fixed-size, possibly trailing-empty slice
+// of external DNS servers) according to the resolver's failover strategy.
+// The result has the same length as entries, with any unused trailing slots
+// left in place, since callers stop iterating at the first empty IPStr.
+func (r *Resolver) orderExtDNS(entries []extDNSEntry) []extDNSEntry {
+	n := 0
+	for n < len(entries) && entries[n].IPStr != "" {
+		n++
 	}
-	return r.extDNSList[:]
+	if n < 2 {
+		return entries
+	}
+
+	ordered := make([]extDNSEntry, len(entries))
+	copy(ordered, entries)
+
+	switch r.failoverStrategy {
+	case dnsFailoverRotate:
+		start := int(r.rrCounter.Add(1)-1) % n
+		rotated := make([]extDNSEntry, n)
+		for i := range n {
+			rotated[i] = ordered[(start+i)%n]
+		}
+		copy(ordered, rotated)
+	case dnsFailoverFastestFirst:
+		r.latencyMu.Lock()
+		latency := r.latency
+		r.latencyMu.Unlock()
+		sort.SliceStable(ordered[:n], func(i, j int) bool {
+			return latency[ordered[i].IPStr] < latency[ordered[j].IPStr]
+		})
+	}
+	return ordered
+}
+
+// recordLatency stores the observed exchange duration for the external DNS
+// server at ipStr, consulted by orderExtDNS under the "fastest-first"
+// strategy. A server with no recorded latency sorts as if it took 0,
+// i.e. it's preferred until proven otherwise.
+func (r *Resolver) recordLatency(ipStr string, d time.Duration) {
+	r.latencyMu.Lock()
+	defer r.latencyMu.Unlock()
+	if r.latency == nil {
+		r.latency = make(map[string]time.Duration, maxExtDNS)
+	}
+	r.latency[ipStr] = d
 }
 
 func (r *Resolver) exchange(ctx context.Context, proto string, extDNS extDNSEntry, query *dns.Msg) *dns.Msg {
@@ -627,6 +699,7 @@ func (r *Resolver) exchange(ctx context.Context, proto string, extDNS extDNSEntr
 	})
 	logger.Debug("[resolver] forwarding query")
 
+	start := time.Now()
 	resp, _, err := (&dns.Client{
 		Timeout: extIOTimeout,
 		// Following the robustness principle, make a best-effort
@@ -651,6 +724,8 @@ func (r *Resolver) exchange(ctx context.Context, proto string, extDNS extDNSEntr
 		// Should be impossible, so make noise if it happens anyway.
 		logger.Error("[resolver] external DNS returned empty response")
 		span.SetStatus(codes.Error, "External DNS returned empty response")
+	} else {
+		r.recordLatency(extDNS.IPStr, time.Since(start))
 	}
 	return resp
 }
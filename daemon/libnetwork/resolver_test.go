@@ -5,6 +5,7 @@
 	"encoding/hex"
 	"errors"
 	"net"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -380,3 +381,115 @@ func TestInvalidReverseDNS(t *testing.T) {
 	t.Log("Response: ", resp.String())
 	checkDNSResponseCode(t, resp, dns.RcodeServerFailure)
 }
+
+// newAnsweringDNSServer starts a UDP DNS server listening on ip that answers
+// every A query after sleeping for delay, and returns its address. It counts
+// the number of queries it receives in hits.
+func newAnsweringDNSServer(t *testing.T, ip string, delay time.Duration, hits *atomic.Int32) *net.UDPAddr {
+	t.Helper()
+
+	serveStarted := make(chan struct{})
+	srv := &dns.Server{
+		Net:  "udp",
+		Addr: net.JoinHostPort(ip, "0"),
+		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, r *dns.Msg) {
+			hits.Add(1)
+			time.Sleep(delay)
+			resp := new(dns.Msg).SetReply(r)
+			resp.Answer = append(resp.Answer, &dns.A{
+				Hdr: dns.RR_Header{Name: r.Question[0].Name, Rrtype: dns.TypeA, Class: dns.ClassINET, Ttl: respTTL},
+				A:   net.ParseIP("10.0.0.1"),
+			})
+			assert.Check(t, w.WriteMsg(resp))
+		}),
+		NotifyStartedFunc: func() { close(serveStarted) },
+	}
+	serveDone := make(chan error, 1)
+	go func() {
+		defer close(serveDone)
+		serveDone <- srv.ListenAndServe()
+	}()
+	select {
+	case err := <-serveDone:
+		t.Fatal(err)
+	case <-serveStarted:
+	}
+	t.Cleanup(func() {
+		assert.Check(t, srv.Shutdown())
+		<-serveDone
+	})
+
+	return srv.PacketConn.LocalAddr().(*net.UDPAddr)
+}
+
+// TestResolverFastestFirstFailover asserts that, under the "fastest-first"
+// strategy, a resolver learns from observed exchange latency and starts
+// preferring the historically fastest of its external DNS servers, rather
+// than always trying them in configured order.
+func TestResolverFastestFirstFailover(t *testing.T) {
+	var slowHits, fastHits atomic.Int32
+	slowAddr := newAnsweringDNSServer(t, "127.0.0.2", 50*time.Millisecond, &slowHits)
+	fastAddr := newAnsweringDNSServer(t, "127.0.0.3", 0, &fastHits)
+
+	rsv := NewResolver("", true, noopDNSBackend{})
+	rsv.logger = testLogger(t)
+	rsv.SetFailoverStrategy(dnsFailoverFastestFirst)
+	rsv.SetExtServers([]extDNSEntry{
+		{IPStr: slowAddr.IP.String(), port: uint16(slowAddr.Port), HostLoopback: true},
+		{IPStr: fastAddr.IP.String(), port: uint16(fastAddr.Port), HostLoopback: true},
+	})
+
+	query := func() {
+		w := &tstwriter{network: "udp"}
+		q := new(dns.Msg).SetQuestion("example.com.", dns.TypeA)
+		rsv.serveDNS(w, q)
+		checkNonNullResponse(t, w.GetResponse())
+	}
+
+	// No latency recorded yet: the configured order (slow, then fast) is
+	// preserved, so the slow server answers first.
+	query()
+	assert.Equal(t, slowHits.Load(), int32(1))
+	assert.Equal(t, fastHits.Load(), int32(0))
+
+	// The slow server now has a recorded latency and the fast server
+	// doesn't, so the unmeasured fast server is preferred and answers.
+	query()
+	assert.Equal(t, slowHits.Load(), int32(1))
+	assert.Equal(t, fastHits.Load(), int32(1))
+
+	// Both servers now have recorded latencies, and the resolver keeps
+	// preferring the faster one.
+	query()
+	assert.Equal(t, slowHits.Load(), int32(1))
+	assert.Equal(t, fastHits.Load(), int32(2))
+}
+
+// TestResolverRotateFailover asserts that, under the "rotate" strategy, a
+// resolver spreads queries across its external DNS servers instead of always
+// starting from the first one.
+func TestResolverRotateFailover(t *testing.T) {
+	var hitsA, hitsB atomic.Int32
+	addrA := newAnsweringDNSServer(t, "127.0.0.4", 0, &hitsA)
+	addrB := newAnsweringDNSServer(t, "127.0.0.5", 0, &hitsB)
+
+	rsv := NewResolver("", true, noopDNSBackend{})
+	rsv.logger = testLogger(t)
+	rsv.SetFailoverStrategy(dnsFailoverRotate)
+	rsv.SetExtServers([]extDNSEntry{
+		{IPStr: addrA.IP.String(), port: uint16(addrA.Port), HostLoopback: true},
+		{IPStr: addrB.IP.String(), port: uint16(addrB.Port), HostLoopback: true},
+	})
+
+	for range 4 {
+		w := &tstwriter{network: "udp"}
+		q := new(dns.Msg).SetQuestion("example.com.", dns.TypeA)
+		rsv.serveDNS(w, q)
+		checkNonNullResponse(t, w.GetResponse())
+	}
+
+	// Both servers should have been tried as the first (and only, since
+	// each one answers) server for some query.
+	assert.Equal(t, hitsA.Load(), int32(2))
+	assert.Equal(t, hitsB.Load(), int32(2))
+}
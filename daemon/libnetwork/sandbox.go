@@ -87,12 +87,14 @@ type extraHost struct {
 
 // These are the container configs used to customize container /etc/resolv.conf file.
 type resolvConfPathConfig struct {
-	resolvConfPath       string
-	originResolvConfPath string
-	resolvConfHashFile   string
-	dnsList              []netip.Addr
-	dnsSearchList        []string
-	dnsOptionsList       []string
+	resolvConfPath         string
+	originResolvConfPath   string
+	resolvConfHashFile     string
+	resolvConfTemplatePath string
+	dnsList                []netip.Addr
+	dnsSearchList          []string
+	dnsOptionsList         []string
+	dnsFailoverStrategy    string
 }
 
 type containerConfig struct {
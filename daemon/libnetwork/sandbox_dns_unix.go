@@ -55,6 +55,15 @@ func (sb *Sandbox) rebuildHostsFile(ctx context.Context) error {
 	return nil
 }
 
+// resolverIP returns the IP address the embedded DNS resolver listens on
+// inside the sandbox, honoring the daemon's configured override if set.
+func (sb *Sandbox) resolverIP() string {
+	if sb.controller != nil && sb.controller.cfg.EmbeddedDNSAddress != "" {
+		return sb.controller.cfg.EmbeddedDNSAddress
+	}
+	return resolverIPSandbox
+}
+
 func (sb *Sandbox) startResolver(restore bool) {
 	sb.resolverOnce.Do(func() {
 		var err error
@@ -62,7 +71,7 @@ func (sb *Sandbox) startResolver(restore bool) {
 		// have a gateway. So, if the Sandbox is only connected to an 'internal' network,
 		// it will not forward DNS requests to external resolvers. The resolver's
 		// proxyDNS setting is then updated as network Endpoints are added/removed.
-		sb.resolver = NewResolver(resolverIPSandbox, sb.hasExternalAccess(), sb)
+		sb.resolver = NewResolver(sb.resolverIP(), sb.hasExternalAccess(), sb)
 		defer func() {
 			if err != nil {
 				sb.resolver = nil
@@ -81,6 +90,7 @@ func (sb *Sandbox) startResolver(restore bool) {
 			}
 		}
 		sb.resolver.SetExtServers(sb.extDNS)
+		sb.resolver.SetFailoverStrategy(sb.config.dnsFailoverStrategy)
 
 		if err = sb.osSbox.InvokeFunc(sb.resolver.SetupFunc(0)); err != nil {
 			log.G(context.TODO()).Errorf("Resolver Setup function failed for container %s, %q", sb.ContainerID(), err)
@@ -262,7 +272,8 @@ func (sb *Sandbox) loadResolvConf(path string) (*resolvconf.ResolvConf, error) {
 
 // For a new sandbox, write an initial version of the container's resolv.conf. It'll
 // be a copy of the host's file, with overrides for nameservers, options and search
-// domains applied.
+// domains applied. If a resolv.conf template was configured, its contents are used
+// verbatim instead, and are not touched again by updateDNS or rebuildDNS.
 func (sb *Sandbox) setupDNS() error {
 	// Make sure the directory exists.
 	sb.restoreResolvConfPath()
@@ -271,6 +282,10 @@ func (sb *Sandbox) setupDNS() error {
 		return err
 	}
 
+	if sb.config.resolvConfTemplatePath != "" {
+		return copyFile(sb.config.resolvConfTemplatePath, sb.config.resolvConfPath)
+	}
+
 	rc, err := sb.loadResolvConf(sb.config.getOriginResolvConfPath())
 	if err != nil {
 		return err
@@ -280,6 +295,10 @@ func (sb *Sandbox) setupDNS() error {
 
 // Called when an endpoint has joined the sandbox.
 func (sb *Sandbox) updateDNS(ipv6Enabled bool) error {
+	if sb.config.resolvConfTemplatePath != "" {
+		// The container's resolv.conf is a verbatim copy of the template; leave it alone.
+		return nil
+	}
 	if mod, err := resolvconf.UserModified(sb.config.resolvConfPath, sb.config.resolvConfHashFile); err != nil || mod {
 		return err
 	}
@@ -301,6 +320,10 @@ func (sb *Sandbox) updateDNS(ipv6Enabled bool) error {
 
 // Embedded DNS server has to be enabled for this sandbox. Rebuild the container's resolv.conf.
 func (sb *Sandbox) rebuildDNS() error {
+	if sb.config.resolvConfTemplatePath != "" {
+		// The container's resolv.conf is a verbatim copy of the template; leave it alone.
+		return nil
+	}
 	// Don't touch the file if the user has modified it.
 	if mod, err := resolvconf.UserModified(sb.config.resolvConfPath, sb.config.resolvConfHashFile); err != nil || mod {
 		return err
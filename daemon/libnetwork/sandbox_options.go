@@ -64,6 +64,17 @@ func OptionOriginResolvConfPath(path string) SandboxOption {
 	}
 }
 
+// OptionResolvConfTemplatePath function returns an option setter for a
+// resolv.conf template path: when set, its contents are used verbatim as
+// the container's resolv.conf instead of one generated from the host's
+// resolv.conf and DNS/DNSSearch/DNSOptions overrides, and it is not
+// subsequently regenerated as endpoints join or leave the sandbox.
+func OptionResolvConfTemplatePath(path string) SandboxOption {
+	return func(sb *Sandbox) {
+		sb.config.resolvConfTemplatePath = path
+	}
+}
+
 // OptionDNS function returns an option setter for dns entry option to
 // be passed to container Create method.
 func OptionDNS(dns []netip.Addr) SandboxOption {
@@ -88,6 +99,15 @@ func OptionDNSOptions(options []string) SandboxOption {
 	}
 }
 
+// OptionDNSFailoverStrategy function returns an option setter for the
+// resolver's external DNS server failover strategy, to be passed to
+// container Create method. An empty strategy means "in order".
+func OptionDNSFailoverStrategy(strategy string) SandboxOption {
+	return func(sb *Sandbox) {
+		sb.config.dnsFailoverStrategy = strategy
+	}
+}
+
 // OptionUseDefaultSandbox function returns an option setter for using default sandbox
 // (host namespace) to be passed to container Create method.
 func OptionUseDefaultSandbox() SandboxOption {
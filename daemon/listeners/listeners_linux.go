@@ -12,11 +12,16 @@
 	"github.com/docker/go-connections/sockets"
 	"github.com/moby/moby/v2/pkg/homedir"
 	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
 )
 
 // Init creates new listeners for the server.
 // TODO: Clean up the fact that socketGroup and tlsConfig aren't always used.
-func Init(proto, addr, socketGroup string, tlsConfig *tls.Config) ([]net.Listener, error) {
+//
+// backlog, when greater than zero, overrides the OS default accept queue
+// size (the listen(2) backlog) for TCP listeners. It's ignored for other
+// protocols.
+func Init(proto, addr, socketGroup string, tlsConfig *tls.Config, backlog int) ([]net.Listener, error) {
 	ls := []net.Listener{}
 
 	switch proto {
@@ -27,7 +32,7 @@ func Init(proto, addr, socketGroup string, tlsConfig *tls.Config) ([]net.Listene
 		}
 		ls = append(ls, fds...)
 	case "tcp":
-		l, err := sockets.NewTCPSocket(addr, tlsConfig)
+		l, err := newTCPSocket(addr, tlsConfig, backlog)
 		if err != nil {
 			return nil, err
 		}
@@ -59,6 +64,80 @@ func Init(proto, addr, socketGroup string, tlsConfig *tls.Config) ([]net.Listene
 	return ls, nil
 }
 
+// newTCPSocket creates a TCP listener for addr. When backlog is greater than
+// zero, the listener's accept queue is created with that backlog instead of
+// the OS default, to better absorb bursts of incoming connections.
+func newTCPSocket(addr string, tlsConfig *tls.Config, backlog int) (net.Listener, error) {
+	if backlog <= 0 {
+		return sockets.NewTCPSocket(addr, tlsConfig)
+	}
+
+	l, err := listenTCPWithBacklog(addr, backlog)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig != nil {
+		tlsConfig.NextProtos = []string{"http/1.1"}
+		l = tls.NewListener(l, tlsConfig)
+	}
+	return l, nil
+}
+
+// listenTCPWithBacklog creates a TCP listener the same way [net.Listen] does,
+// except that it sets the listen(2) backlog explicitly instead of relying on
+// the OS default (which, on Linux, is capped by net.core.somaxconn). This
+// can't be done through [net.ListenConfig]: its Control hook runs before the
+// socket is bound, and the listen(2) call that follows always uses the OS
+// default backlog regardless of what Control does.
+func listenTCPWithBacklog(addr string, backlog int) (net.Listener, error) {
+	tcpAddr, err := net.ResolveTCPAddr("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+
+	domain := unix.AF_INET
+	var sa unix.Sockaddr
+	if ip4 := tcpAddr.IP.To4(); ip4 != nil {
+		sa4 := &unix.SockaddrInet4{Port: tcpAddr.Port}
+		copy(sa4.Addr[:], ip4)
+		sa = sa4
+	} else {
+		domain = unix.AF_INET6
+		sa6 := &unix.SockaddrInet6{Port: tcpAddr.Port}
+		if tcpAddr.IP != nil {
+			copy(sa6.Addr[:], tcpAddr.IP.To16())
+		}
+		sa = sa6
+	}
+
+	fd, err := unix.Socket(domain, unix.SOCK_STREAM, unix.IPPROTO_TCP)
+	if err != nil {
+		return nil, os.NewSyscallError("socket", err)
+	}
+	if err := unix.SetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_REUSEADDR, 1); err != nil {
+		unix.Close(fd)
+		return nil, os.NewSyscallError("setsockopt", err)
+	}
+	if domain == unix.AF_INET6 {
+		if err := unix.SetsockoptInt(fd, unix.IPPROTO_IPV6, unix.IPV6_V6ONLY, 1); err != nil {
+			unix.Close(fd)
+			return nil, os.NewSyscallError("setsockopt", err)
+		}
+	}
+	if err := unix.Bind(fd, sa); err != nil {
+		unix.Close(fd)
+		return nil, os.NewSyscallError("bind", err)
+	}
+	if err := unix.Listen(fd, backlog); err != nil {
+		unix.Close(fd)
+		return nil, os.NewSyscallError("listen", err)
+	}
+
+	f := os.NewFile(uintptr(fd), addr)
+	defer f.Close()
+	return net.FileListener(f)
+}
+
 // listenFD returns the specified socket activated files as a slice of
 // net.Listeners or all of the activated files if "*" is given.
 func listenFD(addr string, tlsConfig *tls.Config) ([]net.Listener, error) {
@@ -0,0 +1,40 @@
+package listeners
+
+import (
+	"net"
+	"testing"
+
+	"golang.org/x/sys/unix"
+	"gotest.tools/v3/assert"
+)
+
+func TestNewTCPSocketWithBacklog(t *testing.T) {
+	l, err := newTCPSocket("127.0.0.1:0", nil, 16)
+	assert.NilError(t, err)
+	defer l.Close()
+
+	tcpListener, ok := l.(*net.TCPListener)
+	assert.Assert(t, ok, "expected a *net.TCPListener, got %T", l)
+
+	f, err := tcpListener.File()
+	assert.NilError(t, err)
+	defer f.Close()
+
+	reuseAddr, err := unix.GetsockoptInt(int(f.Fd()), unix.SOL_SOCKET, unix.SO_REUSEADDR)
+	assert.NilError(t, err)
+	assert.Assert(t, reuseAddr != 0, "expected SO_REUSEADDR to be set on the custom-backlog listener")
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	assert.NilError(t, err)
+	conn.Close()
+}
+
+func TestNewTCPSocketDefaultBacklog(t *testing.T) {
+	l, err := newTCPSocket("127.0.0.1:0", nil, 0)
+	assert.NilError(t, err)
+	defer l.Close()
+
+	conn, err := net.Dial("tcp", l.Addr().String())
+	assert.NilError(t, err)
+	conn.Close()
+}
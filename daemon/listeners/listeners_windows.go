@@ -1,17 +1,27 @@
 package listeners
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net"
 	"strings"
 
 	"github.com/Microsoft/go-winio"
+	"github.com/containerd/log"
 	"github.com/docker/go-connections/sockets"
 )
 
 // Init creates new listeners for the server.
-func Init(proto, addr, socketGroup string, tlsConfig *tls.Config) ([]net.Listener, error) {
+//
+// backlog is accepted for signature parity with the Linux implementation,
+// but is ignored: the accept queue size can't be customized for TCP sockets
+// on Windows the way it can with Linux's listen(2) backlog.
+func Init(proto, addr, socketGroup string, tlsConfig *tls.Config, backlog int) ([]net.Listener, error) {
+	if backlog > 0 {
+		log.G(context.TODO()).Warn("tcp-listen-backlog is not supported on Windows and will be ignored")
+	}
+
 	ls := []net.Listener{}
 
 	// Windows allows a comma-separated list of groups and/or users to be set.
@@ -80,6 +80,17 @@ type SizedLogger interface {
 	BufSize() int
 }
 
+// LogDropCounter is implemented by loggers that discard messages under
+// backpressure, e.g. when a container logs faster than the configured
+// driver can consume. It reports how much has been discarded so callers can
+// surface it, for example in container inspect output or metrics.
+type LogDropCounter interface {
+	Logger
+	// DroppedStats returns the total number of messages and bytes dropped
+	// since the logger was created.
+	DroppedStats() (messages, bytes int64)
+}
+
 // ReadConfig is the configuration passed into ReadLogs.
 type ReadConfig struct {
 	Since  time.Time
@@ -11,6 +11,12 @@
 // reference: https://www.freedesktop.org/software/systemd/man/journald.conf.html#RateLimitIntervalSec=
 var logErrorLimiter = rate.NewLimiter(333, 333)
 
+// logDropLimiter rate-limits the warning logged when a non-blocking logger's
+// buffer is dropping messages. Backpressure can drop thousands of messages a
+// second, so this is capped much lower than logErrorLimiter to avoid adding
+// to the very backpressure it's reporting on.
+var logDropLimiter = rate.NewLimiter(1, 1)
+
 // logDriverError logs errors produced by log drivers to the daemon logs. It also increments the logWritesFailedCount
 // metric.
 // Logging to the daemon logs is limited to 333 operations per second at most. If this limit is exceeded, the
@@ -25,3 +31,15 @@ func logDriverError(loggerName, msgLine string, logErr error) {
 		}).Error("Error writing log message")
 	}
 }
+
+// logMessageDropped records a message being discarded from a non-blocking
+// logger's buffer because the log driver could not keep up, incrementing the
+// log_messages_dropped and log_bytes_dropped metrics. Logging a warning about
+// it is rate-limited by logDropLimiter.
+func logMessageDropped(loggerName string, size int64) {
+	logMessagesDroppedCount.Inc(1)
+	logBytesDroppedCount.Inc(float64(size))
+	if logDropLimiter.Allow() {
+		log.G(context.TODO()).WithField("driver", loggerName).Warn("Container logs are being dropped: the log driver is not keeping up with the rate of log messages being produced")
+	}
+}
@@ -5,9 +5,11 @@
 )
 
 var (
-	logWritesFailedCount gometrics.Counter
-	logReadsFailedCount  gometrics.Counter
-	totalPartialLogs     gometrics.Counter
+	logWritesFailedCount    gometrics.Counter
+	logReadsFailedCount     gometrics.Counter
+	totalPartialLogs        gometrics.Counter
+	logMessagesDroppedCount gometrics.Counter
+	logBytesDroppedCount    gometrics.Counter
 )
 
 func init() {
@@ -16,6 +18,8 @@ func init() {
 	logWritesFailedCount = loggerMetrics.NewCounter("log_write_operations_failed", "Number of log write operations that failed")
 	logReadsFailedCount = loggerMetrics.NewCounter("log_read_operations_failed", "Number of log reads from container stdio that failed")
 	totalPartialLogs = loggerMetrics.NewCounter("log_entries_size_greater_than_buffer", "Number of log entries which are larger than the log buffer")
+	logMessagesDroppedCount = loggerMetrics.NewCounter("log_messages_dropped", "Number of log messages discarded from a non-blocking logger's buffer because the log driver could not keep up")
+	logBytesDroppedCount = loggerMetrics.NewCounter("log_bytes_dropped", "Number of log message bytes discarded from a non-blocking logger's buffer because the log driver could not keep up")
 
 	gometrics.Register(loggerMetrics)
 }
@@ -22,8 +22,9 @@ type ringLogger struct {
 }
 
 var (
-	_ SizedLogger = (*ringLogger)(nil)
-	_ LogReader   = (*ringWithReader)(nil)
+	_ SizedLogger    = (*ringLogger)(nil)
+	_ LogDropCounter = (*ringLogger)(nil)
+	_ LogReader      = (*ringWithReader)(nil)
 )
 
 type ringWithReader struct {
@@ -41,7 +42,7 @@ func (r *ringWithReader) ReadLogs(ctx context.Context, cfg ReadConfig) *LogWatch
 
 func newRingLogger(driver Logger, logInfo Info, maxSize int64) *ringLogger {
 	l := &ringLogger{
-		buffer:  newRing(maxSize),
+		buffer:  newRing(maxSize, driver.Name()),
 		l:       driver,
 		logInfo: logInfo,
 	}
@@ -62,6 +63,13 @@ func NewRingLogger(driver Logger, logInfo Info, maxSize int64) Logger {
 	return l
 }
 
+// DroppedStats returns the total number of messages and bytes that have been
+// discarded from the ring buffer because the underlying logger could not
+// keep up with the rate of incoming log messages.
+func (r *ringLogger) DroppedStats() (messages, bytes int64) {
+	return r.buffer.Dropped()
+}
+
 // BufSize returns the buffer size of the underlying logger.
 // Returns -1 if the logger doesn't match SizedLogger interface.
 func (r *ringLogger) BufSize() int {
@@ -145,9 +153,14 @@ type messageRing struct {
 	maxBytes  int64 // max buffer size
 	queue     []*Message
 	closed    bool
+
+	loggerName string // name of the wrapped logger, used to report dropped messages
+
+	droppedMessages int64 // number of messages dropped due to backpressure; access atomically
+	droppedBytes    int64 // number of bytes dropped due to backpressure; access atomically
 }
 
-func newRing(maxBytes int64) *messageRing {
+func newRing(maxBytes int64, loggerName string) *messageRing {
 	queueSize := 1000
 	if maxBytes == 0 || maxBytes == 1 {
 		// With 0 or 1 max byte size, the maximum size of the queue would only ever be 1
@@ -155,7 +168,7 @@ func newRing(maxBytes int64) *messageRing {
 		queueSize = 1
 	}
 
-	r := &messageRing{queue: make([]*Message, 0, queueSize), maxBytes: maxBytes}
+	r := &messageRing{queue: make([]*Message, 0, queueSize), maxBytes: maxBytes, loggerName: loggerName}
 	r.wait = sync.NewCond(&r.mu)
 	return r
 }
@@ -173,6 +186,9 @@ func (r *messageRing) Enqueue(m *Message) error {
 	}
 	if mSize+r.sizeBytes > r.maxBytes && len(r.queue) > 0 {
 		r.wait.Signal()
+		atomic.AddInt64(&r.droppedMessages, 1)
+		atomic.AddInt64(&r.droppedBytes, mSize)
+		logMessageDropped(r.loggerName, mSize)
 		return nil
 	}
 
@@ -182,6 +198,12 @@ func (r *messageRing) Enqueue(m *Message) error {
 	return nil
 }
 
+// Dropped returns the total number of messages and bytes that have been
+// discarded from the buffer due to backpressure.
+func (r *messageRing) Dropped() (messages, bytes int64) {
+	return atomic.LoadInt64(&r.droppedMessages), atomic.LoadInt64(&r.droppedBytes)
+}
+
 // Dequeue pulls a message off the queue
 // If there are no messages, it waits for one.
 // If the buffer is closed, it will return immediately.
@@ -50,7 +50,7 @@ func TestRingLogger(t *testing.T) {
 }
 
 func TestRingCap(t *testing.T) {
-	r := newRing(5)
+	r := newRing(5, "mock")
 	for i := range 10 {
 		// queue messages with "0" to "10"
 		// the "5" to "10" messages should be dropped since we only allow 5 bytes in the buffer
@@ -59,6 +59,10 @@ func TestRingCap(t *testing.T) {
 		}
 	}
 
+	if messages, bytes := r.Dropped(); messages != 5 || bytes != 5 {
+		t.Fatalf("expected 5 dropped messages totalling 5 bytes, got: %d messages, %d bytes", messages, bytes)
+	}
+
 	// should have messages in the queue for "0" to "4"
 	for i := range 5 {
 		m, err := r.Dequeue()
@@ -93,7 +97,7 @@ func TestRingCap(t *testing.T) {
 }
 
 func TestRingClose(t *testing.T) {
-	r := newRing(1)
+	r := newRing(1, "mock")
 	if err := r.Enqueue(&Message{Line: []byte("hello")}); err != nil {
 		t.Fatal(err)
 	}
@@ -118,7 +122,7 @@ func TestRingClose(t *testing.T) {
 }
 
 func TestRingDrain(t *testing.T) {
-	r := newRing(5)
+	r := newRing(5, "mock")
 	for i := range 5 {
 		if err := r.Enqueue(&Message{Line: []byte(strconv.Itoa(i))}); err != nil {
 			t.Fatal(err)
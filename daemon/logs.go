@@ -2,6 +2,8 @@
 
 import (
 	"context"
+	"fmt"
+	"sort"
 	"strconv"
 
 	"github.com/containerd/containerd/v2/pkg/tracing"
@@ -166,8 +168,12 @@ func (daemon *Daemon) getLogger(container *container.Container) (_ logger.Logger
 	return logDriver, true, nil
 }
 
-// mergeAndVerifyLogConfig merges the daemon log config to the container's log config if the container's log driver is not specified.
-func (daemon *Daemon) mergeAndVerifyLogConfig(cfg *containertypes.LogConfig) error {
+// mergeAndVerifyLogConfig merges the daemon log config to the container's
+// log config if the container's log driver is not specified, then validates
+// the result. It returns a warning for each option that passed validation
+// but is effectively ignored by the resulting driver, naming the offending
+// option.
+func (daemon *Daemon) mergeAndVerifyLogConfig(cfg *containertypes.LogConfig) ([]string, error) {
 	if cfg.Type == "" {
 		cfg.Type = daemon.defaultLogConfig.Type
 	}
@@ -186,7 +192,26 @@ func (daemon *Daemon) mergeAndVerifyLogConfig(cfg *containertypes.LogConfig) err
 
 	logcache.MergeDefaultLogConfig(cfg.Config, daemon.defaultLogConfig.Config)
 
-	return logger.ValidateLogOpts(cfg.Type, cfg.Config)
+	if err := logger.ValidateLogOpts(cfg.Type, cfg.Config); err != nil {
+		return nil, err
+	}
+	return warnIgnoredLogOpts(cfg.Type, cfg.Config), nil
+}
+
+// warnIgnoredLogOpts returns a warning for each entry in cfg that's ignored
+// by the named log driver, naming the offending option. Currently this only
+// covers the "none" driver, which accepts (and discards) any options
+// without validating them, silently ignoring everything a caller sets.
+func warnIgnoredLogOpts(driverName string, cfg map[string]string) []string {
+	if driverName != "none" || len(cfg) == 0 {
+		return nil
+	}
+	warnings := make([]string, 0, len(cfg))
+	for k := range cfg {
+		warnings = append(warnings, fmt.Sprintf("log option %q is ignored by the %q log driver", k, driverName))
+	}
+	sort.Strings(warnings)
+	return warnings
 }
 
 func defaultLogConfig(cfg *config.Config) (containertypes.LogConfig, error) {
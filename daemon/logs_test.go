@@ -4,12 +4,54 @@
 	"testing"
 
 	containertypes "github.com/moby/moby/api/types/container"
+	"gotest.tools/v3/assert"
 )
 
 func TestMergeAndVerifyLogConfigNilConfig(t *testing.T) {
 	d := &Daemon{defaultLogConfig: containertypes.LogConfig{Type: "json-file", Config: map[string]string{"max-file": "1"}}}
 	cfg := containertypes.LogConfig{Type: d.defaultLogConfig.Type}
-	if err := d.mergeAndVerifyLogConfig(&cfg); err != nil {
+	if _, err := d.mergeAndVerifyLogConfig(&cfg); err != nil {
 		t.Fatal(err)
 	}
 }
+
+func TestMergeAndVerifyLogConfigValidCombo(t *testing.T) {
+	d := &Daemon{}
+	cfg := containertypes.LogConfig{Type: "json-file", Config: map[string]string{"max-size": "10m"}}
+	warnings, err := d.mergeAndVerifyLogConfig(&cfg)
+	assert.NilError(t, err)
+	assert.Equal(t, len(warnings), 0)
+}
+
+func TestMergeAndVerifyLogConfigInvalidCombo(t *testing.T) {
+	d := &Daemon{}
+	cfg := containertypes.LogConfig{Type: "syslog", Config: map[string]string{"max-size": "10m"}}
+	_, err := d.mergeAndVerifyLogConfig(&cfg)
+	assert.ErrorContains(t, err, "unknown log opt")
+}
+
+func TestMergeAndVerifyLogConfigIgnoredOption(t *testing.T) {
+	d := &Daemon{}
+	cfg := containertypes.LogConfig{Type: "none", Config: map[string]string{"max-size": "10m"}}
+	warnings, err := d.mergeAndVerifyLogConfig(&cfg)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, warnings, []string{`log option "max-size" is ignored by the "none" log driver`})
+}
+
+// TestMergeAndVerifyLogConfigCompressOverride confirms that a per-container
+// "compress" log option is honored by the json-file driver even when the
+// daemon-wide default log config sets a different value, and that other
+// unset options still fall back to the daemon default.
+func TestMergeAndVerifyLogConfigCompressOverride(t *testing.T) {
+	d := &Daemon{defaultLogConfig: containertypes.LogConfig{
+		Type:   "json-file",
+		Config: map[string]string{"compress": "true", "max-file": "5", "max-size": "10m"},
+	}}
+
+	cfg := containertypes.LogConfig{Type: "json-file", Config: map[string]string{"compress": "false"}}
+	warnings, err := d.mergeAndVerifyLogConfig(&cfg)
+	assert.NilError(t, err)
+	assert.Equal(t, len(warnings), 0)
+	assert.Equal(t, cfg.Config["compress"], "false")
+	assert.Equal(t, cfg.Config["max-file"], "5")
+}
@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -123,10 +124,14 @@ func (daemon *Daemon) handleContainerExit(c *container.Container, e *libcontaine
 
 	if restart {
 		c.RestartCount++
+		c.LastRestartAt = time.Now()
+		c.LastRestartReason = restartReason(ctrExitStatus.ExitCode, c.State.OOMKilled)
+		metrics.ContainerRestarts.Set(c.ID, c.RestartCount)
 		log.G(ctx).WithFields(log.Fields{
 			"container":     c.ID,
 			"restartPolicy": c.HostConfig.RestartPolicy,
 			"restartCount":  c.RestartCount,
+			"restartReason": c.LastRestartReason,
 			"exitCode":      ctrExitStatus.ExitCode,
 			"exitedAt":      ctrExitStatus.ExitedAt,
 			"manualRestart": c.HasBeenManuallyRestarted,
@@ -337,6 +342,15 @@ func (daemon *Daemon) ProcessEvent(id string, e libcontainerdtypes.EventType, ei
 	}
 }
 
+// restartReason derives a short human-readable reason for a container
+// restart from the signals available at restart time.
+func restartReason(exitCode int, oomKilled bool) string {
+	if oomKilled {
+		return fmt.Sprintf("out of memory (exit code %d)", exitCode)
+	}
+	return fmt.Sprintf("exit code %d", exitCode)
+}
+
 func (daemon *Daemon) autoRemove(cfg *config.Config, c *container.Container) {
 	c.Lock()
 	ar := c.HostConfig.AutoRemove
@@ -20,7 +20,6 @@
 	"github.com/moby/moby/v2/daemon/internal/rootless/mountopts"
 	"github.com/moby/moby/v2/daemon/internal/rootless/specconv"
 	"github.com/moby/moby/v2/daemon/pkg/oci"
-	"github.com/moby/moby/v2/daemon/pkg/oci/caps"
 	volumemounts "github.com/moby/moby/v2/daemon/volume/mounts"
 	"github.com/moby/moby/v2/errdefs"
 	"github.com/moby/sys/mount"
@@ -145,6 +144,15 @@ func WithApparmor(c *container.Container) coci.SpecOpts {
 					return err
 				}
 			}
+
+			if len(c.AppArmorTweaks) > 0 && appArmorProfile != unconfinedAppArmorProfile {
+				tweaked, err := loadTweakedAppArmorProfile(appArmorProfile, c.AppArmorTweaks)
+				if err != nil {
+					return fmt.Errorf("generating tweaked AppArmor profile for container %s: %w", c.ID, err)
+				}
+				appArmorProfile = tweaked
+			}
+
 			if s.Process == nil {
 				s.Process = &specs.Process{}
 			}
@@ -158,12 +166,7 @@ func WithApparmor(c *container.Container) coci.SpecOpts {
 // "CapAdd", "CapDrop", and "Privileged" fields in the container's HostConfig.
 func WithCapabilities(ctr *container.Container) coci.SpecOpts {
 	return func(ctx context.Context, client coci.Client, c *containers.Container, s *specs.Spec) (err error) {
-		capabilities, err := caps.TweakCapabilities(
-			caps.DefaultCapabilities(),
-			ctr.HostConfig.CapAdd,
-			ctr.HostConfig.CapDrop,
-			ctr.HostConfig.Privileged,
-		)
+		capabilities, err := EffectiveCapabilities(ctr.HostConfig)
 		if err != nil {
 			return err
 		}
@@ -278,6 +281,14 @@ func WithNamespaces(daemon *Daemon, c *container.Container) coci.SpecOpts {
 		}
 		if !sysInfo.TimeNamespaces {
 			oci.RemoveNamespace(s, specs.TimeNamespace)
+		} else if offset := c.HostConfig.TimeNsOffsetSeconds; offset != nil {
+			if s.Linux == nil {
+				s.Linux = &specs.Linux{}
+			}
+			s.Linux.TimeOffsets = map[string]specs.LinuxTimeOffset{
+				"boottime":  {Secs: *offset},
+				"monotonic": {Secs: *offset},
+			}
 		}
 
 		// ipc
@@ -491,7 +502,8 @@ func withMounts(daemon *Daemon, daemonCfg *configStore, c *container.Container,
 		//  - all mounts under /dev if a user supplied /dev is present;
 		//  - /dev/shm, in case IpcMode is none.
 		// While at it, also
-		//  - set size for /dev/shm from shmsize.
+		//  - set size for /dev/shm from shmsize;
+		//  - apply HostConfig.ShmOptions to the /dev/shm mount, if set.
 		defaultMounts := s.Mounts[:0]
 		_, mountDev := userMounts["/dev"]
 		for _, m := range s.Mounts {
@@ -509,6 +521,9 @@ func withMounts(daemon *Daemon, daemonCfg *configStore, c *container.Container,
 					// filter out /dev/shm for "none" IpcMode
 					continue
 				}
+				if len(c.HostConfig.ShmOptions) > 0 {
+					m.Options = append([]string{}, c.HostConfig.ShmOptions...)
+				}
 				// set size for /dev/shm mount from spec
 				sizeOpt := "size=" + strconv.FormatInt(c.HostConfig.ShmSize, 10)
 				m.Options = append(m.Options, sizeOpt)
@@ -731,7 +746,8 @@ func withCommonOptions(daemon *Daemon, daemonCfg *dconfig.Config, c *container.C
 		if c.HostConfig.PidMode.IsPrivate() {
 			if (c.HostConfig.Init != nil && *c.HostConfig.Init) ||
 				(c.HostConfig.Init == nil && daemonCfg.Init) {
-				s.Process.Args = append([]string{inContainerInitPath, "--", c.Path}, c.Args...)
+				initArgs := append([]string{inContainerInitPath}, c.HostConfig.InitArgs...)
+				s.Process.Args = append(append(initArgs, "--"), append([]string{c.Path}, c.Args...)...)
 				path, err := daemonCfg.LookupInitPath() // this will fall back to DefaultInitBinary and return an absolute path
 				if err != nil {
 					return err
@@ -742,6 +758,8 @@ func withCommonOptions(daemon *Daemon, daemonCfg *dconfig.Config, c *container.C
 					Source:      path,
 					Options:     []string{"bind", "ro"},
 				})
+			} else if len(c.HostConfig.InitArgs) > 0 {
+				log.G(ctx).WithField("container", c.ID).Warn("InitArgs was set but init is not enabled for this container; ignoring InitArgs")
 			}
 		}
 		s.Process.Cwd = cwd
@@ -907,6 +925,15 @@ func WithDevices(daemon *Daemon, c *container.Container) coci.SpecOpts {
 		s.Linux.Devices = append(s.Linux.Devices, devs...)
 		s.Linux.Resources.Devices = append(s.Linux.Resources.Devices, devPermissions...)
 
+		if !c.HostConfig.Privileged && c.HostConfig.RngDevice != "" {
+			rngDevs, rngPermissions, err := oci.DevicesFromPath(c.HostConfig.RngDevice, "/dev/hwrng", "r")
+			if err != nil {
+				return err
+			}
+			s.Linux.Devices = append(s.Linux.Devices, rngDevs...)
+			s.Linux.Resources.Devices = append(s.Linux.Resources.Devices, rngPermissions...)
+		}
+
 		for _, req := range c.HostConfig.DeviceRequests {
 			if err := daemon.handleDevice(req, s); err != nil {
 				return err
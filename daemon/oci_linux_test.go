@@ -94,7 +94,8 @@ func TestCreateSpecPreservesCDIAdditionalGIDs(t *testing.T) {
 	t.Cleanup(func() {
 		deviceDrivers = origDeviceDrivers
 	})
-	RegisterCDIDriver(cdiDir)
+	_, err = RegisterCDIDriver(false, cdiDir)
+	assert.NilError(t, err)
 
 	c := &container.Container{
 		Config: &containertypes.Config{},
@@ -164,6 +165,37 @@ func TestIpcPrivateVsReadonly(t *testing.T) {
 	}
 }
 
+// TestShmOptions checks that HostConfig.ShmOptions is applied to the
+// /dev/shm mount in the OCI spec, replacing the daemon's default options.
+func TestShmOptions(t *testing.T) {
+	skip.If(t, os.Getuid() != 0, "skipping test that requires root")
+	c := &container.Container{
+		ShmPath: "foobar", // non-empty, for c.IpcMounts() to work
+		HostConfig: &containertypes.HostConfig{
+			IpcMode:    containertypes.IPCModeShareable, // default mode
+			ShmSize:    1024,
+			ShmOptions: []string{"noexec", "nosuid", "mode=1770"},
+		},
+	}
+	d := setupFakeDaemon(t, c)
+
+	s, err := d.createSpec(t.Context(), &configStore{}, c, nil)
+	assert.NilError(t, err)
+
+	var found bool
+	for _, m := range s.Mounts {
+		if m.Destination != "/dev/shm" {
+			continue
+		}
+		found = true
+		assert.Check(t, is.Contains(m.Options, "noexec"))
+		assert.Check(t, is.Contains(m.Options, "nosuid"))
+		assert.Check(t, is.Contains(m.Options, "mode=1770"))
+		assert.Check(t, is.Contains(m.Options, "size=1024"))
+	}
+	assert.Check(t, found, "did not find /dev/shm mount in OCI spec")
+}
+
 // TestSysctlOverride ensures that any implicit sysctls (such as
 // Config.Domainname) are overridden by an explicit sysctl in the HostConfig.
 func TestSysctlOverride(t *testing.T) {
@@ -218,6 +250,66 @@ func TestSysctlOverride(t *testing.T) {
 	assert.Equal(t, s.Linux.Sysctl["net.ipv4.ping_group_range"], "0 2147483647")
 }
 
+// TestInitArgs ensures that HostConfig.InitArgs reach the init process when
+// init is enabled, and that they're ignored (with a warning) when it's not.
+func TestInitArgs(t *testing.T) {
+	ctx := t.Context()
+	initEnabled := true
+	c := &container.Container{
+		Path: "/bin/sh",
+		Args: []string{"-c", "true"},
+		HostConfig: &containertypes.HostConfig{
+			PidMode:  containertypes.PidMode("private"),
+			Init:     &initEnabled,
+			InitArgs: []string{"--foo", "--bar=baz"},
+		},
+	}
+	d := setupFakeDaemon(t, c)
+	daemonCfg := &configStore{Config: config.Config{InitPath: "/usr/libexec/docker/docker-init"}}
+
+	s, err := d.createSpec(ctx, daemonCfg, c, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, is.DeepEqual(s.Process.Args, []string{
+		inContainerInitPath, "--foo", "--bar=baz", "--", "/bin/sh", "-c", "true",
+	}))
+
+	// With init disabled, InitArgs has no effect on the process args.
+	initEnabled = false
+	s, err = d.createSpec(ctx, daemonCfg, c, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, is.DeepEqual(s.Process.Args, []string{"/bin/sh", "-c", "true"}))
+}
+
+// TestTimeNsOffset ensures that HostConfig.TimeNsOffsetSeconds is translated
+// into an OCI time namespace offset for both the boottime and monotonic
+// clocks, and left unset when TimeNsOffsetSeconds is nil.
+func TestTimeNsOffset(t *testing.T) {
+	ctx := t.Context()
+	c := &container.Container{
+		Path:       "/bin/sh",
+		Args:       []string{"-c", "true"},
+		HostConfig: &containertypes.HostConfig{},
+	}
+	d := setupFakeDaemon(t, c)
+
+	sysInfo, err := d.RawSysInfo()
+	assert.NilError(t, err)
+	skip.If(t, !sysInfo.TimeNamespaces, "kernel does not support time namespaces")
+
+	s, err := d.createSpec(ctx, &configStore{}, c, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, s.Linux.TimeOffsets == nil)
+
+	offset := int64(3600)
+	c.HostConfig.TimeNsOffsetSeconds = &offset
+	s, err = d.createSpec(ctx, &configStore{}, c, nil)
+	assert.NilError(t, err)
+	assert.Assert(t, is.DeepEqual(s.Linux.TimeOffsets, map[string]specs.LinuxTimeOffset{
+		"boottime":  {Secs: offset},
+		"monotonic": {Secs: offset},
+	}))
+}
+
 // TestSysctlOverrideHost ensures that any implicit network sysctls are not set
 // with host networking
 func TestSysctlOverrideHost(t *testing.T) {
@@ -0,0 +1,33 @@
+// Package cpuquota holds CPU quota/period consistency validation shared
+// between the container-create path and the classic builder's build-options
+// path, so both apply the same rules.
+package cpuquota
+
+import "fmt"
+
+// linuxDefaultCPUPeriod is the CFS period, in microseconds, the kernel uses
+// when a cgroup doesn't set cpu.cfs_period_us explicitly. It mirrors what
+// the kernel (and runc) fall back to when CPUPeriod is left unset.
+const linuxDefaultCPUPeriod = 100000
+
+// ValidateCPUQuotaPeriod checks that cpuPeriod and cpuQuota are internally
+// consistent, on top of the range checks callers are expected to have
+// already applied to each value individually. A quota is valid on its own
+// (the kernel falls back to the default 100ms period), but the quota/period
+// ratio must not imply more CPU time than the host has available, since the
+// CFS scheduler would simply never let the cgroup use the excess and this
+// almost always indicates the values were confused. numCPU is the number of
+// CPUs available on the host.
+func ValidateCPUQuotaPeriod(cpuPeriod, cpuQuota int64, numCPU int) error {
+	if cpuQuota <= 0 {
+		return nil
+	}
+	period := cpuPeriod
+	if period <= 0 {
+		period = linuxDefaultCPUPeriod
+	}
+	if maxQuota := period * int64(numCPU); cpuQuota > maxQuota {
+		return fmt.Errorf("CPU quota of %d with CPU period of %d would require more CPU time than the %d CPUs available on this host provide (%d) in a single period", cpuQuota, period, numCPU, maxQuota)
+	}
+	return nil
+}
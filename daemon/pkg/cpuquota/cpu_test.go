@@ -0,0 +1,60 @@
+package cpuquota
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestValidateCPUQuotaPeriod(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		cpuPeriod int64
+		cpuQuota  int64
+		numCPU    int
+		expectErr string
+	}{
+		{
+			name:      "no quota set",
+			cpuPeriod: 100000,
+			numCPU:    4,
+		},
+		{
+			name:      "valid quota and period",
+			cpuPeriod: 100000,
+			cpuQuota:  150000,
+			numCPU:    4,
+		},
+		{
+			name:     "quota without period is valid and uses the kernel default period",
+			cpuQuota: 50000,
+			numCPU:   4,
+		},
+		{
+			name:      "quota without period exceeding the default-period ratio",
+			cpuQuota:  500000,
+			numCPU:    4,
+			expectErr: "would require more CPU time",
+		},
+		{
+			name:      "quota implies more CPUs than available",
+			cpuPeriod: 100000,
+			cpuQuota:  500000,
+			numCPU:    4,
+			expectErr: "would require more CPU time",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateCPUQuotaPeriod(tc.cpuPeriod, tc.cpuQuota, tc.numCPU)
+			if tc.expectErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.expectErr)
+			}
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package opts
 
 import (
+	"bufio"
 	"net"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
 
@@ -163,3 +165,32 @@ func ValidateExtraHost(val string) (string, error) {
 	}
 	return val, nil
 }
+
+// ParseExtraHostsFile reads path and returns its entries as a list of
+// "name:ip" strings, in the same form and validated the same way as
+// --add-host entries. Blank lines and lines starting with "#" are ignored.
+// An invalid line is reported with its 1-indexed line number.
+func ParseExtraHostsFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read extra-hosts file")
+	}
+	defer f.Close()
+
+	var hosts []string
+	scanner := bufio.NewScanner(f)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if _, err := ValidateExtraHost(line); err != nil {
+			return nil, errors.Wrapf(err, "invalid entry on line %d of extra-hosts file %s", lineNum, path)
+		}
+		hosts = append(hosts, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read extra-hosts file")
+	}
+	return hosts, nil
+}
@@ -2,6 +2,9 @@
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -200,3 +203,47 @@ func TestValidateExtraHosts(t *testing.T) {
 		}
 	}
 }
+
+func TestParseExtraHostsFile(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("valid entries, comments and blank lines", func(t *testing.T) {
+		path := filepath.Join(dir, "valid")
+		content := "myhost:192.168.0.1\n\n# a comment\nthathost:10.0.2.1\n"
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		hosts, err := ParseExtraHostsFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := []string{"myhost:192.168.0.1", "thathost:10.0.2.1"}
+		if !slices.Equal(hosts, expected) {
+			t.Fatalf("expected %v, got %v", expected, hosts)
+		}
+	})
+
+	t.Run("invalid entry reports its line number", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid")
+		content := "myhost:192.168.0.1\nbadline\n"
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := ParseExtraHostsFile(path)
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		if !strings.Contains(err.Error(), "line 2") {
+			t.Fatalf("expected error to mention line 2, got %v", err)
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := ParseExtraHostsFile(filepath.Join(dir, "does-not-exist"))
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+	})
+}
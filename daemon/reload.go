@@ -13,6 +13,7 @@
 	"github.com/mitchellh/copystructure"
 	"github.com/moby/moby/api/types/events"
 	"github.com/moby/moby/v2/daemon/config"
+	"github.com/moby/moby/v2/daemon/internal/metrics"
 	"github.com/moby/moby/v2/daemon/pkg/opts"
 )
 
@@ -90,6 +91,11 @@ func (tx *reloadTxn) Rollback() error {
 func (daemon *Daemon) Reload(conf *config.Config) error {
 	daemon.configReload.Lock()
 	defer daemon.configReload.Unlock()
+
+	if err := checkImmutableFieldsUnchanged(&daemon.config().Config, conf); err != nil {
+		return err
+	}
+
 	copied, err := copystructure.Copy(daemon.config().Config)
 	if err != nil {
 		return err
@@ -136,6 +142,37 @@ func (daemon *Daemon) Reload(conf *config.Config) error {
 	return err
 }
 
+// immutableReloadFields lists the daemon configuration options, by their
+// JSON key, that cannot be changed once the daemon has started. Reload
+// refuses the entire reload, leaving the running configuration untouched, if
+// the new configuration explicitly sets any of these to a different value.
+var immutableReloadFields = []struct {
+	name string
+	get  func(*config.Config) string
+}{
+	{"data-root", func(c *config.Config) string { return c.Root }},
+	{"exec-root", func(c *config.Config) string { return c.ExecRoot }},
+	{"containerd", func(c *config.Config) string { return c.ContainerdAddr }},
+	{"cri-containerd", func(c *config.Config) string { return strconv.FormatBool(c.CriContainerd) }},
+	{"containerd-namespace", func(c *config.Config) string { return c.ContainerdNamespace }},
+	{"containerd-plugin-namespace", func(c *config.Config) string { return c.ContainerdPluginNamespace }},
+}
+
+// checkImmutableFieldsUnchanged returns an error naming the first immutable
+// field (see immutableReloadFields) that conf explicitly sets to a value
+// different from the one in current.
+func checkImmutableFieldsUnchanged(current *config.Config, conf *config.Config) error {
+	for _, f := range immutableReloadFields {
+		if !conf.IsValueSet(f.name) {
+			continue
+		}
+		if oldVal, newVal := f.get(current), f.get(conf); oldVal != newVal {
+			return fmt.Errorf("unable to reload configuration: %q cannot be changed at runtime (currently %q, requested %q); restart the daemon to apply this change", f.name, oldVal, newVal)
+		}
+	}
+	return nil
+}
+
 func marshalAttributeSlice(v []string) string {
 	if v == nil {
 		return "[]"
@@ -292,6 +329,11 @@ func (daemon *Daemon) reloadFeatures(txn *reloadTxn, newCfg *configStore, conf *
 
 	// prepare reload event attributes with updatable configurations
 	attributes["features"] = fmt.Sprintf("%v", newCfg.Features)
+
+	txn.OnCommit(func() error {
+		metrics.SetFeatureFlags(newCfg.Features)
+		return nil
+	})
 	return nil
 }
 
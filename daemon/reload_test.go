@@ -74,6 +74,30 @@ func TestDaemonReloadLabels(t *testing.T) {
 	}
 }
 
+func TestDaemonReloadImmutableDataRoot(t *testing.T) {
+	daemon := newDaemonForReloadT(t, &config.Config{
+		CommonConfig: config.CommonConfig{
+			Root:   "/var/lib/docker",
+			Labels: []string{"foo:bar"},
+		},
+	})
+	muteLogs(t)
+
+	newConfig := &config.Config{
+		CommonConfig: config.CommonConfig{
+			Root:      "/mnt/docker",
+			Labels:    []string{"foo:bar"},
+			ValuesSet: map[string]any{"data-root": "/mnt/docker"},
+		},
+	}
+
+	err := daemon.Reload(newConfig)
+	assert.ErrorContains(t, err, `"data-root" cannot be changed at runtime`)
+
+	// The running configuration must be left untouched.
+	assert.Equal(t, daemon.config().Root, "/var/lib/docker")
+}
+
 func TestDaemonReloadMirrors(t *testing.T) {
 	daemon := &Daemon{
 		imageService: images.NewImageService(t.Context(), images.ImageServiceConfig{}),
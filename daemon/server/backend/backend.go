@@ -27,6 +27,11 @@ type ContainerCreateConfig struct {
 // to perform.
 type ContainerRmConfig struct {
 	ForceRemove, RemoveVolume, RemoveLink bool
+	// ForceDependents allows removing a container that has running
+	// dependents connected through --network=container:<name> by stopping
+	// those dependents first. Without it, removal is refused while such
+	// dependents are running.
+	ForceDependents bool
 }
 
 // ContainerAttachConfig holds the streams to use when connecting to a container to view logs.
@@ -90,7 +95,22 @@ type LogSelector struct {
 type ContainerStatsConfig struct {
 	Stream    bool
 	OneShot   bool
+	Flatten   bool
 	OutStream func() io.Writer
+
+	// Interval throttles how often a sample is emitted while streaming,
+	// down-sampling the collector's own (fixed) cadence. Zero means emit
+	// every sample as collected. It has no effect when Stream is false.
+	Interval time.Duration
+
+	// NoPreCPU skips the extra sample normally taken to populate
+	// PreCPUStats on the first (and, since Stream is false, only) result,
+	// returning immediately with PreCPUStats zero-valued instead. CPU
+	// percentage can't be derived from a single NoPreCPU snapshot; the
+	// caller must compute deltas across its own polls. It has no effect
+	// when Stream is true, since a previous sample is already available
+	// from the stream itself.
+	NoPreCPU bool
 }
 
 // ContainerInspectOptions defines options for the backend.ContainerInspect
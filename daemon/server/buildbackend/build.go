@@ -111,6 +111,27 @@ type BuildOptions struct {
 	// Outputs defines configurations for exporting build results. Only supported
 	// in BuildKit mode
 	Outputs []BuildOutput
+	// Attestations lists the attestation kinds to generate for the build
+	// (e.g. "sbom", "provenance"). Only supported in BuildKit mode; the
+	// classic builder rejects a build that requests any.
+	Attestations []string
+	// Compression is the compression algorithm applied to the produced
+	// image layers, e.g. "gzip", "estargz", "zstd", or "uncompressed". When
+	// empty, the builder's default applies. It's used as a default for any
+	// Outputs entry that doesn't already set its own "compression" attr.
+	// Only supported in BuildKit mode.
+	Compression string
+	// CompressionLevel is the compression level to use with Compression.
+	// Valid ranges depend on the chosen algorithm. It's used as a default
+	// for any Outputs entry that doesn't already set its own
+	// "compression-level" attr. Only supported in BuildKit mode.
+	CompressionLevel *int64
+	// StrictBuildArgs turns an unused --build-arg (one with no matching ARG
+	// instruction anywhere in the Dockerfile) into a build failure, instead
+	// of just the classic builder's usual warning. A build-arg with a nil
+	// value (mentioned but unset, e.g. "--build-arg FOO") still counts as
+	// used for this check as long as it's referenced by an ARG instruction.
+	StrictBuildArgs bool
 }
 
 // BuildOutput defines configuration for exporting a build result
@@ -3,26 +3,37 @@
 import (
 	"context"
 	"net/http"
+	"sync/atomic"
 )
 
 // ExperimentalMiddleware is a the middleware in charge of adding the
 // 'Docker-Experimental' header to every outgoing request
 type ExperimentalMiddleware struct {
-	experimental string
+	experimental atomic.Bool
 }
 
 // NewExperimentalMiddleware creates a new ExperimentalMiddleware
-func NewExperimentalMiddleware(experimentalEnabled bool) ExperimentalMiddleware {
-	if experimentalEnabled {
-		return ExperimentalMiddleware{"true"}
-	}
-	return ExperimentalMiddleware{"false"}
+func NewExperimentalMiddleware(experimentalEnabled bool) *ExperimentalMiddleware {
+	m := &ExperimentalMiddleware{}
+	m.experimental.Store(experimentalEnabled)
+	return m
+}
+
+// SetExperimental updates whether the 'Docker-Experimental' header reports
+// experimental mode as enabled, allowing it to be toggled on config reload
+// without restarting the daemon.
+func (e *ExperimentalMiddleware) SetExperimental(experimentalEnabled bool) {
+	e.experimental.Store(experimentalEnabled)
 }
 
 // WrapHandler returns a new handler function wrapping the previous one in the request chain.
-func (e ExperimentalMiddleware) WrapHandler(handler func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error) func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+func (e *ExperimentalMiddleware) WrapHandler(handler func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error) func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	return func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
-		w.Header().Set("Docker-Experimental", e.experimental)
+		if e.experimental.Load() {
+			w.Header().Set("Docker-Experimental", "true")
+		} else {
+			w.Header().Set("Docker-Experimental", "false")
+		}
 		return handler(ctx, w, r, vars)
 	}
 }
@@ -9,15 +9,46 @@
 
 // Backend abstracts an image builder whose only purpose is to build an image referenced by an imageID.
 type Backend interface {
-	// Build a Docker image returning the id of the image
+	// Build a Docker image returning the id of the image and a summary of
+	// how much of the build was satisfied from cache.
 	// TODO: make this return a reference instead of string
-	Build(context.Context, buildbackend.BuildConfig) (string, error)
+	Build(context.Context, buildbackend.BuildConfig) (string, *build.CacheSummary, error)
 
 	// PruneCache prunes the build cache.
 	PruneCache(context.Context, buildbackend.CachePruneOptions) (*build.CachePruneReport, error)
 	Cancel(context.Context, string) error
+
+	// CancelSession cancels every in-flight build associated with sessionID
+	// and returns how many builds were cancelled.
+	CancelSession(ctx context.Context, sessionID string) (int, error)
 }
 
 type experimentalProvider interface {
 	HasExperimental() bool
+
+	// HasBuildSquashEnabled returns whether `docker build --squash` is
+	// permitted, either because it was enabled directly or because
+	// HasExperimental is enabled.
+	HasBuildSquashEnabled() bool
+
+	// CheckBuildMinFreeInodes checks that the build root's filesystem has
+	// at least the configured minimum number of free inodes, if configured.
+	// It returns nil if the check is disabled or passes.
+	CheckBuildMinFreeInodes() error
+
+	// BuildContextExcludePatterns returns the daemon-wide .dockerignore-style
+	// patterns to strip from every received build context, or nil if none
+	// are configured.
+	BuildContextExcludePatterns() []string
+
+	// BuildSymlinkPolicy returns the daemon-configured policy for how
+	// symlinks that resolve outside the build context are handled: reject,
+	// follow-within, or preserve (the default).
+	BuildSymlinkPolicy() string
+
+	// SensitiveBuildArgPatterns returns additional substrings, beyond the
+	// router's own built-in defaults, used to decide whether a --build-arg's
+	// value looks sensitive and should be masked in logged or echoed-back
+	// build options.
+	SensitiveBuildArgPatterns() []string
 }
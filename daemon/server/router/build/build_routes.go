@@ -7,7 +7,9 @@
 	"encoding/json"
 	"fmt"
 	"io"
+	"mime"
 	"net/http"
+	"regexp"
 	"runtime"
 	"strconv"
 	"strings"
@@ -22,7 +24,9 @@
 	"github.com/moby/moby/v2/daemon/internal/progress"
 	"github.com/moby/moby/v2/daemon/internal/streamformatter"
 	"github.com/moby/moby/v2/daemon/internal/versions"
+	"github.com/moby/moby/v2/daemon/pkg/cpuquota"
 	"github.com/moby/moby/v2/daemon/server/buildbackend"
+	"github.com/moby/moby/v2/daemon/server/httpstatus"
 	"github.com/moby/moby/v2/daemon/server/httputils"
 	"github.com/moby/moby/v2/pkg/ioutils"
 	"github.com/pkg/errors"
@@ -54,6 +58,7 @@ func newImageBuildOptions(ctx context.Context, r *http.Request) (*buildbackend.B
 		Tags:           r.Form["t"],
 		ExtraHosts:     r.Form["extrahosts"],
 		SecurityOpt:    r.Form["securityopt"],
+		Attestations:   r.Form["attestations"],
 		Squash:         httputils.BoolValue(r, "squash"),
 		Target:         r.FormValue("target"),
 		RemoteContext:  r.FormValue("remote"),
@@ -88,6 +93,16 @@ func newImageBuildOptions(ctx context.Context, r *http.Request) (*buildbackend.B
 		}
 	}
 
+	if versions.GreaterThanOrEqualTo(version, "1.48") {
+		if err := setCompressionOptions(r, options); err != nil {
+			return nil, err
+		}
+	}
+
+	if versions.GreaterThanOrEqualTo(version, "1.51") {
+		options.StrictBuildArgs = httputils.BoolValue(r, "strictbuildargs")
+	}
+
 	if s := r.Form.Get("shmsize"); s != "" {
 		shmSize, err := strconv.ParseInt(s, 10, 64)
 		if err != nil {
@@ -155,9 +170,52 @@ func newImageBuildOptions(ctx context.Context, r *http.Request) (*buildbackend.B
 		options.Version = v
 	}
 
+	if err := cpuquota.ValidateCPUQuotaPeriod(options.CPUPeriod, options.CPUQuota, runtime.NumCPU()); err != nil {
+		return nil, invalidParam{err}
+	}
+
 	return options, nil
 }
 
+// compressionLevelRanges gives the valid [min, max] compression level for
+// each compression algorithm supported by the exporter.
+var compressionLevelRanges = map[string][2]int64{
+	"gzip":    {0, 9},
+	"estargz": {0, 9},
+	"zstd":    {0, 22},
+}
+
+// setCompressionOptions parses and validates the "compression" and
+// "compressionlevel" form values, applied as defaults to the compression of
+// any produced image layers that don't already specify their own via Outputs.
+func setCompressionOptions(r *http.Request, options *buildbackend.BuildOptions) error {
+	compression := r.FormValue("compression")
+	if compression != "" {
+		if _, ok := compressionLevelRanges[compression]; !ok && compression != "uncompressed" {
+			return invalidParam{errors.Errorf("unsupported compression: %q", compression)}
+		}
+		options.Compression = compression
+	}
+
+	levelStr := r.FormValue("compressionlevel")
+	if levelStr == "" {
+		return nil
+	}
+	level, err := strconv.ParseInt(levelStr, 10, 64)
+	if err != nil {
+		return invalidParam{errors.Wrap(err, "invalid compressionlevel")}
+	}
+	rng, ok := compressionLevelRanges[compression]
+	if !ok {
+		return invalidParam{errors.Errorf("compressionlevel requires a compression algorithm that supports levels, got %q", compression)}
+	}
+	if level < rng[0] || level > rng[1] {
+		return invalidParam{errors.Errorf("compressionlevel %d out of range for %s: must be between %d and %d", level, compression, rng[0], rng[1])}
+	}
+	options.CompressionLevel = &level
+	return nil
+}
+
 func parseVersion(s string) (build.BuilderVersion, error) {
 	switch build.BuilderVersion(s) {
 	case build.BuilderV1:
@@ -196,30 +254,37 @@ func (br *buildRouter) postPrune(ctx context.Context, w http.ResponseWriter, r *
 
 	version := httputils.VersionFromContext(ctx)
 	if versions.GreaterThanOrEqualTo(version, "1.48") {
-		if bs, err := parseBytesFromFormValue("reserved-space"); err != nil {
+		reservedSpace, err := parseBytesFromFormValue("reserved-space")
+		if err != nil {
 			return err
-		} else {
-			if bs == 0 {
-				// Deprecated parameter. Only checked if reserved-space is not used.
-				bs, err = parseBytesFromFormValue("keep-storage")
-				if err != nil {
-					return err
-				}
-			}
-			opts.ReservedSpace = bs
 		}
+		keepStorage, err := parseBytesFromFormValue("keep-storage")
+		if err != nil {
+			return err
+		}
+		if reservedSpace != 0 && keepStorage != 0 && reservedSpace != keepStorage {
+			return invalidParam{errors.Errorf("conflicting options: keep-storage (%d) and reserved-space (%d) are both set to different non-zero values; keep-storage is deprecated, use reserved-space instead", keepStorage, reservedSpace)}
+		}
+		if reservedSpace == 0 {
+			// Deprecated parameter. Only checked if reserved-space is not used.
+			reservedSpace = keepStorage
+		}
+		opts.ReservedSpace = reservedSpace
 
-		if bs, err := parseBytesFromFormValue("max-used-space"); err != nil {
+		maxUsedSpace, err := parseBytesFromFormValue("max-used-space")
+		if err != nil {
 			return err
-		} else {
-			opts.MaxUsedSpace = bs
 		}
+		opts.MaxUsedSpace = maxUsedSpace
 
-		if bs, err := parseBytesFromFormValue("min-free-space"); err != nil {
+		minFreeSpace, err := parseBytesFromFormValue("min-free-space")
+		if err != nil {
 			return err
-		} else {
-			opts.MinFreeSpace = bs
 		}
+		if minFreeSpace != 0 && maxUsedSpace != 0 && minFreeSpace > maxUsedSpace {
+			return invalidParam{errors.Errorf("min-free-space (%d) cannot be greater than max-used-space (%d)", minFreeSpace, maxUsedSpace)}
+		}
+		opts.MinFreeSpace = minFreeSpace
 	} else {
 		// Only keep-storage was valid in pre-1.48 versions.
 		if bs, err := parseBytesFromFormValue("keep-storage"); err != nil {
@@ -236,15 +301,30 @@ func (br *buildRouter) postPrune(ctx context.Context, w http.ResponseWriter, r *
 	return httputils.WriteJSON(w, http.StatusOK, report)
 }
 
+// buildCancelSessionResponse is the response body for a
+// POST /build/cancel?session=<id> request.
+type buildCancelSessionResponse struct {
+	Cancelled int `json:"cancelled"`
+}
+
 func (br *buildRouter) postCancel(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
 	w.Header().Set("Content-Type", "application/json")
 
 	id := r.FormValue("id")
-	if id == "" {
+	if id != "" {
+		return br.backend.Cancel(ctx, id)
+	}
+
+	sessionID := r.FormValue("session")
+	if sessionID == "" {
 		return invalidParam{errors.New("build ID not provided")}
 	}
 
-	return br.backend.Cancel(ctx, id)
+	cancelled, err := br.backend.CancelSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	return httputils.WriteJSON(w, http.StatusOK, buildCancelSessionResponse{Cancelled: cancelled})
 }
 
 func (br *buildRouter) postBuild(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
@@ -276,6 +356,10 @@ func (br *buildRouter) postBuild(ctx context.Context, w http.ResponseWriter, r *
 		// Do not write the error in the http output if it's still empty.
 		// This prevents from writing a 200(OK) when there is an internal error.
 		if !output.Flushed() {
+			if acceptsBuildErrorJSON(r) {
+				writeBuildErrorJSON(w, err)
+				return nil
+			}
 			return err
 		}
 		_, err = output.Write(streamformatter.FormatError(err))
@@ -292,10 +376,24 @@ func (br *buildRouter) postBuild(ctx context.Context, w http.ResponseWriter, r *
 	}
 	buildOptions.AuthConfigs = getAuthConfigs(r.Header)
 
-	if buildOptions.Squash && !br.daemon.HasExperimental() {
+	if buildOptions.Squash && !br.daemon.HasBuildSquashEnabled() {
 		return invalidParam{errors.New("squash is only supported with experimental mode")}
 	}
 
+	if err := br.daemon.CheckBuildMinFreeInodes(); err != nil {
+		return errf(err)
+	}
+
+	redactedOptions := redactBuildOptions(buildOptions, br.daemon.SensitiveBuildArgPatterns())
+	log.G(ctx).WithField("options", redactedOptions).Debug("build options")
+
+	if httputils.BoolValue(r, "debug") {
+		aux := &streamformatter.AuxFormatter{Writer: output}
+		if err := aux.Emit("resolvedBuildOptions", redactedOptions); err != nil {
+			log.G(ctx).WithError(err).Warn("failed to emit resolved build options")
+		}
+	}
+
 	out := io.Writer(output)
 	if buildOptions.SuppressOutput {
 		out = notVerboseBuffer
@@ -310,8 +408,18 @@ func (br *buildRouter) postBuild(ctx context.Context, w http.ResponseWriter, r *
 
 	wantAux := versions.GreaterThanOrEqualTo(version, "1.30")
 
-	imgID, err := br.backend.Build(ctx, buildbackend.BuildConfig{
-		Source:         r.Body,
+	source, err := filterBuildContext(ctx, r.Body, br.daemon.BuildContextExcludePatterns())
+	if err != nil {
+		return errf(err)
+	}
+
+	source, err = filterBuildContextSymlinks(ctx, source, br.daemon.BuildSymlinkPolicy())
+	if err != nil {
+		return errf(err)
+	}
+
+	imgID, cacheSummary, err := br.backend.Build(ctx, buildbackend.BuildConfig{
+		Source:         source,
 		Options:        buildOptions,
 		ProgressWriter: buildProgressWriter(out, wantAux, createProgressReader),
 	})
@@ -327,9 +435,117 @@ func (br *buildRouter) postBuild(ctx context.Context, w http.ResponseWriter, r *
 	if buildOptions.SuppressOutput {
 		_, _ = fmt.Fprintln(streamformatter.NewStdoutWriter(output), imgID)
 	}
+
+	// Emit the cache summary directly to the response writer so that it's
+	// available to the client even when output is suppressed with -q.
+	if cacheSummary != nil {
+		aux := &streamformatter.AuxFormatter{Writer: output}
+		_ = aux.Emit("cacheSummary", *cacheSummary)
+	}
 	return nil
 }
 
+// buildErrorJSONMediaType is the opt-in Accept value that makes postBuild
+// report an error that occurred before any output was flushed as a single
+// well-formed JSON object with the error's HTTP status, instead of returning
+// it through the API's usual error response. An error that occurs after
+// output was flushed is still embedded, streamformatter-formatted, into the
+// NDJSON output stream, whatever the Accept header says.
+const buildErrorJSONMediaType = "application/vnd.docker.build.error+json"
+
+// buildErrorJSON is the body written for buildErrorJSONMediaType responses.
+type buildErrorJSON struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// acceptsBuildErrorJSON reports whether the client opted in to
+// buildErrorJSONMediaType via the Accept header.
+func acceptsBuildErrorJSON(r *http.Request) bool {
+	for _, accept := range strings.Split(r.Header.Get("Accept"), ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(accept))
+		if err == nil && mediaType == buildErrorJSONMediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// writeBuildErrorJSON writes err as a buildErrorJSON body. It must only be
+// called before anything has been written to w, since it sets the status
+// code and, unlike the streaming error path, doesn't need to work around
+// a response that may already have been flushed with a 200 status.
+func writeBuildErrorJSON(w http.ResponseWriter, err error) {
+	statusCode := httpstatus.FromError(err)
+	detail := ""
+	if cause := errors.Cause(err); cause.Error() != err.Error() {
+		detail = cause.Error()
+	}
+	w.Header().Set("Content-Type", buildErrorJSONMediaType)
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(buildErrorJSON{
+		Code:    statusCode,
+		Message: err.Error(),
+		Detail:  detail,
+	})
+}
+
+// sensitiveBuildArgPattern matches build-arg names that commonly carry
+// secrets, so their values can be masked before being logged or echoed back
+// for debugging.
+var sensitiveBuildArgPattern = regexp.MustCompile(`(?i)(key|token|secret|password|passwd|credential)`)
+
+// isSensitiveBuildArgName reports whether name looks like it holds a secret,
+// either because it matches sensitiveBuildArgPattern or because it contains
+// one of the daemon's configured extraPatterns (case-insensitive).
+func isSensitiveBuildArgName(name string, extraPatterns []string) bool {
+	if sensitiveBuildArgPattern.MatchString(name) {
+		return true
+	}
+	lower := strings.ToLower(name)
+	for _, pattern := range extraPatterns {
+		if pattern != "" && strings.Contains(lower, strings.ToLower(pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactBuildOptions returns a copy of opts suitable for logging or echoing
+// back to the client for debugging: the build context reader is dropped,
+// registry credentials are stripped, and build-arg values whose name looks
+// sensitive (per isSensitiveBuildArgName) are masked. The real values in
+// opts itself are left untouched, so the build still receives them.
+func redactBuildOptions(opts *buildbackend.BuildOptions, extraSensitivePatterns []string) *buildbackend.BuildOptions {
+	redacted := *opts
+	redacted.Context = nil
+
+	if len(redacted.AuthConfigs) > 0 {
+		authConfigs := make(map[string]registry.AuthConfig, len(redacted.AuthConfigs))
+		for name, auth := range redacted.AuthConfigs {
+			auth.Password = ""
+			auth.IdentityToken = ""
+			authConfigs[name] = auth
+		}
+		redacted.AuthConfigs = authConfigs
+	}
+
+	if len(redacted.BuildArgs) > 0 {
+		buildArgs := make(map[string]*string, len(redacted.BuildArgs))
+		for name, value := range redacted.BuildArgs {
+			if value != nil && isSensitiveBuildArgName(name, extraSensitivePatterns) {
+				masked := "***"
+				value = &masked
+			}
+			buildArgs[name] = value
+		}
+		redacted.BuildArgs = buildArgs
+	}
+
+	return &redacted
+}
+
 func getAuthConfigs(header http.Header) map[string]registry.AuthConfig {
 	authConfigs := map[string]registry.AuthConfig{}
 	authConfigsEncoded := header.Get("X-Registry-Config")
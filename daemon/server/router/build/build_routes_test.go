@@ -0,0 +1,347 @@
+package build
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"runtime"
+	"testing"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/moby/moby/api/types/build"
+	"github.com/moby/moby/api/types/registry"
+	"github.com/moby/moby/v2/daemon/server/buildbackend"
+	"github.com/moby/moby/v2/daemon/server/httputils"
+	"github.com/moby/moby/v2/errdefs"
+	"github.com/pkg/errors"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+// fakePruneBackend is a minimal Backend used to exercise postPrune's form
+// validation without needing a real build controller.
+type fakePruneBackend struct {
+	Backend
+	pruneOpts buildbackend.CachePruneOptions
+}
+
+func (b *fakePruneBackend) PruneCache(_ context.Context, opts buildbackend.CachePruneOptions) (*build.CachePruneReport, error) {
+	b.pruneOpts = opts
+	return &build.CachePruneReport{}, nil
+}
+
+// fakeCancelBackend is a minimal Backend used to exercise postCancel's
+// id/session dispatch without needing a real build controller.
+type fakeCancelBackend struct {
+	Backend
+	cancelledID        string
+	cancelSessionCalls []string
+	cancelSessionCount int
+}
+
+func (b *fakeCancelBackend) Cancel(_ context.Context, id string) error {
+	b.cancelledID = id
+	return nil
+}
+
+func (b *fakeCancelBackend) CancelSession(_ context.Context, sessionID string) (int, error) {
+	b.cancelSessionCalls = append(b.cancelSessionCalls, sessionID)
+	return b.cancelSessionCount, nil
+}
+
+func TestRedactBuildOptions(t *testing.T) {
+	apiKey := "s3cr3t"
+	target := "builder"
+	opts := &buildbackend.BuildOptions{
+		Target: target,
+		BuildArgs: map[string]*string{
+			"API_KEY": &apiKey,
+			"TARGET":  &target,
+		},
+		AuthConfigs: map[string]registry.AuthConfig{
+			"registry.example.com": {
+				Username:      "user",
+				Password:      "hunter2",
+				IdentityToken: "token",
+			},
+		},
+	}
+
+	redacted := redactBuildOptions(opts, nil)
+
+	assert.Equal(t, redacted.Target, "builder")
+	assert.Assert(t, redacted.BuildArgs["API_KEY"] != nil)
+	assert.Equal(t, *redacted.BuildArgs["API_KEY"], "***")
+	assert.Assert(t, redacted.BuildArgs["TARGET"] != nil)
+	assert.Equal(t, *redacted.BuildArgs["TARGET"], "builder")
+
+	auth := redacted.AuthConfigs["registry.example.com"]
+	assert.Equal(t, auth.Username, "user")
+	assert.Equal(t, auth.Password, "")
+	assert.Equal(t, auth.IdentityToken, "")
+
+	// The original options are left untouched.
+	assert.Equal(t, *opts.BuildArgs["API_KEY"], "s3cr3t")
+	assert.Equal(t, opts.AuthConfigs["registry.example.com"].Password, "hunter2")
+}
+
+func TestRedactBuildOptionsExtraSensitivePatterns(t *testing.T) {
+	license := "ABCD-1234"
+	target := "builder"
+	opts := &buildbackend.BuildOptions{
+		BuildArgs: map[string]*string{
+			"LICENSE_CODE": &license,
+			"TARGET":       &target,
+		},
+	}
+
+	// Not masked without the extra pattern configured.
+	redacted := redactBuildOptions(opts, nil)
+	assert.Equal(t, *redacted.BuildArgs["LICENSE_CODE"], "ABCD-1234")
+
+	redacted = redactBuildOptions(opts, []string{"license"})
+	assert.Equal(t, *redacted.BuildArgs["LICENSE_CODE"], "***")
+	assert.Equal(t, *redacted.BuildArgs["TARGET"], "builder")
+}
+
+func TestSetCompressionOptions(t *testing.T) {
+	newRequest := func(form url.Values) *http.Request {
+		return &http.Request{Form: form}
+	}
+
+	t.Run("unset leaves defaults untouched", func(t *testing.T) {
+		options := &buildbackend.BuildOptions{}
+		err := setCompressionOptions(newRequest(url.Values{}), options)
+		assert.NilError(t, err)
+		assert.Equal(t, options.Compression, "")
+		assert.Check(t, options.CompressionLevel == nil)
+	})
+
+	t.Run("valid algorithm and level are applied", func(t *testing.T) {
+		options := &buildbackend.BuildOptions{}
+		err := setCompressionOptions(newRequest(url.Values{
+			"compression":      {"zstd"},
+			"compressionlevel": {"19"},
+		}), options)
+		assert.NilError(t, err)
+		assert.Equal(t, options.Compression, "zstd")
+		assert.Assert(t, options.CompressionLevel != nil)
+		assert.Equal(t, *options.CompressionLevel, int64(19))
+	})
+
+	t.Run("uncompressed does not require a level", func(t *testing.T) {
+		options := &buildbackend.BuildOptions{}
+		err := setCompressionOptions(newRequest(url.Values{
+			"compression": {"uncompressed"},
+		}), options)
+		assert.NilError(t, err)
+		assert.Equal(t, options.Compression, "uncompressed")
+		assert.Check(t, options.CompressionLevel == nil)
+	})
+
+	t.Run("unsupported algorithm is rejected", func(t *testing.T) {
+		options := &buildbackend.BuildOptions{}
+		err := setCompressionOptions(newRequest(url.Values{
+			"compression": {"bzip2"},
+		}), options)
+		assert.Check(t, is.ErrorContains(err, "unsupported compression"))
+	})
+
+	t.Run("level out of range is rejected", func(t *testing.T) {
+		options := &buildbackend.BuildOptions{}
+		err := setCompressionOptions(newRequest(url.Values{
+			"compression":      {"gzip"},
+			"compressionlevel": {"42"},
+		}), options)
+		assert.Check(t, is.ErrorContains(err, "out of range"))
+	})
+
+	t.Run("level without a compression algorithm is rejected", func(t *testing.T) {
+		options := &buildbackend.BuildOptions{}
+		err := setCompressionOptions(newRequest(url.Values{
+			"compressionlevel": {"5"},
+		}), options)
+		assert.Check(t, is.ErrorContains(err, "requires a compression algorithm"))
+	})
+}
+
+func TestAcceptsBuildErrorJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept string
+		want   bool
+	}{
+		{name: "no accept header", accept: "", want: false},
+		{name: "exact match", accept: buildErrorJSONMediaType, want: true},
+		{name: "with q value", accept: buildErrorJSONMediaType + "; q=0.9", want: true},
+		{name: "one of several", accept: "text/plain, " + buildErrorJSONMediaType, want: true},
+		{name: "unrelated type", accept: "application/json", want: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/build", nil)
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+			assert.Check(t, is.Equal(acceptsBuildErrorJSON(req), tc.want))
+		})
+	}
+}
+
+func TestWriteBuildErrorJSON(t *testing.T) {
+	w := httptest.NewRecorder()
+	writeBuildErrorJSON(w, errdefs.InvalidParameter(errors.New("boom")))
+
+	assert.Check(t, is.Equal(w.Code, http.StatusBadRequest))
+	assert.Check(t, is.Equal(w.Header().Get("Content-Type"), buildErrorJSONMediaType))
+
+	var got buildErrorJSON
+	assert.NilError(t, json.Unmarshal(w.Body.Bytes(), &got))
+	assert.Check(t, is.Equal(got.Code, http.StatusBadRequest))
+	assert.Check(t, is.Equal(got.Message, "boom"))
+}
+
+// TestNewImageBuildOptionsCPUQuotaPeriod covers the CPU quota/period
+// consistency check applied to the classic builder's build options, using
+// the same validation as the container-create path.
+func TestNewImageBuildOptionsCPUQuotaPeriod(t *testing.T) {
+	tests := []struct {
+		name      string
+		form      url.Values
+		expectErr string
+	}{
+		{
+			name: "valid quota and period",
+			form: url.Values{
+				"cpuperiod": {"100000"},
+				"cpuquota":  {"150000"},
+			},
+		},
+		{
+			name: "quota without period is allowed",
+			form: url.Values{
+				"cpuquota": {"50000"},
+			},
+		},
+		{
+			name: "quota implies more CPUs than available",
+			form: url.Values{
+				"cpuperiod": {"100000"},
+				"cpuquota":  {fmt.Sprintf("%d", int64(runtime.NumCPU()+1)*100000)},
+			},
+			expectErr: "would require more CPU time",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/build?"+tc.form.Encode(), nil)
+			ctx := context.WithValue(context.Background(), httputils.APIVersionKey{}, "1.48")
+
+			_, err := newImageBuildOptions(ctx, req)
+			if tc.expectErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Check(t, is.ErrorContains(err, tc.expectErr))
+				assert.Check(t, cerrdefs.IsInvalidArgument(err))
+			}
+		})
+	}
+}
+
+func TestPostPruneConflictingSpaceOptions(t *testing.T) {
+	tests := []struct {
+		name      string
+		form      url.Values
+		expectErr string
+	}{
+		{
+			name: "min-free-space greater than max-used-space",
+			form: url.Values{
+				"min-free-space": {"200"},
+				"max-used-space": {"100"},
+			},
+			expectErr: "min-free-space (200) cannot be greater than max-used-space (100)",
+		},
+		{
+			name: "keep-storage and reserved-space conflict",
+			form: url.Values{
+				"keep-storage":   {"100"},
+				"reserved-space": {"200"},
+			},
+			expectErr: "keep-storage (100) and reserved-space (200) are both set to different non-zero values",
+		},
+		{
+			name: "matching keep-storage and reserved-space are allowed",
+			form: url.Values{
+				"keep-storage":   {"100"},
+				"reserved-space": {"100"},
+			},
+		},
+		{
+			name: "min-free-space equal to max-used-space is allowed",
+			form: url.Values{
+				"min-free-space": {"100"},
+				"max-used-space": {"100"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			br := &buildRouter{backend: &fakePruneBackend{}}
+
+			req := httptest.NewRequest(http.MethodPost, "/build/prune?"+tc.form.Encode(), nil)
+			ctx := context.WithValue(context.Background(), httputils.APIVersionKey{}, "1.48")
+			w := httptest.NewRecorder()
+
+			err := br.postPrune(ctx, w, req, nil)
+			if tc.expectErr == "" {
+				assert.NilError(t, err)
+			} else {
+				assert.Check(t, is.ErrorContains(err, tc.expectErr))
+				assert.Check(t, cerrdefs.IsInvalidArgument(err))
+			}
+		})
+	}
+}
+
+func TestPostCancel(t *testing.T) {
+	t.Run("cancels a single build by id", func(t *testing.T) {
+		backend := &fakeCancelBackend{}
+		br := &buildRouter{backend: backend}
+
+		req := httptest.NewRequest(http.MethodPost, "/build/cancel?id=build1", nil)
+		w := httptest.NewRecorder()
+
+		assert.NilError(t, br.postCancel(context.Background(), w, req, nil))
+		assert.Check(t, is.Equal(backend.cancelledID, "build1"))
+		assert.Check(t, is.Len(backend.cancelSessionCalls, 0))
+	})
+
+	t.Run("cancels every build for a session when id is empty", func(t *testing.T) {
+		backend := &fakeCancelBackend{cancelSessionCount: 2}
+		br := &buildRouter{backend: backend}
+
+		req := httptest.NewRequest(http.MethodPost, "/build/cancel?session=sess1", nil)
+		w := httptest.NewRecorder()
+
+		assert.NilError(t, br.postCancel(context.Background(), w, req, nil))
+		assert.Check(t, is.DeepEqual(backend.cancelSessionCalls, []string{"sess1"}))
+
+		var got buildCancelSessionResponse
+		assert.NilError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		assert.Check(t, is.Equal(got.Cancelled, 2))
+	})
+
+	t.Run("neither id nor session provided", func(t *testing.T) {
+		br := &buildRouter{backend: &fakeCancelBackend{}}
+
+		req := httptest.NewRequest(http.MethodPost, "/build/cancel", nil)
+		w := httptest.NewRecorder()
+
+		err := br.postCancel(context.Background(), w, req, nil)
+		assert.Check(t, is.ErrorContains(err, "build ID not provided"))
+	})
+}
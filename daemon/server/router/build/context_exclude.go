@@ -0,0 +1,60 @@
+package build
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"path"
+
+	"github.com/containerd/log"
+	"github.com/moby/patternmatcher"
+	"github.com/pkg/errors"
+)
+
+// filterBuildContext returns a tar stream equivalent to rc with any entry
+// whose name matches one of patterns removed, regardless of what the
+// client's own .dockerignore let through. Each excluded entry is logged at
+// debug. If patterns is empty, rc is returned unchanged.
+func filterBuildContext(ctx context.Context, rc io.ReadCloser, patterns []string) (io.ReadCloser, error) {
+	if len(patterns) == 0 {
+		return rc, nil
+	}
+	pm, err := patternmatcher.New(patterns)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid build-context-exclude pattern")
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer rc.Close()
+		tr := tar.NewReader(rc)
+		tw := tar.NewWriter(pw)
+		err := func() error {
+			for {
+				hdr, err := tr.Next()
+				if err == io.EOF {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				if match, _ := pm.Matches(path.Clean(hdr.Name)); match {
+					log.G(ctx).WithField("file", hdr.Name).Debug("excluded from build context by build-context-exclude")
+					continue
+				}
+				if err := tw.WriteHeader(hdr); err != nil {
+					return err
+				}
+				if _, err := io.Copy(tw, tr); err != nil {
+					return err
+				}
+			}
+		}()
+		if err != nil {
+			_ = pw.CloseWithError(err)
+			return
+		}
+		_ = pw.CloseWithError(tw.Close())
+	}()
+	return pr, nil
+}
@@ -0,0 +1,67 @@
+package build
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func buildTar(t *testing.T, files map[string]string) io.ReadCloser {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name: name,
+			Size: int64(len(content)),
+			Mode: 0o644,
+		})
+		assert.NilError(t, err)
+		_, err = tw.Write([]byte(content))
+		assert.NilError(t, err)
+	}
+	assert.NilError(t, tw.Close())
+	return io.NopCloser(buf)
+}
+
+func readTarNames(t *testing.T, rc io.ReadCloser) []string {
+	t.Helper()
+	defer rc.Close()
+	var names []string
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		assert.NilError(t, err)
+		names = append(names, hdr.Name)
+	}
+	return names
+}
+
+func TestFilterBuildContextNoPatterns(t *testing.T) {
+	rc := buildTar(t, map[string]string{"Dockerfile": "FROM scratch"})
+
+	filtered, err := filterBuildContext(context.Background(), rc, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, filtered, io.ReadCloser(rc))
+}
+
+func TestFilterBuildContextExcludesMatches(t *testing.T) {
+	rc := buildTar(t, map[string]string{
+		"Dockerfile":        "FROM scratch",
+		".git/config":       "secret",
+		"secrets/creds.pem": "cert",
+	})
+
+	filtered, err := filterBuildContext(context.Background(), rc, []string{".git", "secrets/**"})
+	assert.NilError(t, err)
+
+	names := readTarNames(t, filtered)
+	assert.DeepEqual(t, names, []string{"Dockerfile"})
+}
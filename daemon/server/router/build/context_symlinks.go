@@ -0,0 +1,80 @@
+package build
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/containerd/log"
+	"github.com/moby/moby/v2/daemon/config"
+	"github.com/pkg/errors"
+)
+
+// filterBuildContextSymlinks applies policy (one of
+// [config.BuildSymlinkPolicyReject], [config.BuildSymlinkPolicyFollowWithin],
+// or [config.BuildSymlinkPolicyPreserve]) to every symlink entry in the tar
+// stream rc, based on whether the symlink's target resolves outside of the
+// build context:
+//
+//   - BuildSymlinkPolicyReject fails the build outright.
+//   - BuildSymlinkPolicyFollowWithin silently drops the symlink from the
+//     context, leaving in-context symlinks untouched.
+//   - BuildSymlinkPolicyPreserve (the default, when policy is empty) passes
+//     every symlink through unchanged, preserving prior behavior.
+func filterBuildContextSymlinks(ctx context.Context, rc io.ReadCloser, policy string) (io.ReadCloser, error) {
+	if policy == "" || policy == config.BuildSymlinkPolicyPreserve {
+		return rc, nil
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer rc.Close()
+		tr := tar.NewReader(rc)
+		tw := tar.NewWriter(pw)
+		err := func() error {
+			for {
+				hdr, err := tr.Next()
+				if err == io.EOF {
+					return nil
+				}
+				if err != nil {
+					return err
+				}
+				if hdr.Typeflag == tar.TypeSymlink && escapesBuildContext(hdr.Name, hdr.Linkname) {
+					if policy == config.BuildSymlinkPolicyReject {
+						return errors.Errorf("build context symlink %q escapes the build context via target %q", hdr.Name, hdr.Linkname)
+					}
+					log.G(ctx).WithField("file", hdr.Name).WithField("target", hdr.Linkname).
+						Debug("dropped from build context: symlink target escapes the build context")
+					continue
+				}
+				if err := tw.WriteHeader(hdr); err != nil {
+					return err
+				}
+				if _, err := io.Copy(tw, tr); err != nil {
+					return err
+				}
+			}
+		}()
+		if err != nil {
+			_ = pw.CloseWithError(err)
+			return
+		}
+		_ = pw.CloseWithError(tw.Close())
+	}()
+	return pr, nil
+}
+
+// escapesBuildContext reports whether a symlink named name, with target
+// linkname, resolves outside of the build context. An absolute linkname
+// always escapes, since the build context has no concept of a root other
+// than itself.
+func escapesBuildContext(name, linkname string) bool {
+	if path.IsAbs(linkname) {
+		return true
+	}
+	resolved := path.Join(path.Dir(path.Clean(name)), linkname)
+	return resolved == ".." || strings.HasPrefix(resolved, "../")
+}
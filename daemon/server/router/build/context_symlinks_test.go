@@ -0,0 +1,71 @@
+package build
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/moby/moby/v2/daemon/config"
+	"gotest.tools/v3/assert"
+)
+
+func buildTarWithSymlink(t *testing.T, name, linkname string) io.ReadCloser {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	tw := tar.NewWriter(buf)
+	err := tw.WriteHeader(&tar.Header{
+		Name:     name,
+		Typeflag: tar.TypeSymlink,
+		Linkname: linkname,
+		Mode:     0o777,
+	})
+	assert.NilError(t, err)
+	assert.NilError(t, tw.Close())
+	return io.NopCloser(buf)
+}
+
+func TestFilterBuildContextSymlinksPreserve(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		linkname string
+	}{
+		{name: "in-context", linkname: "target"},
+		{name: "escaping", linkname: "../outside"},
+	} {
+		rc := buildTarWithSymlink(t, tc.name, tc.linkname)
+
+		filtered, err := filterBuildContextSymlinks(context.Background(), rc, config.BuildSymlinkPolicyPreserve)
+		assert.NilError(t, err)
+		assert.Equal(t, filtered, io.ReadCloser(rc))
+	}
+}
+
+func TestFilterBuildContextSymlinksReject(t *testing.T) {
+	rc := buildTarWithSymlink(t, "in-context", "target")
+	filtered, err := filterBuildContextSymlinks(context.Background(), rc, config.BuildSymlinkPolicyReject)
+	assert.NilError(t, err)
+	names := readTarNames(t, filtered)
+	assert.DeepEqual(t, names, []string{"in-context"})
+
+	rc = buildTarWithSymlink(t, "escaping", "../outside")
+	filtered, err = filterBuildContextSymlinks(context.Background(), rc, config.BuildSymlinkPolicyReject)
+	assert.NilError(t, err)
+	_, err = io.ReadAll(filtered)
+	assert.ErrorContains(t, err, "escapes the build context")
+}
+
+func TestFilterBuildContextSymlinksFollowWithin(t *testing.T) {
+	rc := buildTarWithSymlink(t, "in-context", "target")
+	filtered, err := filterBuildContextSymlinks(context.Background(), rc, config.BuildSymlinkPolicyFollowWithin)
+	assert.NilError(t, err)
+	names := readTarNames(t, filtered)
+	assert.DeepEqual(t, names, []string{"in-context"})
+
+	rc = buildTarWithSymlink(t, "escaping", "../outside")
+	filtered, err = filterBuildContextSymlinks(context.Background(), rc, config.BuildSymlinkPolicyFollowWithin)
+	assert.NilError(t, err)
+	names = readTarNames(t, filtered)
+	assert.DeepEqual(t, names, []string(nil))
+}
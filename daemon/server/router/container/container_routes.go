@@ -166,10 +166,33 @@ func (c *containerRouter) getContainersStats(ctx context.Context, w http.Respons
 	if versions.GreaterThanOrEqualTo(httputils.VersionFromContext(ctx), "1.41") {
 		oneShot = httputils.BoolValueOrDefault(r, "one-shot", false)
 	}
+	flatten := httputils.BoolValueOrDefault(r, "flatten", false)
+
+	var noPreCPU bool
+	if !stream {
+		noPreCPU = !httputils.BoolValueOrDefault(r, "precpu", true)
+	}
+
+	var interval time.Duration
+	if stream {
+		if v := r.FormValue("interval"); v != "" {
+			secs, err := strconv.Atoi(v)
+			if err != nil {
+				return errdefs.InvalidParameter(errors.Wrap(err, "invalid interval"))
+			}
+			if secs < 1 || secs > 60 {
+				return errdefs.InvalidParameter(errors.Errorf("interval must be between 1 and 60 seconds, got %d", secs))
+			}
+			interval = time.Duration(secs) * time.Second
+		}
+	}
 
 	return c.backend.ContainerStats(ctx, vars["name"], &backend.ContainerStatsConfig{
-		Stream:  stream,
-		OneShot: oneShot,
+		Stream:   stream,
+		OneShot:  oneShot,
+		Flatten:  flatten,
+		Interval: interval,
+		NoPreCPU: noPreCPU,
 		OutStream: func() io.Writer {
 			// Assume that when this is called the request is OK.
 			w.WriteHeader(http.StatusOK)
@@ -1090,9 +1113,10 @@ func (c *containerRouter) deleteContainers(ctx context.Context, w http.ResponseW
 
 	name := vars["name"]
 	config := &backend.ContainerRmConfig{
-		ForceRemove:  httputils.BoolValue(r, "force"),
-		RemoveVolume: httputils.BoolValue(r, "v"),
-		RemoveLink:   httputils.BoolValue(r, "link"),
+		ForceRemove:     httputils.BoolValue(r, "force"),
+		RemoveVolume:    httputils.BoolValue(r, "v"),
+		RemoveLink:      httputils.BoolValue(r, "link"),
+		ForceDependents: httputils.BoolValue(r, "force-dependents"),
 	}
 
 	if err := c.backend.ContainerRm(name, config); err != nil {
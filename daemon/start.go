@@ -2,11 +2,13 @@
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	containerd "github.com/containerd/containerd/v2/client"
 	"github.com/containerd/containerd/v2/core/containers"
 	"github.com/containerd/log"
+	"github.com/distribution/reference"
 	"github.com/moby/moby/api/types/events"
 	"github.com/moby/moby/v2/daemon/container"
 	mobyc8dstore "github.com/moby/moby/v2/daemon/containerd"
@@ -14,6 +16,7 @@
 	"github.com/moby/moby/v2/daemon/internal/metrics"
 	"github.com/moby/moby/v2/daemon/internal/otelutil"
 	"github.com/moby/moby/v2/daemon/server/backend"
+	"github.com/moby/moby/v2/daemon/server/imagebackend"
 	"github.com/moby/moby/v2/errdefs"
 	"github.com/pkg/errors"
 	"go.opentelemetry.io/otel"
@@ -45,6 +48,33 @@ func validateState(ctr *container.Container) error {
 	return nil
 }
 
+// ensureImageAvailable checks that ctr's image still exists, returning a
+// clear, typed not-found error naming the missing image if it doesn't. If
+// daemonCfg.AutoPullMissingImageOnStart is enabled and ctr's original image
+// reference is still known, it first attempts to re-pull that reference
+// before failing, since a locally missing image (e.g. removed by a prune)
+// may still be available from its registry.
+func (daemon *Daemon) ensureImageAvailable(ctx context.Context, daemonCfg *configStore, ctr *container.Container) error {
+	if _, err := daemon.imageService.GetImage(ctx, ctr.ImageID.String(), imagebackend.GetImageOpts{}); err == nil {
+		return nil
+	}
+
+	ref := ctr.Config.Image
+	named, parseErr := reference.ParseNormalizedNamed(ref)
+	if daemonCfg.AutoPullMissingImageOnStart && ref != "" && parseErr == nil {
+		pullErr := daemon.imageService.PullImage(ctx, named, imagebackend.PullOptions{})
+		if pullErr == nil {
+			return nil
+		}
+		log.G(ctx).WithError(pullErr).WithFields(log.Fields{
+			"container": ctr.ID,
+			"image":     ref,
+		}).Warn("failed to re-pull image missing at container start")
+	}
+
+	return errdefs.NotFound(fmt.Errorf("image %q for container %s no longer exists; it may have been removed", ref, ctr.ID))
+}
+
 // ContainerStart starts a container.
 func (daemon *Daemon) ContainerStart(ctx context.Context, name string, checkpoint string, checkpointDir string) error {
 	daemonCfg := daemon.config()
@@ -60,6 +90,10 @@ func (daemon *Daemon) ContainerStart(ctx context.Context, name string, checkpoin
 		return err
 	}
 
+	if err := daemon.ensureImageAvailable(ctx, daemonCfg, ctr); err != nil {
+		return err
+	}
+
 	// check if hostConfig is in line with the current system settings.
 	// It may happen cgroups are unmounted or the like.
 	if _, err = daemon.verifyContainerSettings(daemonCfg, ctr.HostConfig, nil, false); err != nil {
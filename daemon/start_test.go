@@ -0,0 +1,69 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+
+	"github.com/distribution/reference"
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/daemon/container"
+	"github.com/moby/moby/v2/daemon/internal/image"
+	"github.com/moby/moby/v2/daemon/server/imagebackend"
+	"gotest.tools/v3/assert"
+)
+
+// fakeImageServiceForStart implements only the ImageService methods that
+// ensureImageAvailable calls, embedding the interface so the rest panic if
+// ever exercised.
+type fakeImageServiceForStart struct {
+	ImageService
+	getImageErr error
+	pullErr     error
+	pullCalled  bool
+}
+
+func (f *fakeImageServiceForStart) GetImage(ctx context.Context, refOrID string, options imagebackend.GetImageOpts) (*image.Image, error) {
+	if f.getImageErr != nil {
+		return nil, f.getImageErr
+	}
+	return &image.Image{}, nil
+}
+
+func (f *fakeImageServiceForStart) PullImage(ctx context.Context, ref reference.Named, options imagebackend.PullOptions) error {
+	f.pullCalled = true
+	return f.pullErr
+}
+
+func TestEnsureImageAvailablePresent(t *testing.T) {
+	d := &Daemon{imageService: &fakeImageServiceForStart{}}
+	ctr := &container.Container{Config: &containertypes.Config{Image: "nginx"}}
+
+	err := d.ensureImageAvailable(context.Background(), &configStore{}, ctr)
+	assert.NilError(t, err)
+}
+
+func TestEnsureImageAvailableMissingFailsClearly(t *testing.T) {
+	fake := &fakeImageServiceForStart{getImageErr: errNotFoundStub{}}
+	d := &Daemon{imageService: fake}
+	ctr := &container.Container{ID: "abc123", Config: &containertypes.Config{Image: "nginx"}}
+
+	err := d.ensureImageAvailable(context.Background(), &configStore{}, ctr)
+	assert.ErrorContains(t, err, `image "nginx" for container abc123 no longer exists`)
+	assert.Check(t, !fake.pullCalled)
+}
+
+func TestEnsureImageAvailableMissingAutoPulls(t *testing.T) {
+	fake := &fakeImageServiceForStart{getImageErr: errNotFoundStub{}}
+	d := &Daemon{imageService: fake}
+	ctr := &container.Container{ID: "abc123", Config: &containertypes.Config{Image: "nginx"}}
+	cfg := &configStore{}
+	cfg.AutoPullMissingImageOnStart = true
+
+	err := d.ensureImageAvailable(context.Background(), cfg, ctr)
+	assert.NilError(t, err)
+	assert.Check(t, fake.pullCalled)
+}
+
+type errNotFoundStub struct{}
+
+func (errNotFoundStub) Error() string { return "no such image" }
@@ -4,12 +4,14 @@
 	"context"
 	"encoding/json"
 	"runtime"
+	"sync"
 	"time"
 
 	cerrdefs "github.com/containerd/errdefs"
 	"github.com/containerd/log"
 	containertypes "github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/v2/daemon/container"
+	"github.com/moby/moby/v2/daemon/internal/metrics"
 	"github.com/moby/moby/v2/daemon/server/backend"
 )
 
@@ -34,15 +36,20 @@ func (daemon *Daemon) ContainerStats(ctx context.Context, prefixOrName string, c
 				OSType: runtime.GOOS,
 			})
 		}
-		if config.OneShot {
-			// In OneShot-mode, we only collect a single sample, return immediately.
+		if config.OneShot || config.NoPreCPU {
+			// In OneShot-mode, or when the caller opted out of the PreCPUStats
+			// wait via NoPreCPU, we only collect a single sample and return it
+			// immediately, leaving PreRead and PreCPUStats zero-valued. The
+			// caller is then responsible for computing CPU deltas across its
+			// own polls.
 			//
-			// In streaming mode, OneShot has no effect, as we never populate
-			// the Pre* fields for the first result.
+			// In streaming mode, OneShot and NoPreCPU have no effect, as we
+			// never populate the Pre* fields for the first result.
 			stats, err := daemon.GetContainerStats(ctr)
 			if err != nil {
 				return err
 			}
+			applyNetworksFlattening(stats, config.Flatten)
 			return json.NewEncoder(config.OutStream()).Encode(stats)
 		}
 
@@ -56,6 +63,7 @@ func (daemon *Daemon) ContainerStats(ctx context.Context, prefixOrName string, c
 	var (
 		previousRead     time.Time               // Previous Read time to populate the PreRead field.
 		previousCPUStats containertypes.CPUStats // Previous CPUStats to populate the PreCPUStats field.
+		lastEmit         time.Time               // Time of the last emitted sample, to enforce config.Interval.
 	)
 
 	enc := json.NewEncoder(config.OutStream())
@@ -80,8 +88,17 @@ func (daemon *Daemon) ContainerStats(ctx context.Context, prefixOrName string, c
 				continue
 			}
 
+			if config.Interval > 0 && !lastEmit.IsZero() && statsJSON.Read.Sub(lastEmit) < config.Interval {
+				// Down-sample: skip this collector tick, but keep Pre*
+				// tracking the most recent sample for when we do emit.
+				previousRead = statsJSON.Read
+				previousCPUStats = statsJSON.CPUStats
+				continue
+			}
+
 			statsJSON.PreRead = previousRead
 			statsJSON.PreCPUStats = previousCPUStats
+			applyNetworksFlattening(&statsJSON, config.Flatten)
 			if err := enc.Encode(&statsJSON); err != nil {
 				return err
 			}
@@ -92,19 +109,60 @@ func (daemon *Daemon) ContainerStats(ctx context.Context, prefixOrName string, c
 
 			previousRead = statsJSON.Read
 			previousCPUStats = statsJSON.CPUStats
+			lastEmit = statsJSON.Read
 		case <-ctx.Done():
 			return nil
 		}
 	}
 }
 
+// applyNetworksFlattening replaces stats.Networks with its flattened,
+// prefixed-key equivalent in stats.NetworksFlat when flatten is requested.
+// The nested Networks map remains the default (flatten is false) for
+// backward compatibility.
+func applyNetworksFlattening(stats *containertypes.StatsResponse, flatten bool) {
+	if !flatten || len(stats.Networks) == 0 {
+		return
+	}
+	stats.NetworksFlat = flattenNetworkStats(stats.Networks)
+	stats.Networks = nil
+}
+
+// flattenNetworkStats transforms a per-interface network stats map into a
+// flat map keyed by "<interface>_<field>" (e.g. "eth0_rx_bytes"), for
+// metrics exporters that prefer flattened keys over nested structures.
+func flattenNetworkStats(networks map[string]containertypes.NetworkStats) map[string]uint64 {
+	flat := make(map[string]uint64, len(networks)*8)
+	for iface, ns := range networks {
+		flat[iface+"_rx_bytes"] = ns.RxBytes
+		flat[iface+"_rx_packets"] = ns.RxPackets
+		flat[iface+"_rx_errors"] = ns.RxErrors
+		flat[iface+"_rx_dropped"] = ns.RxDropped
+		flat[iface+"_tx_bytes"] = ns.TxBytes
+		flat[iface+"_tx_packets"] = ns.TxPackets
+		flat[iface+"_tx_errors"] = ns.TxErrors
+		flat[iface+"_tx_dropped"] = ns.TxDropped
+	}
+	return flat
+}
+
 // subscribeToContainerStats starts collecting stats for the given container.
 // It returns a channel containing [containertypes.StatsResponse] records,
 // and a cancel function to unsubscribe and stop collecting stats.
+//
+// It increments [metrics.StatsCollectorGoroutines] for the lifetime of the
+// subscription, and decrements it once cancel is called, so that a client
+// that disconnects without cleanly tearing down its collector shows up as a
+// gauge that never returns to its baseline.
 func (daemon *Daemon) subscribeToContainerStats(c *container.Container) (updates chan any, cancel func()) {
 	ch := daemon.statsCollector.Collect(c)
+	metrics.StatsCollectorGoroutines.Inc(1)
+	var once sync.Once
 	cancel = func() {
-		daemon.statsCollector.Unsubscribe(c, ch)
+		once.Do(func() {
+			daemon.statsCollector.Unsubscribe(c, ch)
+			metrics.StatsCollectorGoroutines.Dec(1)
+		})
 	}
 	return ch, cancel
 }
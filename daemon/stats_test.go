@@ -0,0 +1,109 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	gometrics "github.com/docker/go-metrics"
+	containertypes "github.com/moby/moby/api/types/container"
+	"github.com/moby/moby/v2/daemon/container"
+	"github.com/moby/moby/v2/daemon/internal/metrics"
+	"github.com/moby/moby/v2/daemon/stats"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+func TestFlattenNetworkStatsMatchesNested(t *testing.T) {
+	networks := map[string]containertypes.NetworkStats{
+		"eth0": {
+			RxBytes: 100, RxPackets: 10, RxErrors: 1, RxDropped: 2,
+			TxBytes: 200, TxPackets: 20, TxErrors: 3, TxDropped: 4,
+		},
+	}
+
+	flat := flattenNetworkStats(networks)
+
+	assert.Equal(t, flat["eth0_rx_bytes"], networks["eth0"].RxBytes)
+	assert.Equal(t, flat["eth0_rx_packets"], networks["eth0"].RxPackets)
+	assert.Equal(t, flat["eth0_rx_errors"], networks["eth0"].RxErrors)
+	assert.Equal(t, flat["eth0_rx_dropped"], networks["eth0"].RxDropped)
+	assert.Equal(t, flat["eth0_tx_bytes"], networks["eth0"].TxBytes)
+	assert.Equal(t, flat["eth0_tx_packets"], networks["eth0"].TxPackets)
+	assert.Equal(t, flat["eth0_tx_errors"], networks["eth0"].TxErrors)
+	assert.Equal(t, flat["eth0_tx_dropped"], networks["eth0"].TxDropped)
+}
+
+func TestApplyNetworksFlatteningDefaultLeavesNestedForm(t *testing.T) {
+	stats := &containertypes.StatsResponse{
+		Networks: map[string]containertypes.NetworkStats{"eth0": {RxBytes: 100}},
+	}
+
+	applyNetworksFlattening(stats, false)
+
+	assert.Check(t, stats.NetworksFlat == nil)
+	assert.Equal(t, len(stats.Networks), 1)
+}
+
+func TestApplyNetworksFlatteningReplacesNestedForm(t *testing.T) {
+	stats := &containertypes.StatsResponse{
+		Networks: map[string]containertypes.NetworkStats{"eth0": {RxBytes: 100}},
+	}
+
+	applyNetworksFlattening(stats, true)
+
+	assert.Check(t, stats.Networks == nil)
+	assert.Equal(t, stats.NetworksFlat["eth0_rx_bytes"], uint64(100))
+}
+
+// noopStatsSupervisor implements the stats.Collector's supervisor
+// interface, without ever actually producing a sample; the tests below
+// only exercise Collect/Unsubscribe, not the collection loop itself.
+type noopStatsSupervisor struct{}
+
+func (noopStatsSupervisor) GetContainerStats(*container.Container) (*containertypes.StatsResponse, error) {
+	return &containertypes.StatsResponse{}, nil
+}
+
+func TestSubscribeToContainerStatsGaugeReturnsToBaseline(t *testing.T) {
+	daemon := &Daemon{statsCollector: stats.NewCollector(noopStatsSupervisor{}, time.Second)}
+	ctr := container.NewBaseContainer("container-id", t.TempDir())
+
+	baseline := gaugeValue(t, metrics.StatsCollectorGoroutines)
+
+	const subscribers = 5
+	var cancels []func()
+	for i := 1; i <= subscribers; i++ {
+		_, cancel := daemon.subscribeToContainerStats(ctr)
+		cancels = append(cancels, cancel)
+		assert.Check(t, is.Equal(gaugeValue(t, metrics.StatsCollectorGoroutines), baseline+float64(i)))
+	}
+
+	for _, cancel := range cancels {
+		cancel()
+	}
+
+	assert.Check(t, is.Equal(gaugeValue(t, metrics.StatsCollectorGoroutines), baseline))
+}
+
+// gaugeValue reads the current value of a [gometrics.Gauge] by collecting it
+// as a Prometheus metric, since the Gauge interface itself is write-only.
+// The concrete gauges vended by a [gometrics.Namespace] also implement
+// prometheus.Collector; that's asserted at runtime here rather than
+// declared in gaugeValue's signature, since gometrics.Gauge itself doesn't
+// expose Describe/Collect.
+func gaugeValue(t *testing.T, g gometrics.Gauge) float64 {
+	t.Helper()
+
+	collector, ok := g.(prometheus.Collector)
+	if !ok {
+		t.Fatalf("gauge %T does not implement prometheus.Collector", g)
+	}
+
+	ch := make(chan prometheus.Metric, 1)
+	collector.Collect(ch)
+	var m dto.Metric
+	assert.NilError(t, (<-ch).Write(&m))
+	return m.GetGauge().GetValue()
+}
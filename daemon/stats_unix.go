@@ -8,11 +8,13 @@
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"strings"
 
 	statsV1 "github.com/containerd/cgroups/v3/cgroup1/stats"
+	"github.com/containerd/cgroups/v3/cgroup2"
 	statsV2 "github.com/containerd/cgroups/v3/cgroup2/stats"
 	cerrdefs "github.com/containerd/errdefs"
 	containertypes "github.com/moby/moby/api/types/container"
@@ -33,6 +35,75 @@ func copyBlkioEntry(entries []*statsV1.BlkIOEntry) []containertypes.BlkioStatEnt
 	return out
 }
 
+// readBlkioLatency reads block IO read/write latency percentiles from the
+// "io.stat" file of the cgroup v2 group containing pid, if the kernel
+// exposes them via the optional "rlat<pct>"/"wlat<pct>" fields. It returns
+// nil if the group or file can't be resolved, or if the file doesn't carry
+// any percentile data, which is the common case since most kernels only
+// report the standard io.stat fields (bytes and IO counts).
+func readBlkioLatency(pid uint32) *containertypes.BlkioLatencyStats {
+	group, err := cgroup2.PidGroupPath(int(pid))
+	if err != nil {
+		return nil
+	}
+	f, err := os.Open(filepath.Join("/sys/fs/cgroup", group, "io.stat"))
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	return parseBlkioLatency(f)
+}
+
+// parseBlkioLatency parses the optional per-device "rlat50"/"rlat95"/
+// "rlat99"/"wlat50"/"wlat95"/"wlat99" fields (in nanoseconds) out of a
+// cgroup v2 "io.stat" file, aggregating them across all reported devices by
+// keeping the maximum value seen for each percentile. It returns nil if
+// none of those fields are present.
+func parseBlkioLatency(r io.Reader) *containertypes.BlkioLatencyStats {
+	var lat containertypes.BlkioLatencyStats
+	found := false
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		for _, field := range fields[1:] {
+			key, val, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			v, err := strconv.ParseUint(val, 10, 64)
+			if err != nil {
+				continue
+			}
+			var dst *uint64
+			switch key {
+			case "rlat50":
+				dst = &lat.ReadP50
+			case "rlat95":
+				dst = &lat.ReadP95
+			case "rlat99":
+				dst = &lat.ReadP99
+			case "wlat50":
+				dst = &lat.WriteP50
+			case "wlat95":
+				dst = &lat.WriteP95
+			case "wlat99":
+				dst = &lat.WriteP99
+			default:
+				continue
+			}
+			found = true
+			if v > *dst {
+				*dst = v
+			}
+		}
+	}
+	if !found {
+		return nil
+	}
+	return &lat
+}
+
 func (daemon *Daemon) stats(c *container.Container) (*containertypes.StatsResponse, error) {
 	c.Lock()
 	task, err := c.GetRunningTask()
@@ -57,7 +128,7 @@ func (daemon *Daemon) stats(c *container.Container) (*containertypes.StatsRespon
 	case *statsV1.Metrics:
 		return daemon.statsV1(s, t)
 	case *statsV2.Metrics:
-		return daemon.statsV2(s, t)
+		return daemon.statsV2(s, t, task.Pid())
 	default:
 		return nil, errors.Errorf("unexpected type of metrics %+v", t)
 	}
@@ -157,7 +228,7 @@ func (daemon *Daemon) statsV1(s *containertypes.StatsResponse, stats *statsV1.Me
 	return s, nil
 }
 
-func (daemon *Daemon) statsV2(s *containertypes.StatsResponse, stats *statsV2.Metrics) (*containertypes.StatsResponse, error) {
+func (daemon *Daemon) statsV2(s *containertypes.StatsResponse, stats *statsV2.Metrics, pid uint32) (*containertypes.StatsResponse, error) {
 	if stats.Io != nil {
 		var isbr []containertypes.BlkioStatEntry
 		for _, re := range stats.Io.Usage {
@@ -179,6 +250,7 @@ func (daemon *Daemon) statsV2(s *containertypes.StatsResponse, stats *statsV2.Me
 		s.BlkioStats = containertypes.BlkioStats{
 			IoServiceBytesRecursive: isbr,
 			// Other fields are unsupported
+			Latency: readBlkioLatency(pid),
 		}
 	}
 
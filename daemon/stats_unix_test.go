@@ -7,8 +7,10 @@
 	"strings"
 	"testing"
 
+	"github.com/containerd/cgroups/v3"
 	"gotest.tools/v3/assert"
 	is "gotest.tools/v3/assert/cmp"
+	"gotest.tools/v3/skip"
 )
 
 //go:embed testdata/stat
@@ -20,3 +22,36 @@ func TestGetSystemCPUUsageParsing(t *testing.T) {
 	assert.Check(t, is.Equal(cpuUsage, uint64(65647090000000)))
 	assert.Check(t, is.Equal(cpuNum, uint32(128)))
 }
+
+func TestParseBlkioLatency(t *testing.T) {
+	// This test only exercises the "io.stat" parser directly, since
+	// percentile latency fields are only ever produced by a cgroup v2
+	// host, and readBlkioLatency (which resolves an actual cgroup) is
+	// only wired up from statsV2.
+	skip.If(t, cgroups.Mode() != cgroups.Unified, "test requires cgroup v2")
+
+	t.Run("with latency percentiles", func(t *testing.T) {
+		input := strings.NewReader("8:0 rbytes=1024 wbytes=2048 rios=4 wios=8 rlat50=100 rlat95=500 rlat99=900 wlat50=200 wlat95=600 wlat99=950\n")
+		lat := parseBlkioLatency(input)
+		assert.Assert(t, lat != nil)
+		assert.Check(t, is.Equal(lat.ReadP50, uint64(100)))
+		assert.Check(t, is.Equal(lat.ReadP95, uint64(500)))
+		assert.Check(t, is.Equal(lat.ReadP99, uint64(900)))
+		assert.Check(t, is.Equal(lat.WriteP50, uint64(200)))
+		assert.Check(t, is.Equal(lat.WriteP95, uint64(600)))
+		assert.Check(t, is.Equal(lat.WriteP99, uint64(950)))
+	})
+
+	t.Run("without latency percentiles", func(t *testing.T) {
+		input := strings.NewReader("8:0 rbytes=1024 wbytes=2048 rios=4 wios=8 dbytes=0 dios=0\n")
+		lat := parseBlkioLatency(input)
+		assert.Check(t, is.Nil(lat))
+	})
+
+	t.Run("keeps the maximum across devices", func(t *testing.T) {
+		input := strings.NewReader("8:0 rlat99=900\n8:16 rlat99=1200\n")
+		lat := parseBlkioLatency(input)
+		assert.Assert(t, lat != nil)
+		assert.Check(t, is.Equal(lat.ReadP99, uint64(1200)))
+	})
+}
@@ -8,6 +8,7 @@
 	containertypes "github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/api/types/events"
 	"github.com/moby/moby/v2/daemon/container"
+	"github.com/moby/moby/v2/daemon/internal/metrics"
 	"github.com/moby/moby/v2/daemon/server/backend"
 	"github.com/moby/moby/v2/errdefs"
 	"github.com/moby/sys/signal"
@@ -42,6 +43,27 @@ func (daemon *Daemon) ContainerStop(ctx context.Context, name string, options ba
 	return nil
 }
 
+// acquireStopSlot blocks until a concurrent-stop slot is available, bounded
+// by config.MaxConcurrentStops, tracking the in-flight count (including stops
+// still waiting for a slot) as a metric. It returns a release function the
+// caller must call once the stop completes. If concurrent stops are unbounded
+// (the default), it returns immediately, with a release function that only
+// updates the metric.
+func (daemon *Daemon) acquireStopSlot(ctx context.Context) (func(), error) {
+	metrics.ContainerStopsInFlight.Inc()
+	if daemon.stopLimiter == nil {
+		return func() { metrics.ContainerStopsInFlight.Dec() }, nil
+	}
+	if err := daemon.stopLimiter.Acquire(ctx, 1); err != nil {
+		metrics.ContainerStopsInFlight.Dec()
+		return nil, err
+	}
+	return func() {
+		metrics.ContainerStopsInFlight.Dec()
+		daemon.stopLimiter.Release(1)
+	}, nil
+}
+
 // containerStop sends a stop signal, waits, sends a kill signal. It uses
 // a [context.WithoutCancel], so cancelling the context does not cancel
 // the request to stop the container.
@@ -53,6 +75,12 @@ func (daemon *Daemon) containerStop(ctx context.Context, ctr *container.Containe
 		return nil
 	}
 
+	release, err := daemon.acquireStopSlot(ctx)
+	if err != nil {
+		return err
+	}
+	defer release()
+
 	var (
 		stopSignal  = ctr.StopSignal()
 		stopTimeout = ctr.StopTimeout()
@@ -82,7 +110,7 @@ func (daemon *Daemon) containerStop(ctx context.Context, ctr *container.Containe
 	}()
 
 	// 1. Send a stop signal
-	err := daemon.killPossiblyDeadProcess(ctr, stopSignal)
+	err = daemon.killPossiblyDeadProcess(ctr, stopSignal)
 	if err != nil {
 		wait = 2 * time.Second
 	}
@@ -0,0 +1,60 @@
+package daemon
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+	"gotest.tools/v3/assert"
+)
+
+// TestAcquireStopSlotUnbounded asserts that a Daemon with no configured
+// max-concurrent-stops (the default) never blocks acquirers.
+func TestAcquireStopSlotUnbounded(t *testing.T) {
+	daemon := &Daemon{}
+	release, err := daemon.acquireStopSlot(context.Background())
+	assert.NilError(t, err)
+	release()
+}
+
+// TestAcquireStopSlotBoundsConcurrency issues many concurrent stops against a
+// Daemon configured with a small max-concurrent-stops, and asserts that the
+// number of stops running at once never exceeds that bound.
+func TestAcquireStopSlotBoundsConcurrency(t *testing.T) {
+	const (
+		limit     = 3
+		callers   = 50
+		holdEvery = time.Millisecond
+	)
+	daemon := &Daemon{stopLimiter: semaphore.NewWeighted(limit)}
+
+	var (
+		current int64
+		peak    int64
+	)
+	var wg sync.WaitGroup
+	for range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := daemon.acquireStopSlot(context.Background())
+			assert.Check(t, err)
+			n := atomic.AddInt64(&current, 1)
+			for {
+				p := atomic.LoadInt64(&peak)
+				if n <= p || atomic.CompareAndSwapInt64(&peak, p, n) {
+					break
+				}
+			}
+			time.Sleep(holdEvery)
+			atomic.AddInt64(&current, -1)
+			release()
+		}()
+	}
+	wg.Wait()
+
+	assert.Check(t, peak <= limit, "observed %d concurrent stops, want <= %d", peak, limit)
+}
@@ -2,6 +2,7 @@
 
 import (
 	"context"
+	"strconv"
 
 	cerrdefs "github.com/containerd/errdefs"
 	"github.com/moby/moby/api/types/container"
@@ -76,7 +77,9 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 		ctr.UpdateMonitor(hostConfig.RestartPolicy)
 	}
 
-	defer daemon.LogContainerEvent(ctr, events.ActionUpdate)
+	if changes := resourceUpdateAttributes(backupHostConfig.Resources, ctr.HostConfig.Resources); len(changes) > 0 {
+		defer daemon.LogContainerEventWithAttributes(ctr, events.ActionUpdate, changes)
+	}
 
 	// If container is not running, update hostConfig struct is enough,
 	// resources will be updated when the container is started again.
@@ -107,6 +110,75 @@ func (daemon *Daemon) update(name string, hostConfig *container.HostConfig) erro
 	return nil
 }
 
+// resourceUpdateAttributes compares a container's resources before and after
+// an update and returns event attributes for the fields that actually
+// changed, using the same "<field>.old" / "<field>.new" naming convention
+// used for other update events (see logNodeEvent, logServiceEvent). It
+// returns an empty map if none of the tracked resources changed, so that
+// callers can skip emitting an event for no-op updates.
+func resourceUpdateAttributes(oldResources, newResources container.Resources) map[string]string {
+	attributes := map[string]string{}
+	if oldResources.CPUShares != newResources.CPUShares {
+		attributes["cpushares.old"] = strconv.FormatInt(oldResources.CPUShares, 10)
+		attributes["cpushares.new"] = strconv.FormatInt(newResources.CPUShares, 10)
+	}
+	if oldResources.Memory != newResources.Memory {
+		attributes["memory.old"] = strconv.FormatInt(oldResources.Memory, 10)
+		attributes["memory.new"] = strconv.FormatInt(newResources.Memory, 10)
+	}
+	if oldResources.MemoryReservation != newResources.MemoryReservation {
+		attributes["memoryreservation.old"] = strconv.FormatInt(oldResources.MemoryReservation, 10)
+		attributes["memoryreservation.new"] = strconv.FormatInt(newResources.MemoryReservation, 10)
+	}
+	if oldResources.MemorySwap != newResources.MemorySwap {
+		attributes["memoryswap.old"] = strconv.FormatInt(oldResources.MemorySwap, 10)
+		attributes["memoryswap.new"] = strconv.FormatInt(newResources.MemorySwap, 10)
+	}
+	if oldResources.NanoCPUs != newResources.NanoCPUs {
+		attributes["nanocpus.old"] = strconv.FormatInt(oldResources.NanoCPUs, 10)
+		attributes["nanocpus.new"] = strconv.FormatInt(newResources.NanoCPUs, 10)
+	}
+	if oldResources.CPUPeriod != newResources.CPUPeriod {
+		attributes["cpuperiod.old"] = strconv.FormatInt(oldResources.CPUPeriod, 10)
+		attributes["cpuperiod.new"] = strconv.FormatInt(newResources.CPUPeriod, 10)
+	}
+	if oldResources.CPUQuota != newResources.CPUQuota {
+		attributes["cpuquota.old"] = strconv.FormatInt(oldResources.CPUQuota, 10)
+		attributes["cpuquota.new"] = strconv.FormatInt(newResources.CPUQuota, 10)
+	}
+	if oldResources.CpusetCpus != newResources.CpusetCpus {
+		attributes["cpusetcpus.old"] = oldResources.CpusetCpus
+		attributes["cpusetcpus.new"] = newResources.CpusetCpus
+	}
+	if oldResources.CpusetMems != newResources.CpusetMems {
+		attributes["cpusetmems.old"] = oldResources.CpusetMems
+		attributes["cpusetmems.new"] = newResources.CpusetMems
+	}
+	if oldResources.BlkioWeight != newResources.BlkioWeight {
+		attributes["blkioweight.old"] = strconv.FormatUint(uint64(oldResources.BlkioWeight), 10)
+		attributes["blkioweight.new"] = strconv.FormatUint(uint64(newResources.BlkioWeight), 10)
+	}
+	if !int64PtrEqual(oldResources.PidsLimit, newResources.PidsLimit) {
+		attributes["pidslimit.old"] = formatInt64Ptr(oldResources.PidsLimit)
+		attributes["pidslimit.new"] = formatInt64Ptr(newResources.PidsLimit)
+	}
+	return attributes
+}
+
+func int64PtrEqual(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func formatInt64Ptr(v *int64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatInt(*v, 10)
+}
+
 func errCannotUpdate(containerID string, err error) error {
 	return errors.Wrap(err, "Cannot update container "+containerID)
 }
@@ -0,0 +1,36 @@
+package daemon
+
+import (
+	"testing"
+
+	containertypes "github.com/moby/moby/api/types/container"
+	"gotest.tools/v3/assert"
+)
+
+func TestResourceUpdateAttributesMemoryChanged(t *testing.T) {
+	old := containertypes.Resources{Memory: 100}
+	updated := containertypes.Resources{Memory: 200}
+
+	attributes := resourceUpdateAttributes(old, updated)
+	assert.DeepEqual(t, attributes, map[string]string{
+		"memory.old": "100",
+		"memory.new": "200",
+	})
+}
+
+func TestResourceUpdateAttributesNoChange(t *testing.T) {
+	resources := containertypes.Resources{Memory: 100, CPUShares: 512}
+
+	attributes := resourceUpdateAttributes(resources, resources)
+	assert.Equal(t, len(attributes), 0)
+}
+
+func TestResourceUpdateAttributesPidsLimit(t *testing.T) {
+	limit := int64(50)
+
+	attributes := resourceUpdateAttributes(containertypes.Resources{}, containertypes.Resources{PidsLimit: &limit})
+	assert.DeepEqual(t, attributes, map[string]string{
+		"pidslimit.old": "",
+		"pidslimit.new": "50",
+	})
+}
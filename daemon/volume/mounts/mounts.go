@@ -17,6 +17,29 @@
 	"github.com/pkg/errors"
 )
 
+// MountOrigin classifies how a mount point came to be attached to a
+// container, for reporting purposes (e.g. `docker inspect`). It has no
+// effect on how the mount itself is set up.
+type MountOrigin string
+
+const (
+	// MountOriginImageVolume marks an anonymous volume created because the
+	// image declared it with a Dockerfile VOLUME instruction, with no
+	// corresponding --volume/--mount override from the user.
+	MountOriginImageVolume MountOrigin = "image-volume"
+	// MountOriginUserVolume marks a named or anonymous volume that the user
+	// requested explicitly, through --volume or --mount.
+	MountOriginUserVolume MountOrigin = "user-volume"
+	// MountOriginBind marks a bind mount of a host path.
+	MountOriginBind MountOrigin = "bind"
+	// MountOriginTmpfs marks a tmpfs mount.
+	MountOriginTmpfs MountOrigin = "tmpfs"
+	// MountOriginVolumesFrom marks a mount point copied from another
+	// container via --volumes-from. FromContainer records the ID of that
+	// source container.
+	MountOriginVolumesFrom MountOrigin = "volumes-from"
+)
+
 // RWLayer represents a writable layer.
 type RWLayer interface {
 	// Mount mounts the RWLayer and returns the filesystem path
@@ -80,6 +103,15 @@ type MountPoint struct {
 	// Spec is a copy of the API request that created this mount.
 	Spec mounttypes.Mount
 
+	// Origin classifies how this mount point came to be attached to the
+	// container (image-defined volume, user volume, bind, tmpfs, or
+	// volumes-from). It is informational only.
+	Origin MountOrigin `json:",omitempty"`
+	// FromContainer is the ID of the container this mount point was copied
+	// from via --volumes-from. It is only set when Origin is
+	// [MountOriginVolumesFrom].
+	FromContainer string `json:",omitempty"`
+
 	// Some bind mounts should not be automatically created.
 	// (Some are auto-created for backwards-compatibility)
 	// This is checked on the API but setting this here prevents race conditions.
@@ -11,6 +11,7 @@
 	"github.com/moby/moby/v2/daemon/internal/directory"
 	"github.com/moby/moby/v2/daemon/internal/filters"
 	"github.com/moby/moby/v2/daemon/internal/idtools"
+	"github.com/moby/moby/v2/daemon/internal/metrics"
 	"github.com/moby/moby/v2/daemon/internal/stringid"
 	"github.com/moby/moby/v2/daemon/volume"
 	"github.com/moby/moby/v2/daemon/volume/drivers"
@@ -33,10 +34,11 @@ type VolumeEventLogger interface {
 // VolumesService manages access to volumes
 // This is used as the main access point for volumes to higher level services and the API.
 type VolumesService struct {
-	vs           *VolumeStore
-	ds           driverLister
-	pruneRunning atomic.Bool
-	eventLogger  VolumeEventLogger
+	vs                *VolumeStore
+	ds                driverLister
+	pruneRunning      atomic.Bool
+	eventLogger       VolumeEventLogger
+	anonymousUsageCap int64
 }
 
 // NewVolumeService creates a new volume service
@@ -53,6 +55,13 @@ func NewVolumeService(root string, pg plugingetter.PluginGetter, rootIDs idtools
 	return &VolumesService{vs: vs, ds: ds, eventLogger: logger}, nil
 }
 
+// SetAnonymousVolumeUsageCap sets the maximum total disk usage, in bytes,
+// allowed across all anonymous volumes. A value of 0 disables the cap.
+// This is intended to be called once, during daemon startup.
+func (s *VolumesService) SetAnonymousVolumeUsageCap(bytes int64) {
+	s.anonymousUsageCap = bytes
+}
+
 // GetDriverList gets the list of registered volume drivers
 func (s *VolumesService) GetDriverList() []string {
 	return s.ds.GetDriverList()
@@ -71,6 +80,15 @@ func (s *VolumesService) GetDriverList() []string {
 // When whatever is going to reference this volume is removed the caller should dereference the volume by calling `Release`.
 func (s *VolumesService) Create(ctx context.Context, name, driverName string, options ...opts.CreateOption) (*volumetypes.Volume, error) {
 	if name == "" {
+		if s.anonymousUsageCap > 0 {
+			usage, err := s.AnonymousVolumesUsage(ctx)
+			if err != nil {
+				return nil, err
+			}
+			if usage >= s.anonymousUsageCap {
+				return nil, errdefs.Forbidden(errors.Errorf("anonymous volume usage cap exceeded: %d/%d bytes in use", usage, s.anonymousUsageCap))
+			}
+		}
 		name = stringid.GenerateRandomID()
 		if driverName == "" {
 			driverName = volume.DefaultDriverName
@@ -206,6 +224,31 @@ func (s *VolumesService) LocalVolumesSize(ctx context.Context) ([]volumetypes.Vo
 	return s.volumesToAPI(ctx, ls, calcSize(true)), nil
 }
 
+// AnonymousVolumesUsage returns the total disk usage, in bytes, of all
+// anonymous volumes across all drivers. It also updates the
+// anonymous_volumes_usage metric to reflect the computed value.
+func (s *VolumesService) AnonymousVolumesUsage(ctx context.Context) (int64, error) {
+	ls, _, err := s.vs.Find(ctx, byLabelFilter(filters.NewArgs(filters.Arg("label", AnonymousLabel))))
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, v := range ls {
+		sz, err := directory.Size(ctx, v.Path())
+		if err != nil {
+			log.G(ctx).WithFields(log.Fields{
+				"error":  err,
+				"volume": v.Name(),
+			}).Warn("could not determine size of volume")
+			continue
+		}
+		total += sz
+	}
+	metrics.AnonymousVolumesUsage.Set(float64(total))
+	return total, nil
+}
+
 // Prune removes (local) volumes which match the past in filter arguments.
 // Note that this intentionally skips volumes with mount options as there would
 // be no space reclaimed in this case.
@@ -61,3 +61,34 @@ func TestLocalVolumeSize(t *testing.T) {
 		}
 	}
 }
+
+func TestAnonymousVolumeUsageCap(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	l, err := local.New(tmpDir, idtools.Identity{UID: os.Getuid(), GID: os.Getegid()})
+	assert.NilError(t, err)
+
+	ds := volumedrivers.NewStore(nil)
+	assert.Assert(t, ds.Register(l, volume.DefaultDriverName))
+
+	service, cleanup := newTestService(t, ds)
+	defer cleanup()
+
+	ctx := context.Background()
+
+	// Fill up an anonymous volume until usage reaches the cap, then verify
+	// further anonymous volume creates are rejected.
+	v1, err := service.Create(ctx, "", volume.DefaultDriverName)
+	assert.NilError(t, err)
+	assert.NilError(t, os.WriteFile(filepath.Join(v1.Mountpoint, "data"), make([]byte, 1024), 0o644))
+
+	service.SetAnonymousVolumeUsageCap(1024)
+
+	_, err = service.Create(ctx, "", volume.DefaultDriverName)
+	assert.Assert(t, is.ErrorContains(err, "anonymous volume usage cap exceeded"))
+
+	// Named volume creates are unaffected by the cap.
+	_, err = service.Create(ctx, "named", volume.DefaultDriverName)
+	assert.NilError(t, err)
+}
@@ -53,6 +53,24 @@ func (m mountSort) parts(i int) int {
 	return strings.Count(filepath.Clean(m[i].Destination), string(os.PathSeparator))
 }
 
+// mountOriginForType classifies a user-specified mount (from --volume,
+// --mount or --tmpfs) by its [mounttypes.Type] for reporting purposes. Mount
+// points that don't originate from an explicit user request, such as
+// volumes-from copies or image-declared volumes, get their origin set
+// elsewhere.
+func mountOriginForType(t mounttypes.Type) volumemounts.MountOrigin {
+	switch t {
+	case mounttypes.TypeBind:
+		return volumemounts.MountOriginBind
+	case mounttypes.TypeVolume:
+		return volumemounts.MountOriginUserVolume
+	case mounttypes.TypeTmpfs:
+		return volumemounts.MountOriginTmpfs
+	default:
+		return ""
+	}
+}
+
 // sortMounts sorts an array of mounts in lexicographic order. This ensure that
 // when mounting, the mounts don't shadow other mounts. For example, if mounting
 // /etc and /etc/resolv.conf, /etc/resolv.conf must not be mounted first.
@@ -61,6 +79,33 @@ func sortMounts(m []container.Mount) []container.Mount {
 	return m
 }
 
+// explicitMountDestinations returns the set of mount destinations explicitly
+// requested by the caller through --volume/--mount/--tmpfs (as opposed to
+// mount points copied in via --volumes-from). It's used to reject a
+// --volumes-from source whose mount points would silently override one of
+// these, instead of the two being merged with the explicit one winning.
+func explicitMountDestinations(ctr *container.Container, parser volumemounts.Parser) (map[string]bool, error) {
+	destinations := map[string]bool{}
+	for _, b := range ctr.HostConfig.Binds {
+		bind, err := parser.ParseMountRaw(b, ctr.HostConfig.VolumeDriver)
+		if err != nil {
+			return nil, err
+		}
+		destinations[bind.Destination] = true
+	}
+	for dest := range ctr.HostConfig.Tmpfs {
+		destinations[dest] = true
+	}
+	for _, cfg := range ctr.HostConfig.Mounts {
+		mp, err := parser.ParseMountSpec(cfg)
+		if err != nil {
+			return nil, errdefs.InvalidParameter(err)
+		}
+		destinations[mp.Destination] = true
+	}
+	return destinations, nil
+}
+
 // registerMountPoints initializes the container mount points with the configured volumes and bind mounts.
 // It follows the next sequence to decide what to mount in each final destination:
 //
@@ -101,6 +146,11 @@ func (daemon *Daemon) registerMountPoints(ctr *container.Container, defaultReadO
 	// 1. Read already configured mount points.
 	maps.Copy(mountPoints, ctr.MountPoints)
 
+	explicitDestinations, err := explicitMountDestinations(ctr, parser)
+	if err != nil {
+		return err
+	}
+
 	// 2. Read volumes from other containers.
 	for _, v := range ctr.HostConfig.VolumesFrom {
 		containerID, mode, err := parser.ParseVolumesFrom(v)
@@ -110,20 +160,26 @@ func (daemon *Daemon) registerMountPoints(ctr *container.Container, defaultReadO
 
 		c, err := daemon.GetContainer(containerID)
 		if err != nil {
-			return errdefs.InvalidParameter(err)
+			return errdefs.InvalidParameter(errors.Wrapf(err, "volumes-from: source container %q not found", containerID))
 		}
 
 		for _, m := range c.MountPoints {
+			if explicitDestinations[m.Destination] {
+				return errdefs.InvalidParameter(errors.Errorf("volumes-from %q conflicts with an explicit mount at destination %q", containerID, m.Destination))
+			}
+
 			cp := &volumemounts.MountPoint{
-				Type:        m.Type,
-				Name:        m.Name,
-				Source:      m.Source,
-				RW:          m.RW && parser.ReadWrite(mode),
-				Driver:      m.Driver,
-				Destination: m.Destination,
-				Propagation: m.Propagation,
-				Spec:        m.Spec,
-				CopyData:    false,
+				Type:          m.Type,
+				Name:          m.Name,
+				Source:        m.Source,
+				RW:            m.RW && parser.ReadWrite(mode),
+				Driver:        m.Driver,
+				Destination:   m.Destination,
+				Propagation:   m.Propagation,
+				Spec:          m.Spec,
+				CopyData:      false,
+				Origin:        volumemounts.MountOriginVolumesFrom,
+				FromContainer: containerID,
 			}
 
 			if cp.Source == "" {
@@ -182,6 +238,8 @@ func (daemon *Daemon) registerMountPoints(ctr *container.Container, defaultReadO
 			}
 		}
 
+		bind.Origin = mountOriginForType(bind.Type)
+
 		binds[bind.Destination] = true
 		dereferenceIfExists(bind.Destination)
 		mountPoints[bind.Destination] = bind
@@ -286,6 +344,8 @@ func (daemon *Daemon) registerMountPoints(ctr *container.Container, defaultReadO
 			// nothing to do
 		}
 
+		mp.Origin = mountOriginForType(mp.Type)
+
 		binds[mp.Destination] = true
 		dereferenceIfExists(mp.Destination)
 		mountPoints[mp.Destination] = mp
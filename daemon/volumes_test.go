@@ -0,0 +1,71 @@
+package daemon
+
+import (
+	"testing"
+
+	containertypes "github.com/moby/moby/api/types/container"
+	mounttypes "github.com/moby/moby/api/types/mount"
+	"github.com/moby/moby/v2/daemon/container"
+	volumemounts "github.com/moby/moby/v2/daemon/volume/mounts"
+	"gotest.tools/v3/assert"
+	is "gotest.tools/v3/assert/cmp"
+)
+
+// TestRegisterMountPointsVolumesFrom covers create-time validation of
+// --volumes-from: a valid source's mount points are copied over, a missing
+// source is reported clearly, and a source mount point that would override
+// an explicit destination mount is rejected instead of silently overridden.
+func TestRegisterMountPointsVolumesFrom(t *testing.T) {
+	newSourceContainer := func() *container.Container {
+		src := newContainerWithState(&container.State{})
+		src.ID = "source"
+		src.Name = "/source"
+		src.HostConfig = &containertypes.HostConfig{}
+		src.MountPoints = map[string]*volumemounts.MountPoint{
+			"/data": {Destination: "/data", Source: "/var/lib/data", Type: mounttypes.TypeBind, RW: true},
+		}
+		return src
+	}
+
+	t.Run("valid source", func(t *testing.T) {
+		d, cleanup := newDaemonWithTmpRoot(t)
+		defer cleanup()
+		src := newSourceContainer()
+		d.containers.Add(src.ID, src)
+
+		ctr := newContainerWithState(&container.State{})
+		ctr.HostConfig = &containertypes.HostConfig{VolumesFrom: []string{"source"}}
+
+		assert.NilError(t, d.registerMountPoints(ctr, false))
+		assert.Check(t, is.Equal(ctr.MountPoints["/data"].Source, "/var/lib/data"))
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		d, cleanup := newDaemonWithTmpRoot(t)
+		defer cleanup()
+
+		ctr := newContainerWithState(&container.State{})
+		ctr.HostConfig = &containertypes.HostConfig{VolumesFrom: []string{"does-not-exist"}}
+
+		err := d.registerMountPoints(ctr, false)
+		assert.Check(t, is.ErrorContains(err, "source container"))
+		assert.Check(t, is.ErrorContains(err, "not found"))
+	})
+
+	t.Run("conflicting destination", func(t *testing.T) {
+		d, cleanup := newDaemonWithTmpRoot(t)
+		defer cleanup()
+		src := newSourceContainer()
+		d.containers.Add(src.ID, src)
+
+		ctr := newContainerWithState(&container.State{})
+		ctr.HostConfig = &containertypes.HostConfig{
+			VolumesFrom: []string{"source"},
+			Binds:       []string{"/host/data:/data"},
+		}
+
+		err := d.registerMountPoints(ctr, false)
+		assert.Check(t, is.ErrorContains(err, "conflicts with an explicit mount"))
+		assert.Check(t, is.ErrorContains(err, "/data"))
+	})
+}
@@ -61,6 +61,73 @@ func (s *DockerAPISuite) TestAPIStatsNoStreamGetCpu(c *testing.T) {
 	assert.Assert(c, cpuPercent != 0.0, "docker stats with no-stream get cpu usage failed: was %v", cpuPercent)
 }
 
+// TestAPIStatsWithInterval verifies that the "interval" query parameter
+// throttles how often samples are emitted while streaming, down-sampling
+// the collector's fixed 1-second cadence.
+func (s *DockerAPISuite) TestAPIStatsWithInterval(c *testing.T) {
+	testRequires(c, DaemonIsLinux)
+	id := runSleepingContainer(c)
+	cli.WaitRun(c, id)
+
+	ctx, cancel := context.WithTimeout(testutil.GetContext(c), 30*time.Second)
+	defer cancel()
+
+	resp, body, err := request.Get(ctx, "/containers/"+id+"/stats?stream=true&interval=2")
+	assert.NilError(c, err)
+	assert.Equal(c, resp.StatusCode, http.StatusOK)
+	defer body.Close()
+
+	dec := json.NewDecoder(body)
+	var first, second container.StatsResponse
+	assert.NilError(c, dec.Decode(&first))
+	assert.NilError(c, dec.Decode(&second))
+
+	gap := second.Read.Sub(first.Read)
+	assert.Check(c, gap >= 1500*time.Millisecond, "expected gap of roughly 2s between samples, got %v", gap)
+}
+
+// TestAPIStatsNoPreCPU verifies that "precpu=false" (combined with
+// "stream=false") returns a single snapshot immediately, leaving
+// PreCPUStats zero-valued instead of waiting for a second sample.
+func (s *DockerAPISuite) TestAPIStatsNoPreCPU(c *testing.T) {
+	id := runSleepingContainer(c)
+	cli.WaitRun(c, id)
+
+	resp, body, err := request.Get(testutil.GetContext(c), "/containers/"+id+"/stats?stream=false&precpu=false")
+	assert.NilError(c, err)
+	assert.Equal(c, resp.StatusCode, http.StatusOK)
+
+	var v container.StatsResponse
+	assert.NilError(c, json.NewDecoder(body).Decode(&v))
+	_ = body.Close()
+
+	assert.Check(c, is.DeepEqual(v.PreCPUStats, container.CPUStats{}))
+	assert.Check(c, v.PreRead.IsZero())
+}
+
+// TestAPIStatsIntervalValidation verifies that an out-of-range "interval"
+// value is rejected with a 400, and that it's accepted (and ignored) when
+// combined with stream=false.
+func (s *DockerAPISuite) TestAPIStatsIntervalValidation(c *testing.T) {
+	id := runSleepingContainer(c)
+	cli.WaitRun(c, id)
+
+	resp, body, err := request.Get(testutil.GetContext(c), "/containers/"+id+"/stats?stream=true&interval=0")
+	assert.NilError(c, err)
+	_ = body.Close()
+	assert.Equal(c, resp.StatusCode, http.StatusBadRequest)
+
+	resp, body, err = request.Get(testutil.GetContext(c), "/containers/"+id+"/stats?stream=true&interval=61")
+	assert.NilError(c, err)
+	_ = body.Close()
+	assert.Equal(c, resp.StatusCode, http.StatusBadRequest)
+
+	resp, body, err = request.Get(testutil.GetContext(c), "/containers/"+id+"/stats?stream=false&interval=0")
+	assert.NilError(c, err)
+	_ = body.Close()
+	assert.Equal(c, resp.StatusCode, http.StatusOK)
+}
+
 func (s *DockerAPISuite) TestAPIStatsStoppedContainerInGoroutines(c *testing.T) {
 	out := cli.DockerCmd(c, "run", "-d", "busybox", "/bin/sh", "-c", "echo 1").Stdout()
 	id := strings.TrimSpace(out)
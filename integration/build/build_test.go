@@ -617,6 +617,61 @@ func TestBuildWithEmptyDockerfile(t *testing.T) {
 	}
 }
 
+func strPtr(s string) *string { return &s }
+
+func TestBuildStrictBuildArgs(t *testing.T) {
+	ctx := setupTest(t)
+
+	const dockerfile = `
+FROM scratch
+ARG USED_ARG
+`
+
+	apiClient := testEnv.APIClient()
+
+	buf := bytes.NewBuffer(nil)
+	w := tar.NewWriter(buf)
+	writeTarRecord(t, w, "Dockerfile", dockerfile)
+	assert.NilError(t, w.Close())
+
+	resp, err := apiClient.ImageBuild(ctx, buf, client.ImageBuildOptions{
+		Remove:      true,
+		ForceRemove: true,
+		BuildArgs: map[string]*string{
+			"USED_ARG":   strPtr("used"),
+			"UNUSED_ARG": strPtr("unused"),
+		},
+		StrictBuildArgs: true,
+	})
+	assert.NilError(t, err)
+	out, readErr := io.ReadAll(resp.Body)
+	assert.Check(t, resp.Body.Close())
+	// StrictBuildArgs surfaces the failure as an error message in the build
+	// output stream, same as any other build error, rather than as a
+	// transport-level error.
+	assert.NilError(t, readErr)
+	assert.Check(t, is.Contains(string(out), "UNUSED_ARG"))
+
+	// The same build without StrictBuildArgs only warns, and still succeeds.
+	buf = bytes.NewBuffer(nil)
+	w = tar.NewWriter(buf)
+	writeTarRecord(t, w, "Dockerfile", dockerfile)
+	assert.NilError(t, w.Close())
+
+	resp, err = apiClient.ImageBuild(ctx, buf, client.ImageBuildOptions{
+		Remove:      true,
+		ForceRemove: true,
+		BuildArgs: map[string]*string{
+			"USED_ARG":   strPtr("used"),
+			"UNUSED_ARG": strPtr("unused"),
+		},
+	})
+	assert.NilError(t, err)
+	_, readErr = io.ReadAll(resp.Body)
+	assert.Check(t, resp.Body.Close())
+	assert.NilError(t, readErr)
+}
+
 func TestBuildPreserveOwnership(t *testing.T) {
 	skip.If(t, testEnv.DaemonInfo.OSType == "windows", "FIXME")
 
@@ -709,6 +764,60 @@ func TestBuildWorkdirNoCacheMiss(t *testing.T) {
 	}
 }
 
+// TestBuildCacheSummary checks that a build's cache-hit summary is reported
+// through the aux stream, and that it correctly reflects a build where some
+// steps hit the cache and others had to be executed.
+func TestBuildCacheSummary(t *testing.T) {
+	ctx := setupTest(t)
+	apiClient := testEnv.APIClient()
+
+	dockerfile := "FROM busybox\nRUN echo one\nRUN echo two\n"
+	source := fakecontext.New(t, "", fakecontext.WithDockerfile(dockerfile))
+	defer source.Close()
+
+	runBuild := func(df string) *build.CacheSummary {
+		assert.NilError(t, source.Add("Dockerfile", df))
+		resp, err := apiClient.ImageBuild(ctx, source.AsTarReader(t), client.ImageBuildOptions{
+			Version: build.BuilderV1,
+		})
+		assert.NilError(t, err)
+		defer resp.Body.Close()
+		return readCacheSummary(t, resp.Body)
+	}
+
+	summary := runBuild(dockerfile)
+	assert.Assert(t, summary != nil, "expected a cache summary in the build output")
+	assert.Check(t, is.Equal(summary.Total, 2))
+
+	// Change the second step so only the first one hits the cache.
+	summary = runBuild("FROM busybox\nRUN echo one\nRUN echo three\n")
+	assert.Assert(t, summary != nil, "expected a cache summary in the build output")
+	assert.Check(t, is.Equal(summary.Total, 2))
+	assert.Check(t, is.Equal(summary.CacheHits, 1))
+	assert.Check(t, is.Equal(summary.Executed, 1))
+}
+
+func readCacheSummary(t *testing.T, rd io.Reader) *build.CacheSummary {
+	t.Helper()
+	decoder := json.NewDecoder(rd)
+	for {
+		var jm jsonstream.Message
+		if err := decoder.Decode(&jm); err != nil {
+			if err == io.EOF {
+				break
+			}
+			assert.NilError(t, err)
+		}
+		if jm.Aux == nil || jm.ID != "cacheSummary" {
+			continue
+		}
+		var summary build.CacheSummary
+		assert.NilError(t, json.Unmarshal(*jm.Aux, &summary))
+		return &summary
+	}
+	return nil
+}
+
 func TestBuildEmitsImageCreateEvent(t *testing.T) {
 	ctx := setupTest(t)
 
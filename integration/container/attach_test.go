@@ -5,9 +5,10 @@
 	"time"
 
 	"github.com/moby/moby/api/types"
-	"github.com/moby/moby/api/types/container"
+	containertypes "github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/api/types/network"
 	"github.com/moby/moby/client"
+	"github.com/moby/moby/v2/integration/internal/container"
 	systemutil "github.com/moby/moby/v2/integration/internal/system"
 	"github.com/moby/moby/v2/internal/testutil"
 	"github.com/moby/moby/v2/internal/testutil/daemon"
@@ -42,12 +43,12 @@ func TestAttach(t *testing.T) {
 
 			ctx := testutil.StartSpan(ctx, t)
 			resp, err := apiClient.ContainerCreate(ctx, client.ContainerCreateOptions{
-				Config: &container.Config{
+				Config: &containertypes.Config{
 					Image: "busybox",
 					Cmd:   []string{"echo", "hello"},
 					Tty:   tc.tty,
 				},
-				HostConfig:       &container.HostConfig{},
+				HostConfig:       &containertypes.HostConfig{},
 				NetworkingConfig: &network.NetworkingConfig{},
 			})
 			assert.NilError(t, err)
@@ -80,11 +81,11 @@ func TestAttachDisconnectLeak(t *testing.T) {
 	apiClient := d.NewClientT(t)
 
 	resp, err := apiClient.ContainerCreate(ctx, client.ContainerCreateOptions{
-		Config: &container.Config{
+		Config: &containertypes.Config{
 			Image: "busybox",
 			Cmd:   []string{"/bin/sh", "-c", "while true; usleep 100000; done"},
 		},
-		HostConfig:       &container.HostConfig{},
+		HostConfig:       &containertypes.HostConfig{},
 		NetworkingConfig: &network.NetworkingConfig{},
 	})
 	assert.NilError(t, err)
@@ -115,3 +116,46 @@ func TestAttachDisconnectLeak(t *testing.T) {
 
 	poll.WaitOn(t, systemutil.CheckGoroutineCount(ctx, apiClient, nGoroutines), poll.WithTimeout(time.Minute))
 }
+
+// TestAttachStdinOnce verifies that a container created with OpenStdin and
+// StdinOnce closes its stdin once the attached client detaches, rather than
+// leaving it open for a subsequent attach. Without this, a process reading
+// from stdin (like "cat" below) would hang forever once its only writer has
+// gone away.
+func TestAttachStdinOnce(t *testing.T) {
+	ctx := setupTest(t)
+	apiClient := testEnv.APIClient()
+
+	resp, err := apiClient.ContainerCreate(ctx, client.ContainerCreateOptions{
+		Config: &containertypes.Config{
+			Image:     "busybox",
+			Cmd:       []string{"cat"},
+			OpenStdin: true,
+			StdinOnce: true,
+		},
+		HostConfig:       &containertypes.HostConfig{},
+		NetworkingConfig: &network.NetworkingConfig{},
+	})
+	assert.NilError(t, err)
+	cID := resp.ID
+	defer apiClient.ContainerRemove(ctx, cID, client.ContainerRemoveOptions{Force: true})
+
+	attach, err := apiClient.ContainerAttach(ctx, cID, client.ContainerAttachOptions{
+		Stream: true,
+		Stdin:  true,
+	})
+	assert.NilError(t, err)
+
+	_, err = apiClient.ContainerStart(ctx, cID, client.ContainerStartOptions{})
+	assert.NilError(t, err)
+
+	_, err = attach.Conn.Write([]byte("hello\n"))
+	assert.NilError(t, err)
+
+	// Detach without an explicit exit; the daemon should close the
+	// container's stdin on our behalf because StdinOnce is set, causing
+	// "cat" to see EOF and the container to exit on its own.
+	attach.Close()
+
+	poll.WaitOn(t, container.IsStopped(ctx, apiClient, cID), poll.WithTimeout(30*time.Second))
+}
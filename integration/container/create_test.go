@@ -648,6 +648,59 @@ func TestCreateInvalidHostConfig(t *testing.T) {
 	}
 }
 
+func TestCreateReadonlyRootfsWarning(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType == "windows")
+
+	ctx := setupTest(t)
+	apiClient := testEnv.APIClient()
+
+	const warning = "ReadonlyRootfs is set but no writable mounts (tmpfs, volumes, or bind mounts) were found; processes in the container will be unable to write anywhere, including to /tmp"
+
+	testCases := []struct {
+		doc         string
+		hc          container.HostConfig
+		expectedLen int
+	}{
+		{
+			doc:         "no writable mounts",
+			hc:          container.HostConfig{ReadonlyRootfs: true},
+			expectedLen: 1,
+		},
+		{
+			doc: "tmpfs mount",
+			hc: container.HostConfig{
+				ReadonlyRootfs: true,
+				Tmpfs:          map[string]string{"/tmp": ""},
+			},
+			expectedLen: 0,
+		},
+		{
+			doc:         "not readonly",
+			hc:          container.HostConfig{},
+			expectedLen: 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.doc, func(t *testing.T) {
+			t.Parallel()
+			ctx := testutil.StartSpan(ctx, t)
+			cfg := container.Config{
+				Image: "busybox",
+			}
+			resp, err := apiClient.ContainerCreate(ctx, client.ContainerCreateOptions{
+				Config:     &cfg,
+				HostConfig: &tc.hc,
+			})
+			assert.NilError(t, err)
+			assert.Check(t, is.Len(resp.Warnings, tc.expectedLen))
+			if tc.expectedLen > 0 {
+				assert.Check(t, is.Equal(resp.Warnings[0], warning))
+			}
+		})
+	}
+}
+
 func TestCreateValidation(t *testing.T) {
 	tests := []struct {
 		name      string
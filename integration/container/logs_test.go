@@ -8,6 +8,7 @@
 	"time"
 
 	"github.com/moby/moby/api/pkg/stdcopy"
+	containertypes "github.com/moby/moby/api/types/container"
 	"github.com/moby/moby/client"
 	"github.com/moby/moby/v2/daemon/logger/jsonfilelog"
 	"github.com/moby/moby/v2/daemon/logger/local"
@@ -177,6 +178,41 @@ func testLogs(t *testing.T, logDriver string) {
 	}
 }
 
+// TestLogsNonBlockingModeDropStats verifies that when a container logs
+// faster than the log driver can consume, the daemon tracks how much output
+// was discarded and reports it via container inspect.
+func TestLogsNonBlockingModeDropStats(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType == "windows")
+
+	ctx := setupTest(t)
+	apiClient := testEnv.APIClient()
+
+	id := container.Run(ctx, t, apiClient,
+		container.WithCmd("sh", "-c", "while true; do echo aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa; done"),
+		container.WithHostConfig(&containertypes.HostConfig{
+			LogConfig: containertypes.LogConfig{
+				Type: local.Name,
+				Config: map[string]string{
+					"mode":            string(containertypes.LogModeNonBlock),
+					"max-buffer-size": "1b",
+				},
+			},
+		}),
+	)
+	defer apiClient.ContainerRemove(ctx, id, client.ContainerRemoveOptions{Force: true})
+
+	poll.WaitOn(t, func(t poll.LogT) poll.Result {
+		inspect, err := apiClient.ContainerInspect(ctx, id, client.ContainerInspectOptions{})
+		if err != nil {
+			return poll.Error(err)
+		}
+		if inspect.Container.LogDropped != nil && inspect.Container.LogDropped.Messages > 0 {
+			return poll.Success()
+		}
+		return poll.Continue("waiting for dropped log messages to be recorded")
+	}, poll.WithTimeout(30*time.Second))
+}
+
 // This hack strips the escape codes that appear in the Windows TTY output and don't have
 // any effect on the text content.
 // This doesn't handle all escape sequences, only ones that were encountered during testing.
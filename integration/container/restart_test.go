@@ -391,6 +391,12 @@ func TestContainerRestartPolicyOnFailure(t *testing.T) {
 		poll.WaitOn(t, containerRestartCountIs(ctx, apiClient, resp.ID, 3), poll.WithTimeout(waitTimeout))
 		poll.WaitOn(t, testContainer.IsInState(ctx, apiClient, resp.ID, container.StateExited), poll.WithTimeout(waitTimeout))
 
+		inspect, err := apiClient.ContainerInspect(ctx, resp.ID, client.ContainerInspectOptions{})
+		assert.NilError(t, err)
+		assert.Check(t, is.Equal(inspect.Container.RestartCount, 3))
+		assert.Check(t, inspect.Container.LastRestartAt != "")
+		assert.Check(t, is.Equal(inspect.Container.LastRestartReason, "exit code 1"))
+
 		_, err = apiClient.ContainerRestart(ctx, resp.ID, client.ContainerRestartOptions{})
 		assert.NilError(t, err)
 	})
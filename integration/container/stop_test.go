@@ -120,6 +120,34 @@ func TestStopContainerWithTimeout(t *testing.T) {
 	}
 }
 
+// TestStopContainerForwardStopSignal checks that HostConfig.ForwardStopSignal
+// broadcasts the stop signal to all processes in the container, so that
+// `docker stop` can terminate a container gracefully even when its PID 1
+// ignores the stop signal without forwarding it to its children.
+func TestStopContainerForwardStopSignal(t *testing.T) {
+	skip.If(t, testEnv.DaemonInfo.OSType == "windows", "ForwardStopSignal is not implemented on Windows")
+
+	ctx := setupTest(t)
+	apiClient := testEnv.APIClient()
+
+	// PID 1 ignores SIGTERM and never forwards it to the "sleep" child, so
+	// without ForwardStopSignal the container only stops once the timeout
+	// elapses and the daemon falls back to SIGKILL.
+	testCmd := container.WithCmd("sh", "-c", `trap "" TERM; sleep 300 & wait`)
+
+	id := container.Run(ctx, t, apiClient, testCmd, container.WithHostConfig(&containertypes.HostConfig{
+		ForwardStopSignal: true,
+	}))
+
+	timeout := 60
+	_, err := apiClient.ContainerStop(ctx, id, client.ContainerStopOptions{Timeout: &timeout})
+	assert.NilError(t, err)
+
+	// The container should stop well within the timeout, proving the signal
+	// reached the "sleep" child rather than being ignored by PID 1.
+	poll.WaitOn(t, container.IsStopped(ctx, apiClient, id), poll.WithTimeout(10*time.Second))
+}
+
 func TestContainerAPIPostContainerStop(t *testing.T) {
 	apiClient := testEnv.APIClient()
 	ctx := setupTest(t)
@@ -65,6 +65,32 @@ func TestResolvConfLocalhostIPv6(t *testing.T) {
 `))
 }
 
+// Check that the daemon's "--embedded-dns-address" flag overrides the address
+// the embedded DNS resolver listens on inside containers.
+func TestResolvConfEmbeddedDNSAddress(t *testing.T) {
+	// No "/etc/resolv.conf" on Windows.
+	skip.If(t, testEnv.DaemonInfo.OSType == "windows")
+
+	ctx := setupTest(t)
+
+	d := daemon.New(t)
+	d.StartWithBusybox(ctx, t, "--embedded-dns-address=127.0.0.53")
+	defer d.Stop(t)
+
+	c := d.NewClientT(t)
+	defer c.Close()
+
+	result := container.RunAttach(ctx, t, c,
+		container.WithImage("busybox:latest"),
+		container.WithCmd("cat", "/etc/resolv.conf"),
+	)
+	defer c.ContainerRemove(ctx, result.ContainerID, client.ContainerRemoveOptions{
+		Force: true,
+	})
+
+	assert.Check(t, is.Contains(result.Stdout.String(), "nameserver 127.0.0.53"))
+}
+
 // Check that when a container is connected to an internal network, DNS
 // requests sent to daemon's internal DNS resolver are not forwarded to
 // an upstream resolver listening on a localhost address.
@@ -51,6 +51,9 @@ type Ctx struct {
 	requestMethod   string
 	requestURI      string
 	plugins         []Plugin
+	// failMode controls what happens when a plugin is unreachable; the zero
+	// value behaves like FailClosed.
+	failMode FailMode
 	// authReq stores the cached request object for the current transaction
 	authReq *Request
 }
@@ -108,6 +111,10 @@ func (ctx *Ctx) AuthZRequest(w http.ResponseWriter, r *http.Request) error {
 
 		authRes, err := plugin.AuthZRequest(ctx.authReq)
 		if err != nil {
+			if ctx.failMode == FailOpen {
+				logFailOpen(plugin.Name(), "AuthZRequest", err)
+				continue
+			}
 			return fmt.Errorf("plugin %s failed with error: %s", plugin.Name(), err)
 		}
 
@@ -132,6 +139,10 @@ func (ctx *Ctx) AuthZResponse(rm ResponseModifier, r *http.Request) error {
 
 		authRes, err := plugin.AuthZResponse(ctx.authReq)
 		if err != nil {
+			if ctx.failMode == FailOpen {
+				logFailOpen(plugin.Name(), "AuthZResponse", err)
+				continue
+			}
 			return fmt.Errorf("plugin %s failed with error: %s", plugin.Name(), err)
 		}
 
@@ -145,6 +156,15 @@ func (ctx *Ctx) AuthZResponse(rm ResponseModifier, r *http.Request) error {
 	return nil
 }
 
+// logFailOpen prominently logs that a request bypassed an unreachable authz
+// plugin because of a FailOpen configuration.
+func logFailOpen(plugin, call string, err error) {
+	log.G(context.TODO()).WithError(err).WithFields(log.Fields{
+		"plugin": plugin,
+		"call":   call,
+	}).Warn("authorization plugin unreachable: allowing request through because authz-fail-mode is \"open\"")
+}
+
 func isAuthEndpoint(urlPath string) (bool, error) {
 	// eg www.test.com/v1.24/auth/optional?optional1=something&optional2=something (version optional)
 	matched, err := regexp.MatchString(`^[^\/]*\/(v\d[\d\.]*\/)?auth.*`, urlPath)
@@ -9,11 +9,26 @@
 	"github.com/moby/moby/v2/pkg/plugingetter"
 )
 
+// FailMode controls what a Middleware does with a request when one of its
+// plugins cannot be reached.
+type FailMode string
+
+const (
+	// FailClosed denies a request when a plugin is unreachable. This is the
+	// default, preserving the authorization guarantee a configured plugin is
+	// meant to provide.
+	FailClosed FailMode = "closed"
+	// FailOpen allows a request through when a plugin is unreachable,
+	// trading that guarantee for availability.
+	FailOpen FailMode = "open"
+)
+
 // Middleware uses a list of plugins to
 // handle authorization in the API requests.
 type Middleware struct {
-	mu      sync.Mutex
-	plugins []Plugin
+	mu       sync.Mutex
+	plugins  []Plugin
+	failMode FailMode
 }
 
 // NewMiddleware creates a new Middleware
@@ -38,6 +53,20 @@ func (m *Middleware) SetPlugins(names []string) {
 	m.mu.Unlock()
 }
 
+// SetFailMode sets the behavior applied when a plugin is unreachable. An
+// unrecognized mode (including "") behaves like FailClosed.
+func (m *Middleware) SetFailMode(mode FailMode) {
+	m.mu.Lock()
+	m.failMode = mode
+	m.mu.Unlock()
+}
+
+func (m *Middleware) getFailMode() FailMode {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.failMode
+}
+
 // RemovePlugin removes a single plugin from this authz middleware chain
 func (m *Middleware) RemovePlugin(name string) {
 	m.mu.Lock()
@@ -72,6 +101,7 @@ func (m *Middleware) WrapHandler(handler func(ctx context.Context, w http.Respon
 		}
 
 		authCtx := NewCtx(plugins, user, userAuthNMethod, r.Method, r.RequestURI)
+		authCtx.failMode = m.getFailMode()
 
 		if err := authCtx.AuthZRequest(w, r); err != nil {
 			log.G(ctx).Errorf("AuthZRequest for %s %s returned error: %s", r.Method, r.RequestURI, err)
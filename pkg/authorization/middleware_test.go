@@ -1,6 +1,8 @@
 package authorization
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -10,6 +12,52 @@
 	"gotest.tools/v3/assert"
 )
 
+// unreachablePlugin simulates a plugin whose transport is down: every call
+// returns an error, as opposed to a reachable plugin explicitly denying a
+// request via Response.Allow == false.
+type unreachablePlugin struct{}
+
+func (unreachablePlugin) Name() string { return "unreachable-plugin" }
+
+func (unreachablePlugin) AuthZRequest(*Request) (*Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func (unreachablePlugin) AuthZResponse(*Request) (*Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestMiddlewareWrapHandlerUnreachablePlugin(t *testing.T) {
+	handlerCalled := false
+	handler := func(ctx context.Context, w http.ResponseWriter, r *http.Request, vars map[string]string) error {
+		handlerCalled = true
+		return nil
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/containers/json", nil)
+
+	t.Run("closed", func(t *testing.T) {
+		handlerCalled = false
+		m := &Middleware{}
+		setAuthzPlugins(m, []Plugin{unreachablePlugin{}})
+
+		err := m.WrapHandler(handler)(context.Background(), httptest.NewRecorder(), req, nil)
+		assert.ErrorContains(t, err, "unreachable-plugin")
+		assert.Assert(t, !handlerCalled, "handler should not run when the request is denied")
+	})
+
+	t.Run("open", func(t *testing.T) {
+		handlerCalled = false
+		m := &Middleware{}
+		setAuthzPlugins(m, []Plugin{unreachablePlugin{}})
+		m.SetFailMode(FailOpen)
+
+		err := m.WrapHandler(handler)(context.Background(), httptest.NewRecorder(), req, nil)
+		assert.NilError(t, err)
+		assert.Assert(t, handlerCalled, "handler should run when an unreachable plugin fails open")
+	})
+}
+
 func TestMiddleware(t *testing.T) {
 	pluginNames := []string{"testPlugin1", "testPlugin2"}
 	var pluginGetter plugingetter.PluginGetter
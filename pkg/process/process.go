@@ -15,6 +15,18 @@ func Alive(pid int) bool {
 	return alive(pid)
 }
 
+// Comm returns the command name of the process with the given pid, as
+// reported by the OS (e.g. "dockerd"), or an error if it can't be
+// determined, for example because the process has exited, or because comm
+// lookup isn't implemented on this platform. It only considers positive
+// PIDs.
+func Comm(pid int) (string, error) {
+	if pid < 1 {
+		return "", fmt.Errorf("invalid PID (%d): only positive PIDs are allowed", pid)
+	}
+	return comm(pid)
+}
+
 // Kill force-stops a process. It only allows positive PIDs; 0 (all processes
 // in the current process group), -1 (all processes with a PID larger than 1),
 // and negative (-n, all processes in process group "n") values for pid producs
@@ -22,3 +22,11 @@ func zombie(pid int) (bool, error) {
 	}
 	return false, nil
 }
+
+func comm(pid int) (string, error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/comm", pid))
+	if err != nil {
+		return "", err
+	}
+	return string(bytes.TrimSpace(data)), nil
+}
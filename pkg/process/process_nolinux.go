@@ -2,6 +2,12 @@
 
 package process
 
+import "errors"
+
 func zombie(pid int) (bool, error) {
 	return false, nil
 }
+
+func comm(pid int) (string, error) {
+	return "", errors.New("process command name lookup is not implemented on this platform")
+}
@@ -87,6 +87,23 @@ func applyMemoryCgroupInfoV2(info *SysInfo) {
 	info.MemorySwappiness = false
 }
 
+func getCPUBurstV2() bool {
+	_, g, err := cgroups.ParseCgroupFileUnified("/proc/self/cgroup")
+	if err != nil {
+		return false
+	}
+
+	if g == "" {
+		return false
+	}
+
+	cGroupPath := path.Join("/sys/fs/cgroup", g, "cpu.max.burst")
+	if _, err = os.Stat(cGroupPath); os.IsNotExist(err) {
+		return false
+	}
+	return true
+}
+
 func applyCPUCgroupInfoV2(info *SysInfo) {
 	if _, ok := info.cg2Controllers["cpu"]; !ok {
 		info.Warnings = append(info.Warnings, "Unable to find cpu controller")
@@ -95,6 +112,7 @@ func applyCPUCgroupInfoV2(info *SysInfo) {
 	info.CPUShares = true
 	info.CPUCfs = true
 	info.CPURealtime = false
+	info.CPUBurst = getCPUBurstV2()
 }
 
 func applyIOCgroupInfoV2(info *SysInfo) {
@@ -77,6 +77,9 @@ type cgroupCPUInfo struct {
 
 	// Whether CPU real-time scheduler is supported
 	CPURealtime bool
+
+	// Whether CPU CFS burst is supported
+	CPUBurst bool
 }
 
 type cgroupBlkioInfo struct {
@@ -177,6 +177,9 @@ func applyCPUCgroupInfo(info *SysInfo) {
 	if !info.CPURealtime {
 		info.Warnings = append(info.Warnings, "Your kernel does not support CPU realtime scheduler")
 	}
+
+	// CPU CFS burst is only available through cgroup v2's "cpu.max.burst".
+	info.CPUBurst = false
 }
 
 // applyBlkioCgroupInfo adds the blkio cgroup controller information to the info.
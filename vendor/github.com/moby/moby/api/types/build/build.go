@@ -14,3 +14,14 @@
 type Result struct {
 	ID string
 }
+
+// CacheSummary reports how many of the steps in a build were satisfied from
+// the build cache versus actually executed.
+type CacheSummary struct {
+	// Total is the number of cacheable steps in the build.
+	Total int
+	// CacheHits is the number of steps that were satisfied from the cache.
+	CacheHits int
+	// Executed is the number of steps that had to be executed.
+	Executed int
+}
@@ -68,6 +68,18 @@ type MountPoint struct {
 	//
 	// This field is not used on Windows.
 	Propagation mount.Propagation
+
+	// Origin classifies how the mount point came to be attached to the
+	// container: an image-declared VOLUME ("image-volume"), a user-specified
+	// volume ("user-volume"), a bind mount ("bind"), a tmpfs mount
+	// ("tmpfs"), or a mount copied from another container via
+	// --volumes-from ("volumes-from").
+	Origin string `json:",omitempty"`
+
+	// FromContainer is the ID of the container this mount point was copied
+	// from via --volumes-from. It is only set when Origin is
+	// "volumes-from".
+	FromContainer string `json:",omitempty"`
 }
 
 // State stores container's running state
@@ -115,25 +127,33 @@ type Summary struct {
 // InspectResponse is the response for the GET "/containers/{name:.*}/json"
 // endpoint.
 type InspectResponse struct {
-	ID              string `json:"Id"`
-	Created         string
-	Path            string
-	Args            []string
-	State           *State
-	Image           string
-	ResolvConfPath  string
-	HostnamePath    string
-	HostsPath       string
-	LogPath         string
-	Name            string
-	RestartCount    int
-	Driver          string
-	Platform        string
-	MountLabel      string
-	ProcessLabel    string
-	AppArmorProfile string
-	ExecIDs         []string
-	HostConfig      *HostConfig
+	ID             string `json:"Id"`
+	Created        string
+	Path           string
+	Args           []string
+	State          *State
+	Image          string
+	ResolvConfPath string
+	HostnamePath   string
+	HostsPath      string
+	LogPath        string
+	Name           string
+	RestartCount   int
+	// LastRestartAt is the time at which the container was last restarted
+	// by its restart policy, formatted as RFC3339Nano. It's empty if the
+	// container has never been restarted.
+	LastRestartAt string `json:",omitempty"`
+	// LastRestartReason is a short description of why the container was
+	// last restarted by its restart policy, such as its exit code. It's
+	// empty if the container has never been restarted.
+	LastRestartReason string `json:",omitempty"`
+	Driver            string
+	Platform          string
+	MountLabel        string
+	ProcessLabel      string
+	AppArmorProfile   string
+	ExecIDs           []string
+	HostConfig        *HostConfig
 
 	// GraphDriver contains information about the container's graph driver.
 	GraphDriver *storage.DriverData `json:"GraphDriver,omitempty"`
@@ -141,6 +161,11 @@ type InspectResponse struct {
 	// Storage contains information about the storage used for the container's filesystem.
 	Storage *storage.Storage `json:"Storage,omitempty"`
 
+	// LogDropped reports log output discarded because the log driver could
+	// not keep up with the container (backpressure). It is only populated
+	// when the container's logging mode is "non-blocking".
+	LogDropped *LogDropStats `json:",omitempty"`
+
 	SizeRw          *int64 `json:",omitempty"`
 	SizeRootFs      *int64 `json:",omitempty"`
 	Mounts          []MountPoint
@@ -148,4 +173,20 @@ type InspectResponse struct {
 	NetworkSettings *NetworkSettings
 	// ImageManifestDescriptor is the descriptor of a platform-specific manifest of the image used to create the container.
 	ImageManifestDescriptor *ocispec.Descriptor `json:"ImageManifestDescriptor,omitempty"`
+
+	// EffectiveCapabilities is the resolved set of Linux capabilities the
+	// container's process runs with, computed from the daemon's default
+	// capability set together with HostConfig.CapAdd, HostConfig.CapDrop,
+	// and HostConfig.Privileged. It is omitted on platforms without a
+	// capability model, such as Windows.
+	EffectiveCapabilities []string `json:",omitempty"`
+}
+
+// LogDropStats reports how much container log output has been discarded
+// because the configured log driver could not keep up with it.
+type LogDropStats struct {
+	// Messages is the number of log messages dropped.
+	Messages int64
+	// Bytes is the total size, in bytes, of the dropped messages.
+	Bytes int64
 }
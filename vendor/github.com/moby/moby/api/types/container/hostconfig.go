@@ -4,6 +4,7 @@
 	"errors"
 	"fmt"
 	"net/netip"
+	"slices"
 	"strings"
 
 	"github.com/docker/go-units"
@@ -42,6 +43,33 @@ func (c CgroupnsMode) Valid() bool {
 	return c.IsEmpty() || c.IsPrivate() || c.IsHost()
 }
 
+// DNSFailoverStrategy represents the order in which the embedded resolver
+// tries the DNS servers listed in HostConfig.DNS.
+type DNSFailoverStrategy string
+
+// DNS failover strategies for containers
+const (
+	// DNSFailoverInOrder tries servers in the order they were configured,
+	// always starting from the first one. This is the default behavior.
+	DNSFailoverInOrder DNSFailoverStrategy = "in-order"
+	// DNSFailoverRotate round-robins the starting server across queries.
+	DNSFailoverRotate DNSFailoverStrategy = "rotate"
+	// DNSFailoverFastestFirst orders servers by their observed response
+	// latency, trying the historically fastest server first.
+	DNSFailoverFastestFirst DNSFailoverStrategy = "fastest-first"
+)
+
+// Valid indicates whether the DNS failover strategy is a known value; an
+// empty string is valid and means DNSFailoverInOrder.
+func (s DNSFailoverStrategy) Valid() bool {
+	switch s {
+	case "", DNSFailoverInOrder, DNSFailoverRotate, DNSFailoverFastestFirst:
+		return true
+	default:
+		return false
+	}
+}
+
 // Isolation represents the isolation technology of a container. The supported
 // values are platform specific
 type Isolation string
@@ -275,6 +303,15 @@ type DeviceMapping struct {
 type RestartPolicy struct {
 	Name              RestartPolicyMode
 	MaximumRetryCount int
+	// ExitCodes, if non-empty, restricts the "on-failure" policy to restart
+	// only for these exit codes, instead of any non-zero exit code. Must not
+	// overlap with ExcludeExitCodes, and is only valid with the "on-failure"
+	// policy.
+	ExitCodes []int
+	// ExcludeExitCodes, if non-empty, prevents the "on-failure" policy from
+	// restarting for these exit codes. Must not overlap with ExitCodes, and
+	// is only valid with the "on-failure" policy.
+	ExcludeExitCodes []int
 }
 
 type RestartPolicyMode string
@@ -316,6 +353,21 @@ func (rp *RestartPolicy) IsSame(tp *RestartPolicy) bool {
 	return rp.Name == tp.Name && rp.MaximumRetryCount == tp.MaximumRetryCount
 }
 
+// ShouldRestartExitCode reports whether an "on-failure" restart policy should
+// restart the container for the given non-zero exit code, taking the
+// optional ExitCodes/ExcludeExitCodes overrides into account. When neither
+// is set, it returns true, preserving the default "restart on any failure"
+// behavior.
+func (rp *RestartPolicy) ShouldRestartExitCode(exitCode int) bool {
+	if len(rp.ExitCodes) > 0 {
+		return slices.Contains(rp.ExitCodes, exitCode)
+	}
+	if len(rp.ExcludeExitCodes) > 0 {
+		return !slices.Contains(rp.ExcludeExitCodes, exitCode)
+	}
+	return true
+}
+
 // ValidateRestartPolicy validates the given RestartPolicy.
 func ValidateRestartPolicy(policy RestartPolicy) error {
 	switch policy.Name {
@@ -327,12 +379,15 @@ func ValidateRestartPolicy(policy RestartPolicy) error {
 			}
 			return &errInvalidParameter{errors.New(msg)}
 		}
+		if len(policy.ExitCodes) > 0 || len(policy.ExcludeExitCodes) > 0 {
+			return &errInvalidParameter{errors.New("invalid restart policy: exit codes can only be used with 'on-failure'")}
+		}
 		return nil
 	case RestartPolicyOnFailure:
 		if policy.MaximumRetryCount < 0 {
 			return &errInvalidParameter{errors.New("invalid restart policy: maximum retry count cannot be negative")}
 		}
-		return nil
+		return validateRestartExitCodes(policy.ExitCodes, policy.ExcludeExitCodes)
 	case "":
 		// Versions before v25.0.0 created an empty restart-policy "name" as
 		// default. Allow an empty name with "any" MaximumRetryCount for
@@ -343,6 +398,27 @@ func ValidateRestartPolicy(policy RestartPolicy) error {
 	}
 }
 
+// validateRestartExitCodes checks that include and exclude are both made up
+// of valid (0-255) exit codes, and that they don't overlap.
+func validateRestartExitCodes(include, exclude []int) error {
+	included := make(map[int]bool, len(include))
+	for _, code := range include {
+		if code < 0 || code > 255 {
+			return &errInvalidParameter{fmt.Errorf("invalid restart policy: exit code %d in ExitCodes is out of range (0-255)", code)}
+		}
+		included[code] = true
+	}
+	for _, code := range exclude {
+		if code < 0 || code > 255 {
+			return &errInvalidParameter{fmt.Errorf("invalid restart policy: exit code %d in ExcludeExitCodes is out of range (0-255)", code)}
+		}
+		if included[code] {
+			return &errInvalidParameter{fmt.Errorf("invalid restart policy: exit code %d cannot be in both ExitCodes and ExcludeExitCodes", code)}
+		}
+	}
+	return nil
+}
+
 // LogMode is a type to define the available modes for logging
 // These modes affect how logs are handled when log messages start piling up.
 type LogMode string
@@ -383,6 +459,7 @@ type Resources struct {
 	BlkioDeviceWriteIOps []*blkiodev.ThrottleDevice
 	CPUPeriod            int64           `json:"CpuPeriod"`          // CPU CFS (Completely Fair Scheduler) period
 	CPUQuota             int64           `json:"CpuQuota"`           // CPU CFS (Completely Fair Scheduler) quota
+	CPUBurst             int64           `json:"CpuBurst"`           // CPU CFS burst, allowing quota to be temporarily exceeded (requires kernel support)
 	CPURealtimePeriod    int64           `json:"CpuRealtimePeriod"`  // CPU real-time period
 	CPURealtimeRuntime   int64           `json:"CpuRealtimeRuntime"` // CPU real-time runtime
 	CpusetCpus           string          // CpusetCpus 0-2, 0,1
@@ -430,30 +507,46 @@ type HostConfig struct {
 	Annotations     map[string]string `json:",omitempty"` // Arbitrary non-identifying metadata attached to container and provided to the runtime
 
 	// Applicable to UNIX platforms
-	CapAdd          []string          // List of kernel capabilities to add to the container
-	CapDrop         []string          // List of kernel capabilities to remove from the container
-	CgroupnsMode    CgroupnsMode      // Cgroup namespace mode to use for the container
-	DNS             []netip.Addr      `json:"Dns"`        // List of DNS server to lookup
-	DNSOptions      []string          `json:"DnsOptions"` // List of DNSOption to look for
-	DNSSearch       []string          `json:"DnsSearch"`  // List of DNSSearch to look for
-	ExtraHosts      []string          // List of extra hosts
-	GroupAdd        []string          // List of additional groups that the container process will run as
-	IpcMode         IpcMode           // IPC namespace to use for the container
-	Cgroup          CgroupSpec        // Cgroup to use for the container
-	Links           []string          // List of links (in the name:alias form)
-	OomScoreAdj     int               // Container preference for OOM-killing
-	PidMode         PidMode           // PID namespace to use for the container
-	Privileged      bool              // Is the container in privileged mode
-	PublishAllPorts bool              // Should docker publish all exposed port for the container
-	ReadonlyRootfs  bool              // Is the container root filesystem in read-only
-	SecurityOpt     []string          // List of string values to customize labels for MLS systems, such as SELinux.
-	StorageOpt      map[string]string `json:",omitempty"` // Storage driver options per container.
-	Tmpfs           map[string]string `json:",omitempty"` // List of tmpfs (mounts) used for the container
-	UTSMode         UTSMode           // UTS namespace to use for the container
-	UsernsMode      UsernsMode        // The user namespace to use for the container
-	ShmSize         int64             // Total shm memory usage
-	Sysctls         map[string]string `json:",omitempty"` // List of Namespaced sysctls used for the container
-	Runtime         string            `json:",omitempty"` // Runtime to use with this container
+	CapAdd       []string     // List of kernel capabilities to add to the container
+	CapDrop      []string     // List of kernel capabilities to remove from the container
+	CgroupnsMode CgroupnsMode // Cgroup namespace mode to use for the container
+	DNS          []netip.Addr `json:"Dns"`        // List of DNS server to lookup
+	DNSOptions   []string     `json:"DnsOptions"` // List of DNSOption to look for
+	DNSSearch    []string     `json:"DnsSearch"`  // List of DNSSearch to look for
+	// DNSFailoverStrategy controls the order in which the embedded resolver
+	// tries the servers listed in DNS.
+	DNSFailoverStrategy DNSFailoverStrategy `json:"DnsFailoverStrategy,omitempty"`
+	ExtraHosts          []string            // List of extra hosts
+	// ExtraHostsFile is the path to a host file, on the daemon host, whose
+	// entries are merged into the container's /etc/hosts at start, in
+	// addition to ExtraHosts. The path must be located within one of the
+	// daemon's configured allowed directories.
+	ExtraHostsFile string
+	// ResolvConfTemplate is the path to a resolv.conf file, on the daemon
+	// host, that the daemon uses verbatim as the container's /etc/resolv.conf
+	// instead of generating one from the host's configuration and DNS,
+	// DNSSearch and DNSOptions. The path must be located within one of the
+	// daemon's configured allowed directories, and must parse as a valid
+	// resolv.conf.
+	ResolvConfTemplate string
+	GroupAdd           []string          // List of additional groups that the container process will run as
+	IpcMode            IpcMode           // IPC namespace to use for the container
+	Cgroup             CgroupSpec        // Cgroup to use for the container
+	Links              []string          // List of links (in the name:alias form)
+	OomScoreAdj        int               // Container preference for OOM-killing
+	PidMode            PidMode           // PID namespace to use for the container
+	Privileged         bool              // Is the container in privileged mode
+	PublishAllPorts    bool              // Should docker publish all exposed port for the container
+	ReadonlyRootfs     bool              // Is the container root filesystem in read-only
+	SecurityOpt        []string          // List of string values to customize labels for MLS systems, such as SELinux.
+	StorageOpt         map[string]string `json:",omitempty"` // Storage driver options per container.
+	Tmpfs              map[string]string `json:",omitempty"` // List of tmpfs (mounts) used for the container
+	UTSMode            UTSMode           // UTS namespace to use for the container
+	UsernsMode         UsernsMode        // The user namespace to use for the container
+	ShmSize            int64             // Total shm memory usage
+	ShmOptions         []string          `json:",omitempty"` // Options for the /dev/shm mount, e.g. "noexec" or "mode=1777". When unset, daemon defaults apply.
+	Sysctls            map[string]string `json:",omitempty"` // List of Namespaced sysctls used for the container
+	Runtime            string            `json:",omitempty"` // Runtime to use with this container
 
 	// Applicable to Windows
 	Isolation Isolation // Isolation technology of the container (e.g. default, hyperv)
@@ -472,6 +565,41 @@ type HostConfig struct {
 
 	// Run a custom init inside the container, if null, use the daemon's configured settings
 	Init *bool `json:",omitempty"`
+
+	// InitArgs are additional arguments passed to the init process when Init
+	// (or the daemon's default init setting) is enabled. It has no effect
+	// when init is disabled.
+	InitArgs []string `json:",omitempty"`
+
+	// TimeNsOffsetSeconds shifts the container's boot-time and monotonic
+	// clocks by the given number of seconds, using a Linux time namespace.
+	// It is intended for testing time-sensitive applications. When nil
+	// (the default), the container shares the host's clock. Requires a
+	// kernel with time namespace support; the daemon returns an error at
+	// create time if the offset is set but the kernel does not support it.
+	TimeNsOffsetSeconds *int64 `json:",omitempty"`
+
+	// ForwardStopSignal broadcasts the stop signal to every process in the
+	// container's PID namespace, instead of only PID 1. Enable this when the
+	// container's PID 1 does not forward signals to its children, so that
+	// `docker stop` can terminate the container gracefully instead of falling
+	// back to SIGKILL. Defaults to false.
+	ForwardStopSignal bool `json:",omitempty"`
+
+	// InheritImageLabels controls whether the image's config labels are
+	// merged onto the container's effective labels. If nil or true (the
+	// default, for backward compatibility), image labels are inherited
+	// unless overridden by a user-supplied label of the same name. If
+	// false, only user-supplied labels are used.
+	InheritImageLabels *bool `json:",omitempty"`
+
+	// RngDevice is the path, on the host, of a character device to bind into
+	// the container as its random number generator source (e.g. a hardware
+	// RNG such as /dev/hwrng), instead of the default /dev/random and
+	// /dev/urandom. The path must refer to an existing character device.
+	// When empty (the default), the container gets the daemon's default RNG
+	// devices.
+	RngDevice string `json:",omitempty"`
 }
 
 // containerID splits "container:<ID|name>" values. It returns the container
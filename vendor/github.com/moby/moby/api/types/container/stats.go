@@ -102,6 +102,24 @@ type BlkioStats struct {
 	IoMergedRecursive       []BlkioStatEntry `json:"io_merged_recursive"`
 	IoTimeRecursive         []BlkioStatEntry `json:"io_time_recursive"`
 	SectorsRecursive        []BlkioStatEntry `json:"sectors_recursive"`
+
+	// Latency holds block IO read/write latency percentiles (in
+	// nanoseconds), when available. It is only populated on a cgroup v2
+	// host whose kernel reports per-device latency percentiles in
+	// "io.stat"; it is nil on cgroup v1 hosts, and on cgroup v2 hosts
+	// where the kernel doesn't report this data.
+	Latency *BlkioLatencyStats `json:"latency,omitempty"`
+}
+
+// BlkioLatencyStats holds block IO latency percentiles for reads and
+// writes, in nanoseconds.
+type BlkioLatencyStats struct {
+	ReadP50  uint64 `json:"read_p50"`
+	ReadP95  uint64 `json:"read_p95"`
+	ReadP99  uint64 `json:"read_p99"`
+	WriteP50 uint64 `json:"write_p50"`
+	WriteP95 uint64 `json:"write_p95"`
+	WriteP99 uint64 `json:"write_p99"`
 }
 
 // StorageStats is the disk I/O stats for read/write on Windows.
@@ -174,6 +192,13 @@ type StatsResponse struct {
 	// This field is omitted if the container has no networking enabled.
 	Networks map[string]NetworkStats `json:"networks,omitempty"`
 
+	// NetworksFlat contains the same per-interface network statistics as
+	// Networks, flattened into prefixed keys (e.g. "eth0_rx_bytes") for
+	// consumers that prefer a flat representation, such as some metrics
+	// exporters. It is only populated when flattened stats were requested,
+	// in which case Networks is omitted.
+	NetworksFlat map[string]uint64 `json:"networks_flat,omitempty"`
+
 	// -------------------------------------------------------------------------
 	// Linux-specific stats, not populated on Windows.
 	// -------------------------------------------------------------------------
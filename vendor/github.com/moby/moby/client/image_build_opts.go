@@ -63,6 +63,10 @@ type ImageBuildOptions struct {
 	// Outputs defines configurations for exporting build results. Only supported
 	// in BuildKit mode
 	Outputs []ImageBuildOutput
+	// StrictBuildArgs turns an unused --build-arg (one with no matching ARG
+	// instruction anywhere in the Dockerfile) into a build failure, instead
+	// of just the daemon's usual warning. Requires API version 1.51 or up.
+	StrictBuildArgs bool
 }
 
 // ImageBuildOutput defines configuration for exporting a build result